@@ -0,0 +1,482 @@
+package a2a
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/jonwraymond/toolprotocol/wire"
+)
+
+// Transport protocols an agent interface can be served over.
+const (
+	TransportJSONRPC  = "JSONRPC"
+	TransportGRPC     = "GRPC"
+	TransportHTTPJSON = "HTTP+JSON"
+)
+
+// Security scheme types.
+const (
+	SecuritySchemeAPIKey        = "apiKey"
+	SecuritySchemeHTTP          = "http"
+	SecuritySchemeOAuth2        = "oauth2"
+	SecuritySchemeOpenIDConnect = "openIdConnect"
+	SecuritySchemeMutualTLS     = "mutualTLS"
+)
+
+// AgentCard is the A2A self-description document served at
+// /.well-known/agent-card.json.
+type AgentCard struct {
+	// ProtocolVersion is the A2A protocol version the agent implements.
+	ProtocolVersion string `json:"protocolVersion"`
+
+	// Name is the human-readable agent name.
+	Name string `json:"name"`
+
+	// Description explains what the agent does.
+	Description string `json:"description"`
+
+	// URL is the preferred endpoint for the agent.
+	URL string `json:"url"`
+
+	// PreferredTransport is the transport served at URL. Empty means JSONRPC.
+	PreferredTransport string `json:"preferredTransport,omitempty"`
+
+	// AdditionalInterfaces lists other endpoints and transports.
+	AdditionalInterfaces []AgentInterface `json:"additionalInterfaces,omitempty"`
+
+	// IconURL is an optional icon for the agent.
+	IconURL string `json:"iconUrl,omitempty"`
+
+	// Provider describes the organization operating the agent.
+	Provider *AgentProvider `json:"provider,omitempty"`
+
+	// Version is the agent's own version.
+	Version string `json:"version"`
+
+	// DocumentationURL links to human-readable documentation.
+	DocumentationURL string `json:"documentationUrl,omitempty"`
+
+	// Capabilities declares optional protocol features.
+	Capabilities AgentCapabilities `json:"capabilities"`
+
+	// SecuritySchemes declares the authentication schemes by name.
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+
+	// Security lists alternative scheme requirements; each entry maps a
+	// scheme name to the scopes it needs.
+	Security []map[string][]string `json:"security,omitempty"`
+
+	// DefaultInputModes are the media types accepted by all skills.
+	DefaultInputModes []string `json:"defaultInputModes"`
+
+	// DefaultOutputModes are the media types produced by all skills.
+	DefaultOutputModes []string `json:"defaultOutputModes"`
+
+	// Skills lists what the agent can do.
+	Skills []AgentSkill `json:"skills"`
+
+	// SupportsAuthenticatedExtendedCard indicates a richer card is served
+	// to authenticated clients.
+	SupportsAuthenticatedExtendedCard bool `json:"supportsAuthenticatedExtendedCard,omitempty"`
+
+	// Signatures are JWS signatures over the card. See [AgentCard.Sign].
+	Signatures []AgentCardSignature `json:"signatures,omitempty"`
+}
+
+// AgentProvider identifies the organization operating an agent.
+type AgentProvider struct {
+	Organization string `json:"organization"`
+	URL          string `json:"url"`
+}
+
+// AgentInterface is an endpoint and the transport it speaks.
+type AgentInterface struct {
+	URL       string `json:"url"`
+	Transport string `json:"transport"`
+}
+
+// AgentCapabilities declares optional protocol features.
+type AgentCapabilities struct {
+	Streaming              bool             `json:"streaming,omitempty"`
+	PushNotifications      bool             `json:"pushNotifications,omitempty"`
+	StateTransitionHistory bool             `json:"stateTransitionHistory,omitempty"`
+	Extensions             []AgentExtension `json:"extensions,omitempty"`
+}
+
+// AgentExtension declares a protocol extension supported by the agent.
+type AgentExtension struct {
+	URI         string         `json:"uri"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Params      map[string]any `json:"params,omitempty"`
+}
+
+// AgentSkill is a capability the agent offers.
+type AgentSkill struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Tags        []string              `json:"tags"`
+	Examples    []string              `json:"examples,omitempty"`
+	InputModes  []string              `json:"inputModes,omitempty"`
+	OutputModes []string              `json:"outputModes,omitempty"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// SecurityScheme describes how clients authenticate. Which fields apply
+// depends on Type.
+type SecurityScheme struct {
+	// Type is one of the SecurityScheme* constants.
+	Type string `json:"type"`
+
+	// Description is optional human-readable guidance.
+	Description string `json:"description,omitempty"`
+
+	// Name and In locate the API key (apiKey). In is "query", "header" or "cookie".
+	Name string `json:"name,omitempty"`
+	In   string `json:"in,omitempty"`
+
+	// Scheme and BearerFormat describe HTTP authentication (http).
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+
+	// Flows and OAuth2MetadataURL describe OAuth 2.0 (oauth2).
+	Flows             *OAuthFlows `json:"flows,omitempty"`
+	OAuth2MetadataURL string      `json:"oauth2MetadataUrl,omitempty"`
+
+	// OpenIDConnectURL is the discovery document (openIdConnect).
+	OpenIDConnectURL string `json:"openIdConnectUrl,omitempty"`
+}
+
+// OAuthFlows lists the supported OAuth 2.0 flows.
+type OAuthFlows struct {
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+}
+
+// OAuthFlow configures a single OAuth 2.0 flow.
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// AgentCardSignature is a detached JWS over the canonical card.
+type AgentCardSignature struct {
+	// Protected is the base64url-encoded JWS protected header.
+	Protected string `json:"protected"`
+
+	// Signature is the base64url-encoded signature.
+	Signature string `json:"signature"`
+
+	// Header holds optional unprotected header parameters.
+	Header map[string]any `json:"header,omitempty"`
+}
+
+// Validate checks that required fields are present and that security
+// requirements, skills and interfaces are consistent. The first problem
+// found is returned wrapped in ErrInvalidCard.
+func (c *AgentCard) Validate() error {
+	switch {
+	case c.ProtocolVersion == "":
+		return invalidCard("protocolVersion required")
+	case c.Name == "":
+		return invalidCard("name required")
+	case c.Description == "":
+		return invalidCard("description required")
+	case c.Version == "":
+		return invalidCard("version required")
+	case len(c.DefaultInputModes) == 0:
+		return invalidCard("defaultInputModes required")
+	case len(c.DefaultOutputModes) == 0:
+		return invalidCard("defaultOutputModes required")
+	case len(c.Skills) == 0:
+		return invalidCard("at least one skill required")
+	}
+	if err := validateURL("url", c.URL); err != nil {
+		return err
+	}
+	if c.PreferredTransport != "" && !validTransport(c.PreferredTransport) {
+		return invalidCard("unknown preferredTransport %q", c.PreferredTransport)
+	}
+	for i, iface := range c.AdditionalInterfaces {
+		if err := validateURL(fmt.Sprintf("additionalInterfaces[%d].url", i), iface.URL); err != nil {
+			return err
+		}
+		if !validTransport(iface.Transport) {
+			return invalidCard("additionalInterfaces[%d]: unknown transport %q", i, iface.Transport)
+		}
+	}
+	if c.Provider != nil {
+		if c.Provider.Organization == "" {
+			return invalidCard("provider.organization required")
+		}
+		if err := validateURL("provider.url", c.Provider.URL); err != nil {
+			return err
+		}
+	}
+	for i, ext := range c.Capabilities.Extensions {
+		if ext.URI == "" {
+			return invalidCard("capabilities.extensions[%d].uri required", i)
+		}
+	}
+	for name, scheme := range c.SecuritySchemes {
+		if err := scheme.validate(); err != nil {
+			return invalidCard("securitySchemes[%s]: %v", name, err)
+		}
+	}
+	if err := c.validateRequirements("security", c.Security); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(c.Skills))
+	for i, s := range c.Skills {
+		field := fmt.Sprintf("skills[%d]", i)
+		switch {
+		case s.ID == "":
+			return invalidCard("%s.id required", field)
+		case seen[s.ID]:
+			return invalidCard("%s: duplicate skill id %q", field, s.ID)
+		case s.Name == "":
+			return invalidCard("%s.name required", field)
+		case s.Description == "":
+			return invalidCard("%s.description required", field)
+		}
+		seen[s.ID] = true
+		if err := c.validateRequirements(field+".security", s.Security); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateRequirements checks that every requirement names a declared scheme.
+func (c *AgentCard) validateRequirements(field string, reqs []map[string][]string) error {
+	for i, req := range reqs {
+		for name := range req {
+			if _, ok := c.SecuritySchemes[name]; !ok {
+				return invalidCard("%s[%d]: undeclared security scheme %q", field, i, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (s SecurityScheme) validate() error {
+	switch s.Type {
+	case SecuritySchemeAPIKey:
+		if s.Name == "" {
+			return fmt.Errorf("apiKey name required")
+		}
+		switch s.In {
+		case "query", "header", "cookie":
+		default:
+			return fmt.Errorf("apiKey in must be query, header or cookie")
+		}
+	case SecuritySchemeHTTP:
+		if s.Scheme == "" {
+			return fmt.Errorf("http scheme required")
+		}
+	case SecuritySchemeOAuth2:
+		if s.Flows == nil {
+			return fmt.Errorf("oauth2 flows required")
+		}
+		f := s.Flows
+		if f.AuthorizationCode == nil && f.ClientCredentials == nil && f.Implicit == nil && f.Password == nil {
+			return fmt.Errorf("oauth2 requires at least one flow")
+		}
+		if f.AuthorizationCode != nil && (f.AuthorizationCode.AuthorizationURL == "" || f.AuthorizationCode.TokenURL == "") {
+			return fmt.Errorf("authorizationCode flow requires authorizationUrl and tokenUrl")
+		}
+		if f.ClientCredentials != nil && f.ClientCredentials.TokenURL == "" {
+			return fmt.Errorf("clientCredentials flow requires tokenUrl")
+		}
+		if f.Implicit != nil && f.Implicit.AuthorizationURL == "" {
+			return fmt.Errorf("implicit flow requires authorizationUrl")
+		}
+		if f.Password != nil && f.Password.TokenURL == "" {
+			return fmt.Errorf("password flow requires tokenUrl")
+		}
+	case SecuritySchemeOpenIDConnect:
+		if s.OpenIDConnectURL == "" {
+			return fmt.Errorf("openIdConnectUrl required")
+		}
+	case SecuritySchemeMutualTLS:
+	default:
+		return fmt.Errorf("unknown type %q", s.Type)
+	}
+	return nil
+}
+
+func validTransport(t string) bool {
+	switch t {
+	case TransportJSONRPC, TransportGRPC, TransportHTTPJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+func validateURL(field, raw string) error {
+	if raw == "" {
+		return invalidCard("%s required", field)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return invalidCard("%s must be an absolute URL", field)
+	}
+	return nil
+}
+
+func invalidCard(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidCard, fmt.Sprintf(format, args...))
+}
+
+// CardBuilder provides a fluent interface for building agent cards.
+type CardBuilder struct {
+	card  AgentCard
+	agent Agent
+}
+
+// NewCardBuilder creates a builder for an agent served at url. The card
+// defaults to the wire A2A protocol version, the JSONRPC transport and
+// text/plain input and output modes.
+func NewCardBuilder(name, url string) *CardBuilder {
+	return &CardBuilder{
+		card: AgentCard{
+			ProtocolVersion:    wire.A2AVersion,
+			Name:               name,
+			URL:                url,
+			PreferredTransport: TransportJSONRPC,
+			DefaultInputModes:  []string{"text/plain"},
+			DefaultOutputModes: []string{"text/plain"},
+		},
+	}
+}
+
+// WithDescription sets the agent description.
+func (b *CardBuilder) WithDescription(description string) *CardBuilder {
+	b.card.Description = description
+	return b
+}
+
+// WithVersion sets the agent version.
+func (b *CardBuilder) WithVersion(version string) *CardBuilder {
+	b.card.Version = version
+	return b
+}
+
+// WithProvider sets the operating organization.
+func (b *CardBuilder) WithProvider(organization, url string) *CardBuilder {
+	b.card.Provider = &AgentProvider{Organization: organization, URL: url}
+	return b
+}
+
+// WithDocumentationURL sets the documentation link.
+func (b *CardBuilder) WithDocumentationURL(url string) *CardBuilder {
+	b.card.DocumentationURL = url
+	return b
+}
+
+// WithCapabilities sets the declared capabilities.
+func (b *CardBuilder) WithCapabilities(caps AgentCapabilities) *CardBuilder {
+	b.card.Capabilities = caps
+	return b
+}
+
+// WithExtension adds a supported protocol extension.
+func (b *CardBuilder) WithExtension(ext AgentExtension) *CardBuilder {
+	b.card.Capabilities.Extensions = append(b.card.Capabilities.Extensions, ext)
+	return b
+}
+
+// WithInputModes replaces the default input media types.
+func (b *CardBuilder) WithInputModes(modes ...string) *CardBuilder {
+	b.card.DefaultInputModes = modes
+	return b
+}
+
+// WithOutputModes replaces the default output media types.
+func (b *CardBuilder) WithOutputModes(modes ...string) *CardBuilder {
+	b.card.DefaultOutputModes = modes
+	return b
+}
+
+// WithInterface adds an additional endpoint.
+func (b *CardBuilder) WithInterface(url, transport string) *CardBuilder {
+	b.card.AdditionalInterfaces = append(b.card.AdditionalInterfaces, AgentInterface{URL: url, Transport: transport})
+	return b
+}
+
+// WithSecurityScheme declares a named security scheme.
+func (b *CardBuilder) WithSecurityScheme(name string, scheme SecurityScheme) *CardBuilder {
+	if b.card.SecuritySchemes == nil {
+		b.card.SecuritySchemes = make(map[string]SecurityScheme)
+	}
+	b.card.SecuritySchemes[name] = scheme
+	return b
+}
+
+// WithSecurity adds an alternative security requirement.
+func (b *CardBuilder) WithSecurity(requirement map[string][]string) *CardBuilder {
+	b.card.Security = append(b.card.Security, requirement)
+	return b
+}
+
+// WithSkill adds a skill.
+func (b *CardBuilder) WithSkill(skill AgentSkill) *CardBuilder {
+	b.card.Skills = append(b.card.Skills, skill)
+	return b
+}
+
+// WithSkillsFrom derives skills from agent.ListSkills when Build is called.
+// Derived skills follow any added with WithSkill.
+func (b *CardBuilder) WithSkillsFrom(agent Agent) *CardBuilder {
+	b.agent = agent
+	return b
+}
+
+// Build derives skills, validates the card and returns it.
+func (b *CardBuilder) Build(ctx context.Context) (*AgentCard, error) {
+	card := b.card
+	card.Skills = append([]AgentSkill(nil), b.card.Skills...)
+	if b.agent != nil {
+		tools, err := b.agent.ListSkills(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list skills: %w", err)
+		}
+		for _, t := range tools {
+			card.Skills = append(card.Skills, SkillFromTool(t))
+		}
+	}
+	if err := card.Validate(); err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// SkillFromTool converts a wire tool to an agent skill. The tool name is
+// used as ID and as the description when the tool has none; tools with an
+// input schema accept application/json.
+func SkillFromTool(t wire.Tool) AgentSkill {
+	skill := AgentSkill{
+		ID:          t.Name,
+		Name:        t.Name,
+		Description: t.Description,
+		Tags:        []string{},
+	}
+	if skill.Description == "" {
+		skill.Description = t.Name
+	}
+	if t.InputSchema != nil {
+		skill.InputModes = []string{"application/json"}
+	}
+	if t.OutputSchema != nil {
+		skill.OutputModes = []string{"application/json"}
+	}
+	return skill
+}
@@ -0,0 +1,241 @@
+package a2a
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // register SHA-256 for crypto.Hash
+	_ "crypto/sha512" // register SHA-384 and SHA-512 for crypto.Hash
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jonwraymond/toolprotocol/wire"
+)
+
+// JWS algorithms supported for agent card signatures.
+const (
+	AlgES256 = "ES256"
+	AlgES384 = "ES384"
+	AlgES512 = "ES512"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// JWSHeader is the protected header of an agent card signature.
+type JWSHeader struct {
+	// Alg is the signing algorithm. Sign derives it from the key when empty.
+	Alg string `json:"alg"`
+
+	// Typ is the media type of the JWS. Sign defaults it to "JOSE".
+	Typ string `json:"typ,omitempty"`
+
+	// Kid identifies the signing key.
+	Kid string `json:"kid,omitempty"`
+
+	// JKU is a URL of a JWK Set containing the signing key.
+	JKU string `json:"jku,omitempty"`
+}
+
+// KeyFunc resolves the public key for a signature's protected header.
+type KeyFunc func(header JWSHeader) (crypto.PublicKey, error)
+
+// Sign appends a detached JWS signature computed over the card's
+// canonical JSON form (RFC 8785) with the signatures field omitted.
+//
+// ECDSA P-256/P-384/P-521, RSA (PKCS #1 v1.5 with SHA-256) and Ed25519
+// keys are supported.
+func (c *AgentCard) Sign(signer crypto.Signer, header JWSHeader) error {
+	alg, err := jwsAlgorithm(signer.Public())
+	if err != nil {
+		return err
+	}
+	if header.Alg != "" && header.Alg != alg {
+		return fmt.Errorf("%w: %s key cannot sign %s", ErrUnsupportedAlgorithm, alg, header.Alg)
+	}
+	header.Alg = alg
+	if header.Typ == "" {
+		header.Typ = "JOSE"
+	}
+
+	rawHeader, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("sign agent card: %w", err)
+	}
+	payload, err := c.signingPayload()
+	if err != nil {
+		return err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(rawHeader)
+	input := protected + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	sig, err := jwsSign(signer, alg, []byte(input))
+	if err != nil {
+		return fmt.Errorf("sign agent card: %w", err)
+	}
+	c.Signatures = append(c.Signatures, AgentCardSignature{
+		Protected: protected,
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	})
+	return nil
+}
+
+// Verify checks the card's signatures against keys resolved by keyFunc.
+// It succeeds when at least one signature verifies; signatures whose key
+// cannot be resolved are skipped.
+func (c *AgentCard) Verify(keyFunc KeyFunc) error {
+	if len(c.Signatures) == 0 {
+		return ErrUnsigned
+	}
+	payload, err := c.signingPayload()
+	if err != nil {
+		return err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	var errs []error
+	for i, s := range c.Signatures {
+		if err := verifySignature(s, encoded, keyFunc); err != nil {
+			errs = append(errs, fmt.Errorf("signatures[%d]: %w", i, err))
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidSignature, errors.Join(errs...))
+}
+
+func verifySignature(s AgentCardSignature, payload string, keyFunc KeyFunc) error {
+	rawHeader, err := base64.RawURLEncoding.DecodeString(s.Protected)
+	if err != nil {
+		return fmt.Errorf("decode protected header: %w", err)
+	}
+	var header JWSHeader
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return fmt.Errorf("decode protected header: %w", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(s.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	key, err := keyFunc(header)
+	if err != nil {
+		return fmt.Errorf("resolve key: %w", err)
+	}
+	// The key, not the header, decides the algorithm family.
+	alg, err := jwsAlgorithm(key)
+	if err != nil {
+		return err
+	}
+	if alg != header.Alg {
+		return fmt.Errorf("%w: header alg %q does not match %s key", ErrUnsupportedAlgorithm, header.Alg, alg)
+	}
+	if !jwsVerify(key, alg, []byte(s.Protected+"."+payload), sig) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// signingPayload returns the canonical JSON of the card without signatures.
+func (c *AgentCard) signingPayload() ([]byte, error) {
+	unsigned := *c
+	unsigned.Signatures = nil
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("encode agent card: %w", err)
+	}
+	return wire.Canonicalize(data)
+}
+
+// jwsAlgorithm returns the JWS algorithm for a public key.
+func jwsAlgorithm(pub crypto.PublicKey) (string, error) {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return AlgES256, nil
+		case elliptic.P384():
+			return AlgES384, nil
+		case elliptic.P521():
+			return AlgES512, nil
+		}
+		return "", fmt.Errorf("%w: ecdsa curve %s", ErrUnsupportedAlgorithm, k.Curve.Params().Name)
+	case *rsa.PublicKey:
+		return AlgRS256, nil
+	case ed25519.PublicKey:
+		return AlgEdDSA, nil
+	}
+	return "", fmt.Errorf("%w: key type %T", ErrUnsupportedAlgorithm, pub)
+}
+
+// jwsHash returns the digest algorithm for alg; EdDSA signs the message directly.
+func jwsHash(alg string) crypto.Hash {
+	switch alg {
+	case AlgES384:
+		return crypto.SHA384
+	case AlgES512:
+		return crypto.SHA512
+	case AlgEdDSA:
+		return 0
+	default:
+		return crypto.SHA256
+	}
+}
+
+func jwsSign(signer crypto.Signer, alg string, input []byte) ([]byte, error) {
+	hash := jwsHash(alg)
+	digest := input
+	if hash != 0 {
+		h := hash.New()
+		h.Write(input)
+		digest = h.Sum(nil)
+	}
+	sig, err := signer.Sign(rand.Reader, digest, hash)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := signer.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return sig, nil
+	}
+	// crypto.Signer yields ASN.1 for ECDSA; JWS uses fixed-width R || S.
+	var rs struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(sig, &rs); err != nil {
+		return nil, err
+	}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	out := make([]byte, 2*size)
+	rs.R.FillBytes(out[:size])
+	rs.S.FillBytes(out[size:])
+	return out, nil
+}
+
+func jwsVerify(pub crypto.PublicKey, alg string, input, sig []byte) bool {
+	hash := jwsHash(alg)
+	digest := input
+	if hash != 0 {
+		h := hash.New()
+		h.Write(input)
+		digest = h.Sum(nil)
+	}
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, hash, digest, sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, input, sig)
+	}
+	return false
+}
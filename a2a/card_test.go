@@ -0,0 +1,252 @@
+package a2a
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolprotocol/wire"
+)
+
+func validCard() *AgentCard {
+	return &AgentCard{
+		ProtocolVersion:    wire.A2AVersion,
+		Name:               "search",
+		Description:        "Searches documents",
+		URL:                "https://agent.example.com/a2a",
+		Version:            "1.0.0",
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+		SecuritySchemes: map[string]SecurityScheme{
+			"bearer": {Type: SecuritySchemeHTTP, Scheme: "bearer"},
+		},
+		Security: []map[string][]string{{"bearer": {}}},
+		Skills: []AgentSkill{
+			{ID: "search", Name: "Search", Description: "Full-text search", Tags: []string{"search"}},
+		},
+	}
+}
+
+func TestAgentCard_Validate(t *testing.T) {
+	if err := validCard().Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*AgentCard)
+		want   string
+	}{
+		{"missing name", func(c *AgentCard) { c.Name = "" }, "name required"},
+		{"relative url", func(c *AgentCard) { c.URL = "/a2a" }, "url must be an absolute URL"},
+		{"no skills", func(c *AgentCard) { c.Skills = nil }, "at least one skill"},
+		{"no input modes", func(c *AgentCard) { c.DefaultInputModes = nil }, "defaultInputModes"},
+		{"bad transport", func(c *AgentCard) { c.PreferredTransport = "SOAP" }, "preferredTransport"},
+		{"duplicate skill", func(c *AgentCard) { c.Skills = append(c.Skills, c.Skills[0]) }, "duplicate skill id"},
+		{"skill without description", func(c *AgentCard) { c.Skills[0].Description = "" }, "skills[0].description"},
+		{"undeclared scheme", func(c *AgentCard) { c.Security = []map[string][]string{{"oauth": {"read"}}} }, "undeclared security scheme"},
+		{"undeclared skill scheme", func(c *AgentCard) { c.Skills[0].Security = []map[string][]string{{"key": nil}} }, "skills[0].security"},
+		{"bad api key", func(c *AgentCard) {
+			c.SecuritySchemes["key"] = SecurityScheme{Type: SecuritySchemeAPIKey, Name: "X-Key", In: "body"}
+		}, "apiKey in"},
+		{"oauth without flows", func(c *AgentCard) {
+			c.SecuritySchemes["oauth"] = SecurityScheme{Type: SecuritySchemeOAuth2, Flows: &OAuthFlows{}}
+		}, "at least one flow"},
+		{"unknown scheme type", func(c *AgentCard) { c.SecuritySchemes["x"] = SecurityScheme{Type: "magic"} }, "unknown type"},
+		{"interface transport", func(c *AgentCard) {
+			c.AdditionalInterfaces = []AgentInterface{{URL: "https://agent.example.com/grpc"}}
+		}, "additionalInterfaces[0]"},
+		{"extension uri", func(c *AgentCard) { c.Capabilities.Extensions = []AgentExtension{{}} }, "extensions[0].uri"},
+		{"provider url", func(c *AgentCard) { c.Provider = &AgentProvider{Organization: "Example"} }, "provider.url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := validCard()
+			tt.mutate(card)
+			err := card.Validate()
+			if !errors.Is(err, ErrInvalidCard) {
+				t.Fatalf("Validate() error = %v, want ErrInvalidCard", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %q, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentCard_JSON(t *testing.T) {
+	data, err := json.Marshal(validCard())
+	if err != nil {
+		t.Fatalf("Marshal error = %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Unmarshal error = %v", err)
+	}
+	for _, key := range []string{"protocolVersion", "defaultInputModes", "defaultOutputModes", "securitySchemes", "skills", "capabilities"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("card JSON missing %q", key)
+		}
+	}
+	if _, ok := raw["signatures"]; ok {
+		t.Errorf("unsigned card JSON has signatures")
+	}
+}
+
+func TestCardBuilder_SkillsFromAgent(t *testing.T) {
+	agent := fakeAgent{skills: []wire.Tool{
+		{Name: "echo", Description: "Echo text", InputSchema: map[string]any{"type": "object"}},
+		{Name: "ping"},
+	}}
+	card, err := NewCardBuilder("echo-agent", "https://agent.example.com/a2a").
+		WithDescription("Echoes input").
+		WithVersion("0.1.0").
+		WithProvider("Example", "https://example.com").
+		WithCapabilities(AgentCapabilities{Streaming: true}).
+		WithSkill(AgentSkill{ID: "custom", Name: "Custom", Description: "Hand written", Tags: []string{"x"}}).
+		WithSkillsFrom(agent).
+		Build(context.Background())
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if card.ProtocolVersion != wire.A2AVersion || card.PreferredTransport != TransportJSONRPC {
+		t.Errorf("defaults = %q/%q, want %q/%q", card.ProtocolVersion, card.PreferredTransport, wire.A2AVersion, TransportJSONRPC)
+	}
+	if len(card.Skills) != 3 {
+		t.Fatalf("len(Skills) = %d, want 3", len(card.Skills))
+	}
+	if card.Skills[0].ID != "custom" || card.Skills[1].ID != "echo" {
+		t.Errorf("skills = %+v, want custom then echo", card.Skills)
+	}
+	if got := card.Skills[1].InputModes; len(got) != 1 || got[0] != "application/json" {
+		t.Errorf("echo InputModes = %v, want application/json", got)
+	}
+	if card.Skills[2].Description != "ping" {
+		t.Errorf("ping Description = %q, want name fallback", card.Skills[2].Description)
+	}
+}
+
+func TestCardBuilder_Invalid(t *testing.T) {
+	_, err := NewCardBuilder("agent", "https://agent.example.com").Build(context.Background())
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Build() error = %v, want ErrInvalidCard", err)
+	}
+}
+
+func TestAgentCard_SignVerify(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ec384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		alg    string
+		signer crypto.Signer
+	}{
+		{AlgES256, ecKey},
+		{AlgES384, ec384},
+		{AlgRS256, rsaKey},
+		{AlgEdDSA, edKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			card := validCard()
+			if err := card.Sign(tt.signer, JWSHeader{Kid: "key-1"}); err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			var header JWSHeader
+			keyFunc := func(h JWSHeader) (crypto.PublicKey, error) {
+				header = h
+				return tt.signer.Public(), nil
+			}
+
+			// Signatures survive a JSON round trip.
+			data, _ := json.Marshal(card)
+			var decoded AgentCard
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unmarshal error = %v", err)
+			}
+			if err := decoded.Verify(keyFunc); err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if header.Alg != tt.alg || header.Kid != "key-1" || header.Typ != "JOSE" {
+				t.Errorf("header = %+v, want alg %s, kid key-1, typ JOSE", header, tt.alg)
+			}
+
+			decoded.Description = "tampered"
+			if err := decoded.Verify(keyFunc); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify(tampered) error = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
+
+func TestAgentCard_VerifyErrors(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	card := validCard()
+	if err := card.Verify(nil); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Verify(unsigned) error = %v, want ErrUnsigned", err)
+	}
+	if err := card.Sign(ecKey, JWSHeader{Alg: AlgRS256}); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("Sign(mismatched alg) error = %v, want ErrUnsupportedAlgorithm", err)
+	}
+	if err := card.Sign(ecKey, JWSHeader{Kid: "ec"}); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	wrongKey := func(JWSHeader) (crypto.PublicKey, error) { return other.Public(), nil }
+	if err := card.Verify(wrongKey); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify(wrong key) error = %v, want ErrInvalidSignature", err)
+	}
+	// An Ed25519 key must not verify an ES256 header.
+	wrongType := func(JWSHeader) (crypto.PublicKey, error) { return edKey.Public(), nil }
+	if err := card.Verify(wrongType); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("Verify(wrong key type) error = %v, want ErrUnsupportedAlgorithm", err)
+	}
+
+	// A second signature by a known key is enough.
+	if err := card.Sign(edKey, JWSHeader{Kid: "ed"}); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	onlyEd := func(h JWSHeader) (crypto.PublicKey, error) {
+		if h.Kid != "ed" {
+			return nil, errors.New("unknown key")
+		}
+		return edKey.Public(), nil
+	}
+	if err := card.Verify(onlyEd); err != nil {
+		t.Errorf("Verify(second signature) error = %v", err)
+	}
+}
+
+func TestHandler_ServeAgentCard_Validates(t *testing.T) {
+	card := validCard()
+	card.Skills = nil
+	h := NewHandler(typedCardAgent{card: card}, nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeAgentCard(rec, httptest.NewRequest(http.MethodGet, "/.well-known/agent-card.json", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("ServeAgentCard status = %d, want 500", rec.Code)
+	}
+}
+
+type typedCardAgent struct {
+	fakeAgent
+	card *AgentCard
+}
+
+func (a typedCardAgent) AgentCard(ctx context.Context) (any, error) { return a.card, nil }
@@ -0,0 +1,20 @@
+package a2a
+
+import "errors"
+
+// Sentinel errors for A2A operations.
+// All errors use the "a2a: " prefix for consistent error identification.
+var (
+	// ErrInvalidCard is returned when an agent card fails validation.
+	ErrInvalidCard = errors.New("a2a: invalid agent card")
+
+	// ErrUnsigned is returned when verifying an agent card without signatures.
+	ErrUnsigned = errors.New("a2a: agent card not signed")
+
+	// ErrInvalidSignature is returned when no agent card signature verifies.
+	ErrInvalidSignature = errors.New("a2a: invalid signature")
+
+	// ErrUnsupportedAlgorithm is returned for keys or JWS algorithms that
+	// cannot be used to sign agent cards.
+	ErrUnsupportedAlgorithm = errors.New("a2a: unsupported signing algorithm")
+)
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jonwraymond/toolprotocol/task"
@@ -27,7 +28,8 @@ type Logger interface {
 
 // Agent defines the callbacks needed to serve A2A requests.
 type Agent interface {
-	// AgentCard returns the A2A agent card payload.
+	// AgentCard returns the A2A agent card payload. A *AgentCard is
+	// validated before it is served.
 	AgentCard(ctx context.Context) (any, error)
 
 	// ListSkills returns the skills the agent supports.
@@ -49,6 +51,34 @@ type Handler struct {
 	Tasks  task.Manager
 	Wire   *wire.A2AWire
 	Logger Logger
+
+	// SkillPager pages ServeSkills responses when set; clients pass the
+	// previous page's nextCursor in the "cursor" query parameter.
+	SkillPager *wire.Pager
+
+	schemas skillSchemas
+}
+
+// skillSchemas caches compiled skill input schemas by skill name.
+type skillSchemas struct {
+	mu     sync.RWMutex
+	byName map[string]compiledSkill
+}
+
+// compiledSkill is a skill's compiled input schema, or the error
+// compiling it.
+type compiledSkill struct {
+	schema *wire.Schema
+	err    error
+}
+
+// ResetSkillSchemas drops the cached input schemas, so the next invoke
+// lists and compiles the agent's skills again. Call it after the skills
+// change.
+func (h *Handler) ResetSkillSchemas() {
+	h.schemas.mu.Lock()
+	h.schemas.byName = nil
+	h.schemas.mu.Unlock()
 }
 
 // NewHandler creates a new A2A handler.
@@ -80,12 +110,16 @@ func (h *Handler) ServeRPC(w http.ResponseWriter, r *http.Request) {
 
 	var resp *wire.Response
 	switch strings.ToLower(req.Method) {
-	case "agent/invoke":
+	case "agent/invoke", wire.A2AMethodMessageSend:
 		resp = h.handleInvoke(ctx, req)
 	case "agent/status", "task/status":
 		resp = h.handleStatus(ctx, req)
+	case wire.A2AMethodTasksGet:
+		resp = h.handleTaskGet(ctx, req)
+	case wire.A2AMethodTasksCancel:
+		resp = h.handleTaskCancel(ctx, req)
 	default:
-		resp = errorResponse(req.ID, fmt.Errorf("unsupported method %q", req.Method))
+		resp = errorResponse(req.ID, wire.NewError(wire.CodeMethodNotFound, fmt.Sprintf("unsupported method %q", req.Method)))
 	}
 
 	out, err := h.Wire.EncodeResponse(ctx, resp)
@@ -108,6 +142,12 @@ func (h *Handler) ServeAgentCard(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
+	if typed, ok := card.(*AgentCard); ok {
+		if err := typed.Validate(); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
 	writeJSONValue(w, card)
 }
 
@@ -122,7 +162,16 @@ func (h *Handler) ServeSkills(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
-	data, err := h.Wire.EncodeToolList(r.Context(), tools)
+	page := &wire.Page[wire.Tool]{Items: tools}
+	if h.SkillPager != nil {
+		p, err := wire.Paginate(*h.SkillPager, tools, toolName, r.URL.Query().Get("cursor"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		page = &p
+	}
+	data, err := h.Wire.EncodeToolPage(r.Context(), page)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err)
 		return
@@ -203,7 +252,10 @@ func (h *Handler) ServeTaskEvents(w http.ResponseWriter, r *http.Request, taskID
 
 func (h *Handler) handleInvoke(ctx context.Context, req *wire.Request) *wire.Response {
 	if h.Agent == nil {
-		return errorResponse(req.ID, errors.New("agent not configured"))
+		return errorResponse(req.ID, wire.NewProtocolError("a2a", wire.CodeUnsupportedOperation, "agent not configured"))
+	}
+	if err := h.validateArguments(ctx, req); err != nil {
+		return errorResponse(req.ID, err)
 	}
 	taskID := req.ID
 	if taskID == "" {
@@ -227,7 +279,7 @@ func (h *Handler) handleInvoke(ctx context.Context, req *wire.Request) *wire.Res
 		ID: req.ID,
 		Meta: map[string]any{
 			"status": map[string]any{
-				"state": "running",
+				"state": string(wire.A2ATaskStateSubmitted),
 				"id":    taskID,
 			},
 			"taskId": taskID,
@@ -235,6 +287,55 @@ func (h *Handler) handleInvoke(ctx context.Context, req *wire.Request) *wire.Res
 	}
 }
 
+// validateArguments checks the invocation arguments against the input
+// schema of the requested skill. Unknown skills are left to the agent.
+//
+// Schemas are compiled once per skill; the agent's skills are listed
+// again only when an invoke names a skill missing from the cache.
+func (h *Handler) validateArguments(ctx context.Context, req *wire.Request) error {
+	if req.ToolID == "" {
+		return nil
+	}
+	h.schemas.mu.RLock()
+	skill, ok := h.schemas.byName[req.ToolID]
+	h.schemas.mu.RUnlock()
+	if !ok {
+		skills, err := h.Agent.ListSkills(ctx)
+		if err != nil {
+			return err
+		}
+		if skill, ok = h.schemas.load(skills, req.ToolID); !ok {
+			return nil
+		}
+	}
+	if skill.err != nil {
+		return skill.err
+	}
+	args := req.Arguments
+	if args == nil {
+		args = map[string]any{}
+	}
+	return skill.schema.Validate(args)
+}
+
+// load compiles the input schemas of skills, replacing the cache, and
+// returns the entry for name.
+func (c *skillSchemas) load(skills []wire.Tool, name string) (compiledSkill, bool) {
+	byName := make(map[string]compiledSkill, len(skills))
+	for _, skill := range skills {
+		s, err := wire.CompileSchema(skill.InputSchema)
+		if err != nil {
+			err = fmt.Errorf("tool %q: %w", skill.Name, err)
+		}
+		byName[skill.Name] = compiledSkill{schema: s, err: err}
+	}
+	c.mu.Lock()
+	c.byName = byName
+	c.mu.Unlock()
+	skill, ok := byName[name]
+	return skill, ok
+}
+
 func (h *Handler) handleStatus(ctx context.Context, req *wire.Request) *wire.Response {
 	taskID := req.ID
 	if taskID == "" {
@@ -243,7 +344,7 @@ func (h *Handler) handleStatus(ctx context.Context, req *wire.Request) *wire.Res
 		}
 	}
 	if taskID == "" {
-		return errorResponse(req.ID, errors.New("task id required"))
+		return errorResponse(req.ID, errTaskIDRequired)
 	}
 	t, err := h.Tasks.Get(ctx, taskID)
 	if err != nil {
@@ -253,21 +354,88 @@ func (h *Handler) handleStatus(ctx context.Context, req *wire.Request) *wire.Res
 		ID: req.ID,
 		Meta: map[string]any{
 			"status": map[string]any{
-				"state": string(t.State),
+				"state": string(a2aState(t.State)),
 				"id":    t.ID,
 			},
 		},
 	}
 }
 
+func (h *Handler) handleTaskGet(ctx context.Context, req *wire.Request) *wire.Response {
+	taskID, _ := req.Arguments["id"].(string)
+	if taskID == "" {
+		return errorResponse(req.ID, errTaskIDRequired)
+	}
+	t, err := h.Tasks.Get(ctx, taskID)
+	if err != nil {
+		return errorResponse(req.ID, err)
+	}
+	return taskResponse(req.ID, t)
+}
+
+func (h *Handler) handleTaskCancel(ctx context.Context, req *wire.Request) *wire.Response {
+	taskID, _ := req.Arguments["id"].(string)
+	if taskID == "" {
+		return errorResponse(req.ID, errTaskIDRequired)
+	}
+	if err := h.Tasks.Cancel(ctx, taskID); err != nil {
+		if errors.Is(err, task.ErrInvalidTransition) {
+			return errorResponse(req.ID, wire.NewProtocolError("a2a", wire.CodeTaskNotCancelable, err.Error()))
+		}
+		return errorResponse(req.ID, err)
+	}
+	t, err := h.Tasks.Get(ctx, taskID)
+	if err != nil {
+		return errorResponse(req.ID, err)
+	}
+	return taskResponse(req.ID, t)
+}
+
+func toolName(t wire.Tool) string { return t.Name }
+
+// taskResponse renders a task as an A2A Task result. Completed tasks
+// include the invocation output as artifacts.
+func taskResponse(id string, t *task.Task) *wire.Response {
+	resp := &wire.Response{
+		ID: id,
+		Meta: map[string]any{
+			"taskId": t.ID,
+			"status": map[string]any{"state": string(a2aState(t.State))},
+		},
+	}
+	if result, ok := t.Result.(InvokeResult); ok {
+		resp.Content = result.Content
+	}
+	return resp
+}
+
+// a2aState maps task manager states to A2A task states.
+func a2aState(s task.State) wire.A2ATaskState {
+	switch s {
+	case task.StatePending:
+		return wire.A2ATaskStateSubmitted
+	case task.StateRunning:
+		return wire.A2ATaskStateWorking
+	case task.StateComplete:
+		return wire.A2ATaskStateCompleted
+	case task.StateFailed:
+		return wire.A2ATaskStateFailed
+	case task.StateCancelled:
+		return wire.A2ATaskStateCanceled
+	default:
+		return wire.A2ATaskStateUnknown
+	}
+}
+
+var errTaskIDRequired = wire.NewError(wire.CodeInvalidParams, "task id required")
+
+// errorResponse renders err as a JSON-RPC error, mapping Go errors to
+// A2A and JSON-RPC codes with wire.FromError.
 func errorResponse(id string, err error) *wire.Response {
 	return &wire.Response{
 		ID:      id,
 		IsError: true,
-		Error: &wire.Error{
-			Code:    -32000,
-			Message: err.Error(),
-		},
+		Error:   wire.FromErrorFor("a2a", err),
 	}
 }
 
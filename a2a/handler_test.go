@@ -5,12 +5,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/jonwraymond/toolprotocol/resource"
 	"github.com/jonwraymond/toolprotocol/task"
 	"github.com/jonwraymond/toolprotocol/wire"
 )
@@ -69,6 +73,45 @@ func TestHandler_ServeAgentCardAndSkills(t *testing.T) {
 	}
 }
 
+func TestHandler_ServeSkillsPaginated(t *testing.T) {
+	agent := fakeAgent{skills: []wire.Tool{{Name: "c"}, {Name: "a"}, {Name: "b"}}}
+	h := NewHandler(agent, task.NewManager())
+	h.SkillPager = &wire.Pager{Codec: wire.NewCursorCodec([]byte("k")), Size: 2, Scope: "skills"}
+
+	get := func(cursor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/a2a/skills?cursor="+cursor, nil)
+		rec := httptest.NewRecorder()
+		h.ServeSkills(rec, req)
+		return rec
+	}
+
+	var names []string
+	cursor := ""
+	for range 3 {
+		rec := get(cursor)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("ServeSkills status = %d, want 200", rec.Code)
+		}
+		page, err := h.Wire.DecodeToolPage(context.Background(), rec.Body.Bytes())
+		if err != nil {
+			t.Fatalf("DecodeToolPage error: %v", err)
+		}
+		for _, tool := range page.Items {
+			names = append(names, tool.Name)
+		}
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("paged skills = %v, want a,b,c", names)
+	}
+
+	if rec := get("forged"); rec.Code != http.StatusBadRequest {
+		t.Errorf("ServeSkills with forged cursor status = %d, want 400", rec.Code)
+	}
+}
+
 func TestHandler_InvokeAndStatus(t *testing.T) {
 	agent := fakeAgent{
 		card: map[string]any{"name": "test-agent"},
@@ -192,3 +235,248 @@ func TestHandler_TaskEvents(t *testing.T) {
 		t.Fatalf("payload id = %v (ID=%v), want %s", payload["id"], payload["ID"], taskID)
 	}
 }
+
+func TestHandler_MessageSendAndTasksGet(t *testing.T) {
+	ctx := context.Background()
+	h := NewHandler(fakeAgent{}, task.NewManager())
+
+	payload, err := h.Wire.EncodeMessageSend(ctx, "rpc-1", &wire.A2AMessageSendParams{
+		Message: wire.A2AMessage{
+			MessageID: "msg-1",
+			Role:      wire.A2ARoleUser,
+			Parts:     []wire.A2APart{{Kind: wire.A2APartData, Data: map[string]any{"message": "hi"}}},
+			Metadata:  map[string]any{"skillId": "echo"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("EncodeMessageSend error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeRPC(rec, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(payload)))
+	_, result, err := h.Wire.DecodeResult(ctx, rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeResult error: %v", err)
+	}
+	sent, ok := result.(*wire.A2ATask)
+	if !ok {
+		t.Fatalf("result = %T, want *wire.A2ATask", result)
+	}
+	if sent.Status.State != wire.A2ATaskStateSubmitted {
+		t.Errorf("state = %q, want %q", sent.Status.State, wire.A2ATaskStateSubmitted)
+	}
+
+	var got *wire.A2ATask
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		payload, err := h.Wire.EncodeTasksGet(ctx, "rpc-2", &wire.A2ATaskQueryParams{ID: sent.ID})
+		if err != nil {
+			t.Fatalf("EncodeTasksGet error: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeRPC(rec, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(payload)))
+		_, result, err := h.Wire.DecodeResult(ctx, rec.Body.Bytes())
+		if err != nil {
+			t.Fatalf("DecodeResult(tasks/get) error: %v", err)
+		}
+		got = result.(*wire.A2ATask)
+		if got.Status.State.IsTerminal() {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got.Status.State != wire.A2ATaskStateCompleted {
+		t.Fatalf("state = %q, want %q", got.Status.State, wire.A2ATaskStateCompleted)
+	}
+	if len(got.Artifacts) != 1 || len(got.Artifacts[0].Parts) != 1 || got.Artifacts[0].Parts[0].Text != "ok" {
+		t.Errorf("artifacts = %+v, want one text part %q", got.Artifacts, "ok")
+	}
+}
+
+func TestHandler_TasksCancel(t *testing.T) {
+	ctx := context.Background()
+	tasks := task.NewManager()
+	h := NewHandler(fakeAgent{}, tasks)
+	if _, err := tasks.Create(ctx, "task-cancel"); err != nil {
+		t.Fatalf("Create task error: %v", err)
+	}
+
+	payload, err := h.Wire.EncodeTasksCancel(ctx, "rpc-1", &wire.A2ATaskIDParams{ID: "task-cancel"})
+	if err != nil {
+		t.Fatalf("EncodeTasksCancel error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeRPC(rec, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(payload)))
+	_, result, err := h.Wire.DecodeResult(ctx, rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeResult error: %v", err)
+	}
+	got, ok := result.(*wire.A2ATask)
+	if !ok {
+		t.Fatalf("result = %T, want *wire.A2ATask", result)
+	}
+	if got.ID != "task-cancel" || got.Status.State != wire.A2ATaskStateCanceled {
+		t.Errorf("task = %s/%s, want task-cancel/canceled", got.ID, got.Status.State)
+	}
+
+	// Canceling a terminal task is rejected.
+	payload, _ = h.Wire.EncodeTasksCancel(ctx, "rpc-2", &wire.A2ATaskIDParams{ID: "task-cancel"})
+	rec = httptest.NewRecorder()
+	h.ServeRPC(rec, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(payload)))
+	if _, _, err := h.Wire.DecodeResult(ctx, rec.Body.Bytes()); !errors.Is(err, wire.ErrTaskNotCancelable) {
+		t.Errorf("DecodeResult error = %v, want ErrTaskNotCancelable", err)
+	}
+	// The error is A2A's before it is encoded, not just once decoded.
+	resp := h.handleTaskCancel(ctx, &wire.Request{ID: "rpc-3", Arguments: map[string]any{"id": "task-cancel"}})
+	if !errors.Is(resp.Error, wire.ErrTaskNotCancelable) || resp.Error.Protocol != "a2a" {
+		t.Errorf("handleTaskCancel error = %+v, want ErrTaskNotCancelable scoped to a2a", resp.Error)
+	}
+}
+
+func TestHandler_ErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	h := NewHandler(fakeAgent{}, nil)
+
+	missing, _ := h.Wire.EncodeTasksGet(ctx, "rpc-1", &wire.A2ATaskQueryParams{ID: "missing"})
+	noID, _ := h.Wire.EncodeTasksCancel(ctx, "rpc-2", &wire.A2ATaskIDParams{})
+	unknown, _ := h.Wire.EncodeCall(ctx, "rpc-3", "tasks/pushNotificationConfig/get", nil)
+
+	tests := []struct {
+		name    string
+		payload []byte
+		want    *wire.Error
+	}{
+		{"task not found", missing, wire.ErrTaskNotFound},
+		{"missing id", noID, wire.ErrInvalidParams},
+		{"unknown method", unknown, wire.ErrMethodNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeRPC(rec, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(tt.payload)))
+			_, _, err := h.Wire.DecodeResult(ctx, rec.Body.Bytes())
+			if !errors.Is(err, tt.want) {
+				t.Errorf("DecodeResult error = %v, want code %d", err, tt.want.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_InvokeValidatesArguments(t *testing.T) {
+	ctx := context.Background()
+	h := NewHandler(fakeAgent{skills: []wire.Tool{{
+		Name: "echo",
+		InputSchema: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"message": map[string]any{"type": "string"}},
+			"required":   []string{"message"},
+		},
+	}}}, nil)
+
+	payload, err := h.Wire.EncodeRequest(ctx, &wire.Request{
+		ID:        "rpc-1",
+		Method:    "agent/invoke",
+		ToolID:    "echo",
+		Arguments: map[string]any{"message": 42},
+	})
+	if err != nil {
+		t.Fatalf("EncodeRequest error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeRPC(rec, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(payload)))
+	resp, err := h.Wire.DecodeResponse(ctx, rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeResponse error: %v", err)
+	}
+	if !errors.Is(resp.Error, wire.ErrInvalidParams) {
+		t.Fatalf("error = %v, want invalid params", resp.Error)
+	}
+	if !strings.Contains(resp.Error.Message, "/message: expected string, got number") {
+		t.Errorf("message = %q, want path-addressed violation", resp.Error.Message)
+	}
+	if _, err := h.Tasks.Get(ctx, "rpc-1"); !errors.Is(err, task.ErrTaskNotFound) {
+		t.Errorf("task created for invalid arguments: %v", err)
+	}
+}
+
+// countingAgent counts ListSkills calls.
+type countingAgent struct {
+	fakeAgent
+	lists *atomic.Int32
+}
+
+func (c countingAgent) ListSkills(ctx context.Context) ([]wire.Tool, error) {
+	c.lists.Add(1)
+	return c.fakeAgent.ListSkills(ctx)
+}
+
+func TestHandler_InvokeCachesSkillSchemas(t *testing.T) {
+	ctx := context.Background()
+	agent := countingAgent{
+		fakeAgent: fakeAgent{skills: []wire.Tool{{
+			Name:        "echo",
+			InputSchema: map[string]any{"type": "object", "required": []string{"message"}},
+		}}},
+		lists: new(atomic.Int32),
+	}
+	h := NewHandler(agent, nil)
+	invoke := func(id string, args map[string]any) *wire.Response {
+		t.Helper()
+		payload, err := h.Wire.EncodeRequest(ctx, &wire.Request{ID: id, Method: "agent/invoke", ToolID: "echo", Arguments: args})
+		if err != nil {
+			t.Fatalf("EncodeRequest error: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeRPC(rec, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(payload)))
+		resp, err := h.Wire.DecodeResponse(ctx, rec.Body.Bytes())
+		if err != nil {
+			t.Fatalf("DecodeResponse error: %v", err)
+		}
+		return resp
+	}
+
+	for i := range 3 {
+		if resp := invoke(fmt.Sprintf("rpc-%d", i), nil); !errors.Is(resp.Error, wire.ErrInvalidParams) {
+			t.Fatalf("invoke %d error = %v, want invalid params", i, resp.Error)
+		}
+	}
+	if n := agent.lists.Load(); n != 1 {
+		t.Errorf("ListSkills called %d times, want 1", n)
+	}
+
+	h.ResetSkillSchemas()
+	if resp := invoke("rpc-ok", map[string]any{"message": "hi"}); resp.Error != nil {
+		t.Fatalf("invoke error = %v", resp.Error)
+	}
+	if n := agent.lists.Load(); n != 2 {
+		t.Errorf("ListSkills called %d times after reset, want 2", n)
+	}
+}
+
+// failingSkillsAgent fails to list its skills.
+type failingSkillsAgent struct {
+	fakeAgent
+	err error
+}
+
+func (f failingSkillsAgent) ListSkills(context.Context) ([]wire.Tool, error) {
+	return nil, f.err
+}
+
+func TestHandler_ForeignErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	h := NewHandler(failingSkillsAgent{err: resource.ErrResourceNotFound}, nil)
+	payload, err := h.Wire.EncodeRequest(ctx, &wire.Request{ID: "rpc-1", Method: "agent/invoke", ToolID: "echo"})
+	if err != nil {
+		t.Fatalf("EncodeRequest error: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeRPC(rec, httptest.NewRequest(http.MethodPost, "/a2a", bytes.NewReader(payload)))
+	resp, err := h.Wire.DecodeResponse(ctx, rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeResponse error: %v", err)
+	}
+	// MCP's resource-not-found code means task-not-cancelable in A2A.
+	if errors.Is(resp.Error, wire.ErrTaskNotCancelable) || !errors.Is(resp.Error, wire.ErrInternal) {
+		t.Errorf("error = %+v, want internal error", resp.Error)
+	}
+}
@@ -0,0 +1,145 @@
+package content
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Role identifies an intended audience of content.
+type Role string
+
+const (
+	// RoleUser is the human user.
+	RoleUser Role = "user"
+
+	// RoleAssistant is the model.
+	RoleAssistant Role = "assistant"
+)
+
+// Annotations describe how clients should use content, as in MCP content
+// block annotations.
+type Annotations struct {
+	// Audience lists who the content is intended for. Empty means
+	// everyone.
+	Audience []Role `json:"audience,omitempty"`
+
+	// Priority is the importance of the content from 0 (least) to 1
+	// (most), or nil when unset.
+	Priority *float64 `json:"priority,omitempty"`
+
+	// LastModified is when the content was last modified.
+	LastModified time.Time `json:"lastModified,omitzero"`
+}
+
+// Priority returns a pointer to p, for setting Annotations.Priority.
+func Priority(p float64) *float64 {
+	return &p
+}
+
+// For reports whether the content is intended for role. Nil annotations
+// and an empty audience include every role.
+func (a *Annotations) For(role Role) bool {
+	if a == nil || len(a.Audience) == 0 {
+		return true
+	}
+	return slices.Contains(a.Audience, role)
+}
+
+// PriorityOr returns the priority, or def when it is unset.
+func (a *Annotations) PriorityOr(def float64) float64 {
+	if a == nil || a.Priority == nil {
+		return def
+	}
+	return *a.Priority
+}
+
+// Validate checks that the priority is within [0, 1] and the audience
+// names known roles. Nil annotations are valid.
+func (a *Annotations) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if p := a.Priority; p != nil && (*p < 0 || *p > 1) {
+		return fmt.Errorf("%w: priority %v outside [0, 1]", ErrInvalidAnnotations, *p)
+	}
+	for _, r := range a.Audience {
+		if r != RoleUser && r != RoleAssistant {
+			return fmt.Errorf("%w: unknown audience %q", ErrInvalidAnnotations, r)
+		}
+	}
+	return nil
+}
+
+// clone returns a deep copy of a, or nil.
+func (a *Annotations) clone() *Annotations {
+	if a == nil {
+		return nil
+	}
+	cp := *a
+	cp.Audience = slices.Clone(a.Audience)
+	if a.Priority != nil {
+		cp.Priority = Priority(*a.Priority)
+	}
+	return &cp
+}
+
+// Annotated is implemented by content that carries annotations. Every
+// content type in this package implements it.
+type Annotated interface {
+	ContentAnnotations() *Annotations
+}
+
+// AnnotationsOf returns the annotations of c, or nil if it has none or
+// does not implement Annotated.
+func AnnotationsOf(c Content) *Annotations {
+	if a, ok := c.(Annotated); ok {
+		return a.ContentAnnotations()
+	}
+	return nil
+}
+
+// ContentAnnotations returns the text's annotations.
+func (c *TextContent) ContentAnnotations() *Annotations { return c.Annotations }
+
+// ContentAnnotations returns the image's annotations.
+func (c *ImageContent) ContentAnnotations() *Annotations { return c.Annotations }
+
+// ContentAnnotations returns the resource's annotations.
+func (c *ResourceContent) ContentAnnotations() *Annotations { return c.Annotations }
+
+// ContentAnnotations returns the audio's annotations.
+func (c *AudioContent) ContentAnnotations() *Annotations { return c.Annotations }
+
+// ContentAnnotations returns the file's annotations.
+func (c *FileContent) ContentAnnotations() *Annotations { return c.Annotations }
+
+// ContentAnnotations returns the lazy content's annotations.
+func (c *LazyContent) ContentAnnotations() *Annotations { return c.Annotations }
+
+// ForAudience returns the content intended for role, in order. Content
+// without annotations or with an empty audience is included.
+func (l List) ForAudience(role Role) List {
+	var out List
+	for _, c := range l {
+		if AnnotationsOf(c).For(role) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// SortByPriority sorts the list by descending priority, keeping the
+// order of equal priorities. Content without a priority sorts as 0.
+func (l List) SortByPriority() {
+	slices.SortStableFunc(l, func(a, b Content) int {
+		pa, pb := AnnotationsOf(a).PriorityOr(0), AnnotationsOf(b).PriorityOr(0)
+		switch {
+		case pa > pb:
+			return -1
+		case pa < pb:
+			return 1
+		}
+		return 0
+	})
+}
@@ -0,0 +1,174 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnnotations_For(t *testing.T) {
+	var none *Annotations
+	if !none.For(RoleUser) || !(&Annotations{}).For(RoleAssistant) {
+		t.Error("nil or empty audience should include every role")
+	}
+	a := &Annotations{Audience: []Role{RoleUser}}
+	if !a.For(RoleUser) || a.For(RoleAssistant) {
+		t.Errorf("For() with audience %v is wrong", a.Audience)
+	}
+}
+
+func TestAnnotations_PriorityOr(t *testing.T) {
+	var none *Annotations
+	if got := none.PriorityOr(0.5); got != 0.5 {
+		t.Errorf("nil PriorityOr = %v, want 0.5", got)
+	}
+	if got := (&Annotations{Priority: Priority(0)}).PriorityOr(0.5); got != 0 {
+		t.Errorf("PriorityOr = %v, want explicit 0", got)
+	}
+}
+
+func TestAnnotations_Validate(t *testing.T) {
+	valid := []*Annotations{
+		nil,
+		{},
+		{Audience: []Role{RoleUser, RoleAssistant}, Priority: Priority(1)},
+	}
+	for _, a := range valid {
+		if err := a.Validate(); err != nil {
+			t.Errorf("Validate(%+v) error = %v", a, err)
+		}
+	}
+	invalid := []*Annotations{
+		{Priority: Priority(1.5)},
+		{Priority: Priority(-0.1)},
+		{Audience: []Role{"system"}},
+	}
+	for _, a := range invalid {
+		if err := a.Validate(); !errors.Is(err, ErrInvalidAnnotations) {
+			t.Errorf("Validate(%+v) error = %v, want ErrInvalidAnnotations", a, err)
+		}
+	}
+}
+
+func TestAnnotations_JSON(t *testing.T) {
+	modified := time.Date(2025, 1, 12, 15, 0, 58, 0, time.UTC)
+	a := &Annotations{Audience: []Role{RoleUser}, Priority: Priority(0.8), LastModified: modified}
+	parts := []Content{
+		&TextContent{Text: "hi", Mime: "text/plain", Annotations: a},
+		&ImageContent{Data: []byte{1}, Mime: "image/png", Annotations: a},
+		&ResourceContent{URI: "file:///a", Mime: "text/plain", Text: "x", Annotations: a},
+		&AudioContent{Data: []byte{2}, Mime: "audio/wav", Annotations: a},
+		&FileContent{Data: []byte{3}, Mime: "text/csv", Path: "/a.csv", Size: 1, Annotations: a},
+	}
+	for _, want := range parts {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		if !strings.Contains(string(data), `"annotations":{"audience":["user"],"priority":0.8,"lastModified":"2025-01-12T15:00:58Z"}`) {
+			t.Errorf("JSON = %s, want MCP annotations", data)
+		}
+		got, err := Unmarshal(data)
+		if err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip = %+v, want %+v", got, want)
+		}
+	}
+
+	data, _ := json.Marshal(NewText("plain"))
+	if strings.Contains(string(data), "annotations") {
+		t.Errorf("JSON = %s, want no annotations", data)
+	}
+}
+
+func TestAnnotationsOf(t *testing.T) {
+	a := &Annotations{Priority: Priority(0.3)}
+	lazy := &LazyContent{Annotations: a}
+	if AnnotationsOf(lazy) != a {
+		t.Error("AnnotationsOf(lazy) did not return its annotations")
+	}
+	if AnnotationsOf(&markdownContent{}) != nil {
+		t.Error("AnnotationsOf(custom) != nil")
+	}
+}
+
+func TestBuilder_Annotations(t *testing.T) {
+	base := NewBuilder()
+	user := base.ForAudience(RoleUser)
+	urgent := user.WithPriority(1)
+
+	if c := base.Text("plain"); c.Annotations != nil {
+		t.Errorf("base builder annotations = %+v, want nil", c.Annotations)
+	}
+	if c := user.Text("for user"); !reflect.DeepEqual(c.Annotations, &Annotations{Audience: []Role{RoleUser}}) {
+		t.Errorf("user annotations = %+v", c.Annotations)
+	}
+	c := urgent.Image([]byte{1}, "image/png")
+	if !c.Annotations.For(RoleUser) || c.Annotations.For(RoleAssistant) || c.Annotations.PriorityOr(0) != 1 {
+		t.Errorf("urgent annotations = %+v", c.Annotations)
+	}
+	if user.Text("x").Annotations.Priority != nil {
+		t.Error("WithPriority modified the parent builder")
+	}
+
+	modified := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	r := base.WithLastModified(modified).ResourceWithText("file:///a", "x")
+	if !r.Annotations.LastModified.Equal(modified) {
+		t.Errorf("LastModified = %v, want %v", r.Annotations.LastModified, modified)
+	}
+
+	shared := &Annotations{Audience: []Role{RoleAssistant}}
+	b := base.WithAnnotations(shared)
+	first, second := b.Audio([]byte{1}, "audio/wav"), b.File([]byte{1}, "text/plain")
+	first.Annotations.Audience[0] = RoleUser
+	if second.Annotations.Audience[0] != RoleAssistant || shared.Audience[0] != RoleAssistant {
+		t.Error("content shares annotations with the builder or other content")
+	}
+}
+
+func TestList_ForAudience(t *testing.T) {
+	b := NewBuilder()
+	l := List{
+		b.Text("everyone"),
+		b.ForAudience(RoleUser).Text("user"),
+		b.ForAudience(RoleAssistant).Text("assistant"),
+		b.ForAudience(RoleUser, RoleAssistant).Text("both"),
+	}
+	texts := func(l List) []string {
+		var out []string
+		for _, c := range l {
+			out = append(out, c.String())
+		}
+		return out
+	}
+	if got, want := texts(l.ForAudience(RoleUser)), []string{"everyone", "user", "both"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ForAudience(user) = %v, want %v", got, want)
+	}
+	if got, want := texts(l.ForAudience(RoleAssistant)), []string{"everyone", "assistant", "both"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ForAudience(assistant) = %v, want %v", got, want)
+	}
+}
+
+func TestList_SortByPriority(t *testing.T) {
+	b := NewBuilder()
+	l := List{
+		b.Text("none-1"),
+		b.WithPriority(0.5).Text("mid-1"),
+		b.WithPriority(1).Text("high"),
+		b.Text("none-2"),
+		b.WithPriority(0.5).Text("mid-2"),
+	}
+	l.SortByPriority()
+	var got []string
+	for _, c := range l {
+		got = append(got, c.String())
+	}
+	if want := []string{"high", "mid-1", "mid-2", "none-1", "none-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortByPriority = %v, want %v", got, want)
+	}
+}
@@ -15,6 +15,9 @@ type AudioContent struct {
 
 	// Duration is the audio duration.
 	Duration time.Duration
+
+	// Annotations are optional audience, priority and modification hints.
+	Annotations *Annotations
 }
 
 // NewAudio creates a new audio content.
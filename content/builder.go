@@ -1,69 +1,183 @@
 package content
 
+import (
+	"slices"
+	"time"
+)
+
 // Builder creates content instances.
-type Builder struct{}
+//
+// With WithMIMEPolicy(MIMEFill) or WithMIMEPolicy(MIMEReject), binary
+// content created without a MIME type gets one sniffed from its data.
+// The constructors never fail, so a declared type that contradicts the
+// data is kept as given under either policy; MIMEReject only rejects
+// through Check, which also enforces the policy on content built any
+// other way:
+//
+//	b := NewBuilder(WithMIMEPolicy(MIMEReject))
+//	img := b.Image(data, "image/jpeg")
+//	if err := b.Check(img); err != nil {
+//	    // errors.Is(err, ErrMIMEMismatch) when data is not a JPEG
+//	}
+//
+// WithAnnotations, ForAudience, WithPriority and WithLastModified return
+// a derived builder whose content carries a copy of those annotations.
+type Builder struct {
+	opts        options
+	annotations *Annotations
+}
 
 // NewBuilder creates a new content builder.
-func NewBuilder() *Builder {
-	return &Builder{}
+func NewBuilder(opts ...Option) *Builder {
+	return &Builder{opts: newOptions(opts)}
+}
+
+// Check applies the builder's MIME policy to c, filling a missing MIME
+// type and, under MIMEReject, returning a *MIMEError for a mismatch.
+func (b *Builder) Check(c Content) error {
+	return CheckMIME(c, b.opts.mimePolicy)
+}
+
+// WithAnnotations returns a builder that attaches a to the content it
+// creates.
+func (b *Builder) WithAnnotations(a *Annotations) *Builder {
+	return &Builder{opts: b.opts, annotations: a.clone()}
+}
+
+// ForAudience returns a builder whose content is intended for roles.
+func (b *Builder) ForAudience(roles ...Role) *Builder {
+	d := b.derive()
+	d.annotations.Audience = slices.Clone(roles)
+	return d
+}
+
+// WithPriority returns a builder whose content has priority p, from 0
+// (least important) to 1 (most important).
+func (b *Builder) WithPriority(p float64) *Builder {
+	d := b.derive()
+	d.annotations.Priority = Priority(p)
+	return d
+}
+
+// WithLastModified returns a builder whose content was last modified at t.
+func (b *Builder) WithLastModified(t time.Time) *Builder {
+	d := b.derive()
+	d.annotations.LastModified = t
+	return d
+}
+
+// derive copies the builder with its own, non-nil annotations.
+func (b *Builder) derive() *Builder {
+	a := b.annotations.clone()
+	if a == nil {
+		a = &Annotations{}
+	}
+	return &Builder{opts: b.opts, annotations: a}
+}
+
+// finish attaches the builder's annotations to new content and sets a
+// missing MIME type of in-memory content. Reading in-memory content
+// cannot fail and filling never rejects, so errors are impossible.
+func (b *Builder) finish(c Content) {
+	if b.annotations != nil {
+		setAnnotations(c, b.annotations.clone())
+	}
+	if b.opts.mimePolicy != MIMEKeep {
+		_ = CheckMIME(c, MIMEFill)
+	}
+}
+
+// setAnnotations sets the annotations of the content types in this
+// package.
+func setAnnotations(c Content, a *Annotations) {
+	switch c := c.(type) {
+	case *TextContent:
+		c.Annotations = a
+	case *ImageContent:
+		c.Annotations = a
+	case *ResourceContent:
+		c.Annotations = a
+	case *AudioContent:
+		c.Annotations = a
+	case *FileContent:
+		c.Annotations = a
+	}
 }
 
 // Text creates a text content.
 func (b *Builder) Text(text string) *TextContent {
-	return NewText(text)
+	c := NewText(text)
+	b.finish(c)
+	return c
 }
 
 // TextWithMIME creates a text content with a custom MIME type.
 func (b *Builder) TextWithMIME(text, mimeType string) *TextContent {
-	return &TextContent{
+	c := &TextContent{
 		Text: text,
 		Mime: mimeType,
 	}
+	b.finish(c)
+	return c
 }
 
 // Image creates an image content.
 func (b *Builder) Image(data []byte, mimeType string) *ImageContent {
-	return NewImage(data, mimeType)
+	c := NewImage(data, mimeType)
+	b.finish(c)
+	return c
 }
 
 // ImageWithAlt creates an image content with alt text.
 func (b *Builder) ImageWithAlt(data []byte, mimeType, altText string) *ImageContent {
-	return &ImageContent{
+	c := &ImageContent{
 		Data:    data,
 		Mime:    mimeType,
 		AltText: altText,
 	}
+	b.finish(c)
+	return c
 }
 
 // Resource creates a resource content.
 func (b *Builder) Resource(uri string) *ResourceContent {
-	return NewResource(uri)
+	c := NewResource(uri)
+	b.finish(c)
+	return c
 }
 
 // ResourceWithText creates a resource content with text representation.
 func (b *Builder) ResourceWithText(uri, text string) *ResourceContent {
-	return &ResourceContent{
+	c := &ResourceContent{
 		URI:  uri,
 		Text: text,
 	}
+	b.finish(c)
+	return c
 }
 
 // Audio creates an audio content.
 func (b *Builder) Audio(data []byte, mimeType string) *AudioContent {
-	return NewAudio(data, mimeType)
+	c := NewAudio(data, mimeType)
+	b.finish(c)
+	return c
 }
 
 // File creates a file content.
 func (b *Builder) File(data []byte, mimeType string) *FileContent {
-	return NewFile(data, mimeType)
+	c := NewFile(data, mimeType)
+	b.finish(c)
+	return c
 }
 
 // FileWithPath creates a file content with path information.
 func (b *Builder) FileWithPath(data []byte, mimeType, path string) *FileContent {
-	return &FileContent{
+	c := &FileContent{
 		Data: data,
 		Mime: mimeType,
 		Path: path,
 		Size: int64(len(data)),
 	}
+	b.finish(c)
+	return c
 }
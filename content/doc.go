@@ -41,12 +41,21 @@
 //   - [AudioContent]: Audio data with duration (MP3, WAV, etc.)
 //   - [FileContent]: File data with path and size metadata
 //
+// [LazyContent] holds an image, audio, file or resource payload in a
+// [Source] instead of memory.
+//
 // # Core Components
 //
 //   - [Content]: Interface implemented by all content types
 //   - [Type]: Content type constants (text, image, resource, audio, file)
 //   - [Builder]: Factory for creating content instances
 //   - [NewText], [NewImage], [NewResource], [NewAudio], [NewFile]: Constructors
+//   - [Unmarshal], [List]: Decode content from JSON by its "type" member
+//   - [Registry]: Content type factories for decoding, including custom types ([Register])
+//   - [SniffMIME], [ValidateMIME], [CheckMIME]: Detect payload MIME types and
+//     enforce a [MIMEPolicy] ([WithMIMEPolicy])
+//   - [Annotations]: Audience, priority and last-modified time of content
+//   - [TranscodeImage]: Downscale, re-encode and fit images to a byte budget
 //
 // # Quick Start
 //
@@ -96,13 +105,95 @@
 //   - AudioContent: Returns base64-encoded data
 //   - FileContent: Returns Path if set, otherwise "[file data]"
 //
+// # JSON Encoding
+//
+// Every content type marshals to a JSON object with a "type" member.
+// Binary data is base64 in "data", audio durations use Go duration
+// syntax ("1m30s") and files carry "path" and "size". Decode an object
+// of unknown type with [Unmarshal], or a whole array with [List]:
+//
+//	var msg struct {
+//	    Content content.List `json:"content"`
+//	}
+//	err := json.Unmarshal(data, &msg)
+//
+// Custom types decode once registered:
+//
+//	content.Register("markdown", func() content.Content { return &Markdown{} })
+//
+// # MIME Fidelity
+//
+// A declared MIME type must match its payload. [SniffMIME] recognises
+// PNG, JPEG, GIF, WebP, WAV, MP3, Ogg and PDF signatures and falls back
+// to http.DetectContentType. [ValidateMIME] reports a [*MIMEError]
+// (errors.Is [ErrMIMEMismatch]) when they disagree; aliases such as
+// image/jpg and generic types such as application/octet-stream pass.
+//
+// Builders and registries apply a [MIMEPolicy]:
+//
+//	b := content.NewBuilder(content.WithMIMEPolicy(content.MIMEFill))
+//	img := b.Image(pngData, "") // MIMEType() == "image/png"
+//
+// Builder constructors never fail; under [MIMEReject] a builder reports
+// mismatches from [Builder.Check]:
+//
+//	strict := content.NewBuilder(content.WithMIMEPolicy(content.MIMEReject))
+//	err := strict.Check(strict.Image(pngData, "image/jpeg")) // ErrMIMEMismatch
+//
+//	r := content.NewRegistry(content.WithMIMEPolicy(content.MIMEReject))
+//	c, err := r.Unmarshal(data) // mismatches fail to decode
+//
+// # Annotations
+//
+// Every content type has an Annotations field, encoded as the MCP
+// "annotations" object: an audience of [RoleUser] and [RoleAssistant], a
+// priority from 0 to 1 and a lastModified time. Derived builders attach
+// them, and a [List] can be filtered and ordered by them:
+//
+//	forUser := content.NewBuilder().ForAudience(content.RoleUser).WithPriority(0.9)
+//	summary := forUser.Text("3 files changed")
+//
+//	parts = parts.ForAudience(content.RoleAssistant) // unannotated parts stay
+//	parts.SortByPriority()                           // highest first, stable
+//
+// # Image Transcoding
+//
+// [TranscodeImage] decodes a PNG, JPEG or GIF, downscales it with
+// [WithMaxDimension], re-encodes it with [WithFormat] and [WithQuality],
+// and fits it within [WithMaxBytes], lowering JPEG quality before
+// shrinking. The result is a new ImageContent whose MIME type, Width and
+// Height describe the new data:
+//
+//	img, err := content.TranscodeImage(screenshot,
+//	    content.WithMaxDimension(1568),
+//	    content.WithFormat("image/jpeg"),
+//	    content.WithMaxBytes(5<<20),
+//	)
+//
+// # Lazy Content
+//
+// Large payloads can stay on disk. A [Source] ([ReaderAtSource],
+// [FileSource], [FSSource]) reports its size without reading and opens a
+// fresh reader for each use:
+//
+//	rec := content.NewLazyFile("/data/recording.wav", "audio/wav")
+//	size, err := rec.Size() // os.Stat, no read
+//	err = rec.EncodeJSON(w) // base64 streamed to w
+//	err = rec.Chunks(48<<10, func(c content.Chunk) error {
+//	    return send(c.Offset, c.Data) // fixed-size pieces for transports
+//	})
+//
+// LazyContent marshals to the JSON of its Kind, so receivers decode an
+// ordinary FileContent, AudioContent, ImageContent or ResourceContent.
+//
 // # Thread Safety
 //
 // Content types are designed for concurrent read access:
 //
 //   - All content types are safe for concurrent reads after creation
 //   - Content should be treated as immutable after construction
-//   - Builder is stateless and safe for concurrent use
+//   - Builder is immutable after construction and safe for concurrent use
+//   - Registry: sync.RWMutex protects all operations
 //   - Bytes() returns the underlying slice; do not modify
 //
 // # Integration with ApertureStack
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // contentJSON is the JSON representation of content.
@@ -14,15 +15,22 @@ type contentJSON struct {
 	Data     string `json:"data,omitempty"` // base64 encoded
 	URI      string `json:"uri,omitempty"`
 	AltText  string `json:"altText,omitempty"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
 	Path     string `json:"path,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Duration string `json:"duration,omitempty"` // Go duration syntax, e.g. "1m30s"
+
+	Annotations *Annotations `json:"annotations,omitempty"`
 }
 
 // MarshalJSON marshals text content to JSON.
 func (c *TextContent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(contentJSON{
-		Type:     TypeText,
-		MIMEType: c.MIMEType(),
-		Text:     c.Text,
+		Type:        TypeText,
+		MIMEType:    c.MIMEType(),
+		Text:        c.Text,
+		Annotations: c.Annotations,
 	})
 }
 
@@ -34,17 +42,21 @@ func (c *TextContent) UnmarshalJSON(data []byte) error {
 	}
 	c.Text = j.Text
 	c.Mime = j.MIMEType
+	c.Annotations = j.Annotations
 	return nil
 }
 
 // MarshalJSON marshals image content to JSON.
 func (c *ImageContent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(contentJSON{
-		Type:     TypeImage,
-		MIMEType: c.MIMEType(),
-		Data:     base64.StdEncoding.EncodeToString(c.Data),
-		URI:      c.URI,
-		AltText:  c.AltText,
+		Type:        TypeImage,
+		MIMEType:    c.MIMEType(),
+		Data:        base64.StdEncoding.EncodeToString(c.Data),
+		URI:         c.URI,
+		AltText:     c.AltText,
+		Width:       c.Width,
+		Height:      c.Height,
+		Annotations: c.Annotations,
 	})
 }
 
@@ -64,16 +76,20 @@ func (c *ImageContent) UnmarshalJSON(data []byte) error {
 	c.Mime = j.MIMEType
 	c.URI = j.URI
 	c.AltText = j.AltText
+	c.Width = j.Width
+	c.Height = j.Height
+	c.Annotations = j.Annotations
 	return nil
 }
 
 // MarshalJSON marshals resource content to JSON.
 func (c *ResourceContent) MarshalJSON() ([]byte, error) {
 	j := contentJSON{
-		Type:     TypeResource,
-		MIMEType: c.MIMEType(),
-		URI:      c.URI,
-		Text:     c.Text,
+		Type:        TypeResource,
+		MIMEType:    c.MIMEType(),
+		URI:         c.URI,
+		Text:        c.Text,
+		Annotations: c.Annotations,
 	}
 	if len(c.Blob) > 0 {
 		j.Data = base64.StdEncoding.EncodeToString(c.Blob)
@@ -90,6 +106,7 @@ func (c *ResourceContent) UnmarshalJSON(data []byte) error {
 	c.URI = j.URI
 	c.Mime = j.MIMEType
 	c.Text = j.Text
+	c.Annotations = j.Annotations
 	if j.Data != "" {
 		decoded, err := base64.StdEncoding.DecodeString(j.Data)
 		if err != nil {
@@ -100,6 +117,80 @@ func (c *ResourceContent) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals audio content to JSON.
+func (c *AudioContent) MarshalJSON() ([]byte, error) {
+	j := contentJSON{
+		Type:        TypeAudio,
+		MIMEType:    c.MIMEType(),
+		Data:        base64.StdEncoding.EncodeToString(c.Data),
+		Annotations: c.Annotations,
+	}
+	if c.Duration != 0 {
+		j.Duration = c.Duration.String()
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON unmarshals JSON to audio content.
+func (c *AudioContent) UnmarshalJSON(data []byte) error {
+	var j contentJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Data != "" {
+		decoded, err := base64.StdEncoding.DecodeString(j.Data)
+		if err != nil {
+			return fmt.Errorf("decode audio data: %w", err)
+		}
+		c.Data = decoded
+	}
+	c.Mime = j.MIMEType
+	c.Annotations = j.Annotations
+	if j.Duration != "" {
+		d, err := time.ParseDuration(j.Duration)
+		if err != nil {
+			return fmt.Errorf("decode audio duration: %w", err)
+		}
+		c.Duration = d
+	}
+	return nil
+}
+
+// MarshalJSON marshals file content to JSON.
+func (c *FileContent) MarshalJSON() ([]byte, error) {
+	j := contentJSON{
+		Type:        TypeFile,
+		MIMEType:    c.MIMEType(),
+		Path:        c.Path,
+		Size:        c.Size,
+		Annotations: c.Annotations,
+	}
+	if len(c.Data) > 0 {
+		j.Data = base64.StdEncoding.EncodeToString(c.Data)
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON unmarshals JSON to file content.
+func (c *FileContent) UnmarshalJSON(data []byte) error {
+	var j contentJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Data != "" {
+		decoded, err := base64.StdEncoding.DecodeString(j.Data)
+		if err != nil {
+			return fmt.Errorf("decode file data: %w", err)
+		}
+		c.Data = decoded
+	}
+	c.Mime = j.MIMEType
+	c.Path = j.Path
+	c.Size = j.Size
+	c.Annotations = j.Annotations
+	return nil
+}
+
 // EncodeBase64 encodes bytes to base64 string.
 func EncodeBase64(data []byte) string {
 	return base64.StdEncoding.EncodeToString(data)
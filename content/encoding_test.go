@@ -2,7 +2,10 @@ package content
 
 import (
 	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestMarshalJSON_Text(t *testing.T) {
@@ -323,6 +326,8 @@ func TestRoundTrip_Image(t *testing.T) {
 		Mime:    "image/png",
 		URI:     "https://example.com/img.png",
 		AltText: "PNG image",
+		Width:   640,
+		Height:  480,
 	}
 
 	data, err := json.Marshal(original)
@@ -347,6 +352,9 @@ func TestRoundTrip_Image(t *testing.T) {
 	if restored.AltText != original.AltText {
 		t.Errorf("AltText = %q, want %q", restored.AltText, original.AltText)
 	}
+	if restored.Width != original.Width || restored.Height != original.Height {
+		t.Errorf("dimensions = %dx%d, want %dx%d", restored.Width, restored.Height, original.Width, original.Height)
+	}
 }
 
 func TestRoundTrip_Resource(t *testing.T) {
@@ -404,3 +412,58 @@ func TestUnmarshalJSON_Resource_Invalid(t *testing.T) {
 		t.Fatal("expected error for invalid JSON, got nil")
 	}
 }
+
+func TestRoundTrip_Audio(t *testing.T) {
+	original := &AudioContent{Data: []byte("RIFF"), Mime: "audio/wav", Duration: 90*time.Second + 250*time.Millisecond}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `"duration":"1m30.25s"`) {
+		t.Errorf("JSON = %s, want duration 1m30.25s", data)
+	}
+
+	var restored AudioContent
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(&restored, original) {
+		t.Errorf("restored = %+v, want %+v", restored, original)
+	}
+}
+
+func TestRoundTrip_File(t *testing.T) {
+	tests := []*FileContent{
+		{Data: []byte("a,b"), Mime: "text/csv", Path: "/reports/q1.csv", Size: 3},
+		{Mime: "application/x-iso9660-image", Path: "/big.iso", Size: 1 << 32},
+	}
+	for _, original := range tests {
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		var restored FileContent
+		if err := json.Unmarshal(data, &restored); err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		if !reflect.DeepEqual(&restored, original) {
+			t.Errorf("restored = %+v, want %+v", restored, original)
+		}
+	}
+}
+
+func TestUnmarshalJSON_Audio_Invalid(t *testing.T) {
+	for _, data := range []string{"not json", `{"type":"audio","duration":"soon"}`, `{"type":"audio","data":"!"}`} {
+		var c AudioContent
+		if err := json.Unmarshal([]byte(data), &c); err == nil {
+			t.Errorf("Unmarshal(%s) error = nil, want error", data)
+		}
+	}
+}
+
+func TestUnmarshalJSON_File_Invalid(t *testing.T) {
+	var c FileContent
+	if err := json.Unmarshal([]byte(`{"type":"file","data":"!"}`), &c); err == nil {
+		t.Fatal("expected error for invalid data, got nil")
+	}
+}
@@ -0,0 +1,34 @@
+package content
+
+import "errors"
+
+// Sentinel errors for content operations.
+// All errors use the "content: " prefix for consistent error identification.
+var (
+	// ErrUnknownType is returned when decoding content of an unregistered type.
+	ErrUnknownType = errors.New("content: unknown type")
+
+	// ErrInvalidContent is returned when content JSON is malformed or has
+	// no type discriminator.
+	ErrInvalidContent = errors.New("content: invalid content")
+
+	// ErrMIMEMismatch is returned when a declared MIME type contradicts
+	// the payload ([MIMEError]).
+	ErrMIMEMismatch = errors.New("content: MIME type mismatch")
+
+	// ErrInvalidAnnotations is returned when annotations have a priority
+	// outside [0, 1] or an unknown audience role.
+	ErrInvalidAnnotations = errors.New("content: invalid annotations")
+
+	// ErrUnsupportedImage is returned when image data cannot be decoded or
+	// the target format cannot be encoded.
+	ErrUnsupportedImage = errors.New("content: unsupported image")
+
+	// ErrImageTooLarge is returned when an image has too many pixels to
+	// decode or cannot be made to fit a byte budget.
+	ErrImageTooLarge = errors.New("content: image too large")
+
+	// ErrNoSource is returned when lazy content has no Source to read
+	// its payload from.
+	ErrNoSource = errors.New("content: no source")
+)
@@ -1,7 +1,11 @@
 package content_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"image"
+	"image/png"
 
 	"github.com/jonwraymond/toolprotocol/content"
 )
@@ -348,3 +352,64 @@ func Example_builderWorkflow() {
 	// Image alt: Logo
 	// File path: /report.csv
 }
+
+func ExampleList() {
+	data := []byte(`{"content":[
+		{"type":"text","text":"Transcript attached"},
+		{"type":"audio","mimeType":"audio/wav","data":"UklGRg==","duration":"1m30s"},
+		{"type":"file","mimeType":"text/plain","path":"/notes.txt","size":2048}
+	]}`)
+
+	var msg struct {
+		Content content.List `json:"content"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	for _, c := range msg.Content {
+		fmt.Printf("%T\n", c)
+	}
+	fmt.Println(msg.Content[1].(*content.AudioContent).Duration)
+	// Output:
+	// *content.TextContent
+	// *content.AudioContent
+	// *content.FileContent
+	// 1m30s
+}
+
+func ExampleList_SortByPriority() {
+	b := content.NewBuilder()
+	parts := content.List{
+		b.Text("debug log"),
+		b.ForAudience(content.RoleUser).WithPriority(1).Text("Build failed"),
+		b.ForAudience(content.RoleAssistant).WithPriority(0.5).Text("stack trace"),
+	}
+
+	parts.SortByPriority()
+	for _, c := range parts.ForAudience(content.RoleUser) {
+		fmt.Println(c)
+	}
+	// Output:
+	// Build failed
+	// debug log
+}
+
+func ExampleTranscodeImage() {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewGray(image.Rect(0, 0, 3000, 2000)))
+	screenshot := content.NewImage(buf.Bytes(), "image/png")
+
+	img, err := content.TranscodeImage(screenshot,
+		content.WithMaxDimension(1200),
+		content.WithFormat("image/jpeg"),
+		content.WithMaxBytes(100<<10),
+	)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(img.Mime, img.Width, img.Height)
+	// Output:
+	// image/jpeg 1200 800
+}
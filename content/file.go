@@ -13,6 +13,9 @@ type FileContent struct {
 
 	// Size is the file size in bytes (may be set independently of Data).
 	Size int64
+
+	// Annotations are optional audience, priority and modification hints.
+	Annotations *Annotations
 }
 
 // NewFile creates a new file content.
@@ -15,6 +15,13 @@ type ImageContent struct {
 
 	// AltText is accessibility text.
 	AltText string
+
+	// Width and Height are the pixel dimensions, or 0 when unknown.
+	Width  int
+	Height int
+
+	// Annotations are optional audience, priority and modification hints.
+	Annotations *Annotations
 }
 
 // NewImage creates a new image content.
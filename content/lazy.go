@@ -0,0 +1,241 @@
+package content
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// LazyContent is binary content whose payload is read from a Source
+// when needed instead of being held in memory.
+//
+// It marshals to the same JSON as the in-memory type named by Kind, so
+// Unmarshal decodes it back to an ImageContent, AudioContent,
+// FileContent or ResourceContent. EncodeJSON streams the base64 payload
+// without reading it into memory.
+type LazyContent struct {
+	// Kind is the content type: TypeImage, TypeAudio, TypeFile or
+	// TypeResource (default: TypeFile).
+	Kind Type
+
+	// Mime is the MIME type.
+	Mime string
+
+	// Path is the file path (for files).
+	Path string
+
+	// URI is the resource URI, or the source URI of an image.
+	URI string
+
+	// Source provides the payload. Methods that need the payload return
+	// ErrNoSource when it is nil.
+	Source Source
+
+	// Annotations are optional audience, priority and modification hints.
+	Annotations *Annotations
+}
+
+// NewLazy creates lazily loaded content of the given type.
+func NewLazy(kind Type, src Source, mimeType string) *LazyContent {
+	return &LazyContent{
+		Kind:   kind,
+		Mime:   mimeType,
+		Source: src,
+	}
+}
+
+// NewLazyFile creates file content backed by the file at path.
+func NewLazyFile(path, mimeType string) *LazyContent {
+	return &LazyContent{
+		Kind:   TypeFile,
+		Mime:   mimeType,
+		Path:   path,
+		Source: FileSource(path),
+	}
+}
+
+// NewLazyFS creates file content backed by the named file in fsys.
+func NewLazyFS(fsys fs.FS, name, mimeType string) *LazyContent {
+	return &LazyContent{
+		Kind:   TypeFile,
+		Mime:   mimeType,
+		Path:   name,
+		Source: FSSource(fsys, name),
+	}
+}
+
+// Type returns Kind, or TypeFile if Kind is empty.
+func (c *LazyContent) Type() Type {
+	if c.Kind == "" {
+		return TypeFile
+	}
+	return c.Kind
+}
+
+// MIMEType returns the MIME type.
+func (c *LazyContent) MIMEType() string {
+	if c.Mime == "" {
+		if c.Type() == TypeAudio {
+			return "audio/mpeg"
+		}
+		return "application/octet-stream"
+	}
+	return c.Mime
+}
+
+// Size returns the payload size in bytes without reading it.
+func (c *LazyContent) Size() (int64, error) {
+	if c.Source == nil {
+		return 0, ErrNoSource
+	}
+	return c.Source.Size()
+}
+
+// Open returns a reader over the payload. The caller closes it.
+func (c *LazyContent) Open() (io.ReadCloser, error) {
+	if c.Source == nil {
+		return nil, ErrNoSource
+	}
+	return c.Source.Open()
+}
+
+// Bytes reads the whole payload into memory.
+func (c *LazyContent) Bytes() ([]byte, error) {
+	r, err := c.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// String returns the file path or URI, without reading the payload.
+func (c *LazyContent) String() string {
+	switch {
+	case c.Path != "":
+		return c.Path
+	case c.URI != "":
+		return c.URI
+	}
+	return "[" + string(c.Type()) + " data]"
+}
+
+// WriteBase64 streams the base64 encoding of the payload to w and
+// returns the number of payload bytes read.
+func (c *LazyContent) WriteBase64(w io.Writer) (int64, error) {
+	r, err := c.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	enc := base64.NewEncoder(base64.StdEncoding, w)
+	n, err := io.Copy(enc, r)
+	if err != nil {
+		return n, err
+	}
+	return n, enc.Close()
+}
+
+// EncodeJSON writes the JSON encoding of the content to w, streaming the
+// base64 payload instead of buffering it.
+//
+// The object is written as it is produced, so if the source fails while
+// the payload is read, w is left holding a partial object. Callers that
+// need all or nothing encode into a buffer, as MarshalJSON does.
+func (c *LazyContent) EncodeJSON(w io.Writer) error {
+	if c.Source == nil {
+		return fmt.Errorf("encode %s: %w", c.Type(), ErrNoSource)
+	}
+	j := contentJSON{
+		Type:        c.Type(),
+		MIMEType:    c.MIMEType(),
+		URI:         c.URI,
+		Path:        c.Path,
+		Annotations: c.Annotations,
+	}
+	if c.Type() == TypeFile {
+		size, err := c.Size()
+		if err != nil {
+			return err
+		}
+		j.Size = size
+	}
+	head, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	// Reopen the object after the fixed members to append the payload.
+	head = append(head[:len(head)-1], `,"data":"`...)
+	if _, err := w.Write(head); err != nil {
+		return err
+	}
+	if _, err := c.WriteBase64(w); err != nil {
+		return fmt.Errorf("encode %s data: %w", c.Type(), err)
+	}
+	_, err = io.WriteString(w, `"}`)
+	return err
+}
+
+// MarshalJSON marshals lazy content to JSON. Only the encoded output is
+// buffered; use EncodeJSON to stream it.
+func (c *LazyContent) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.EncodeJSON(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Chunk is one piece of a payload read by Chunks.
+type Chunk struct {
+	// Offset is the position of Data in the payload.
+	Offset int64
+
+	// Data is the chunk's bytes. It is only valid during the callback.
+	Data []byte
+
+	// Last reports whether this is the final chunk.
+	Last bool
+}
+
+// Chunks reads the payload in chunks of up to size bytes and calls fn
+// for each, in order. An empty payload yields one empty, last chunk.
+// Reading stops at the first error from fn, which Chunks returns.
+//
+// Chunks whose size is a multiple of 3 base64-encode independently, so
+// transports can send each as its own base64 string.
+func (c *LazyContent) Chunks(size int, fn func(Chunk) error) error {
+	if size <= 0 {
+		return fmt.Errorf("chunk size %d: must be positive", size)
+	}
+	total, err := c.Size()
+	if err != nil {
+		return err
+	}
+	r, err := c.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	buf := make([]byte, min(int64(size), max(total, 1)))
+	var off int64
+	for {
+		n, err := io.ReadFull(r, buf)
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			return fn(Chunk{Offset: off, Data: buf[:n], Last: true})
+		default:
+			return err
+		}
+		last := off+int64(n) >= total
+		if err := fn(Chunk{Offset: off, Data: buf[:n], Last: last}); err != nil || last {
+			return err
+		}
+		off += int64(n)
+	}
+}
@@ -0,0 +1,228 @@
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// failingReaderAt fails every read, proving callers did not read.
+type failingReaderAt struct{}
+
+func (failingReaderAt) ReadAt([]byte, int64) (int, error) {
+	return 0, errors.New("read not allowed")
+}
+
+func TestLazyContent_SizeWithoutReading(t *testing.T) {
+	c := NewLazy(TypeAudio, ReaderAtSource(failingReaderAt{}, 1<<30), "audio/wav")
+	size, err := c.Size()
+	if err != nil || size != 1<<30 {
+		t.Errorf("Size() = %d, %v, want %d", size, err, 1<<30)
+	}
+	if c.Type() != TypeAudio || c.MIMEType() != "audio/wav" {
+		t.Errorf("Type/MIMEType = %s/%s, want audio/audio/wav", c.Type(), c.MIMEType())
+	}
+	if _, err := c.Bytes(); err == nil {
+		t.Error("Bytes() error = nil, want read error")
+	}
+}
+
+func TestLazyContent_Defaults(t *testing.T) {
+	c := &LazyContent{Source: ReaderAtSource(strings.NewReader(""), 0)}
+	if c.Type() != TypeFile || c.MIMEType() != "application/octet-stream" || c.String() != "[file data]" {
+		t.Errorf("defaults = %s/%s/%s", c.Type(), c.MIMEType(), c.String())
+	}
+	if got := (&LazyContent{Kind: TypeAudio}).MIMEType(); got != "audio/mpeg" {
+		t.Errorf("audio MIMEType() = %q, want audio/mpeg", got)
+	}
+}
+
+func TestNewLazyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.csv")
+	payload := []byte("a,b,c\n1,2,3\n")
+	if err := os.WriteFile(path, payload, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := NewLazyFile(path, "text/csv")
+	if size, err := c.Size(); err != nil || size != int64(len(payload)) {
+		t.Errorf("Size() = %d, %v, want %d", size, err, len(payload))
+	}
+	if got, err := c.Bytes(); err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("Bytes() = %q, %v, want %q", got, err, payload)
+	}
+	if c.String() != path {
+		t.Errorf("String() = %q, want %q", c.String(), path)
+	}
+
+	missing := NewLazyFile(filepath.Join(t.TempDir(), "missing"), "")
+	if _, err := missing.Size(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing Size() error = %v, want ErrNotExist", err)
+	}
+	if _, err := missing.Bytes(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing Bytes() error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestNewLazyFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/a.txt": {Data: []byte("hello")},
+	}
+	c := NewLazyFS(fsys, "docs/a.txt", "text/plain")
+	if size, err := c.Size(); err != nil || size != 5 {
+		t.Errorf("Size() = %d, %v, want 5", size, err)
+	}
+	if got, err := c.Bytes(); err != nil || string(got) != "hello" {
+		t.Errorf("Bytes() = %q, %v, want hello", got, err)
+	}
+	if _, err := NewLazyFS(fsys, "docs", "").Size(); err == nil {
+		t.Error("directory Size() error = nil, want error")
+	}
+}
+
+func TestLazyContent_JSON(t *testing.T) {
+	payload := bytes.Repeat([]byte("0123456789"), 1000)
+	tests := []struct {
+		lazy *LazyContent
+		want Content
+	}{
+		{
+			lazy: &LazyContent{Kind: TypeFile, Mime: "text/plain", Path: "/digits.txt", Source: ReaderAtSource(bytes.NewReader(payload), int64(len(payload)))},
+			want: &FileContent{Data: payload, Mime: "text/plain", Path: "/digits.txt", Size: int64(len(payload))},
+		},
+		{
+			lazy: &LazyContent{Kind: TypeImage, Mime: "image/png", URI: "https://example.com/a.png", Source: ReaderAtSource(bytes.NewReader(payload), int64(len(payload)))},
+			want: &ImageContent{Data: payload, Mime: "image/png", URI: "https://example.com/a.png"},
+		},
+		{
+			lazy: &LazyContent{Kind: TypeResource, URI: "file:///a.bin", Source: ReaderAtSource(bytes.NewReader(payload), int64(len(payload)))},
+			want: &ResourceContent{URI: "file:///a.bin", Mime: "application/octet-stream", Blob: payload},
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := tt.lazy.EncodeJSON(&buf); err != nil {
+			t.Fatalf("EncodeJSON error: %v", err)
+		}
+		if !json.Valid(buf.Bytes()) {
+			t.Fatalf("EncodeJSON produced invalid JSON: %.80s", buf.Bytes())
+		}
+		got, err := Unmarshal(buf.Bytes())
+		if err != nil {
+			t.Fatalf("Unmarshal error: %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: decoded %T differs from %T", tt.lazy.Type(), got, tt.want)
+		}
+
+		data, err := json.Marshal(tt.lazy)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		if !bytes.Equal(data, buf.Bytes()) {
+			t.Errorf("MarshalJSON differs from EncodeJSON")
+		}
+	}
+}
+
+func TestLazyContent_EncodeJSON_SourceError(t *testing.T) {
+	c := NewLazy(TypeImage, ReaderAtSource(failingReaderAt{}, 10), "image/png")
+	if err := c.EncodeJSON(io.Discard); err == nil {
+		t.Error("EncodeJSON error = nil, want read error")
+	}
+}
+
+func TestLazyContent_NilSource(t *testing.T) {
+	c := &LazyContent{Kind: TypeImage, Mime: "image/png"}
+	if _, err := c.Size(); !errors.Is(err, ErrNoSource) {
+		t.Errorf("Size error = %v, want ErrNoSource", err)
+	}
+	if _, err := c.Open(); !errors.Is(err, ErrNoSource) {
+		t.Errorf("Open error = %v, want ErrNoSource", err)
+	}
+	if _, err := c.Bytes(); !errors.Is(err, ErrNoSource) {
+		t.Errorf("Bytes error = %v, want ErrNoSource", err)
+	}
+	var buf bytes.Buffer
+	if err := c.EncodeJSON(&buf); !errors.Is(err, ErrNoSource) || buf.Len() != 0 {
+		t.Errorf("EncodeJSON error = %v, wrote %q, want ErrNoSource and nothing written", err, buf.String())
+	}
+	if _, err := json.Marshal(c); !errors.Is(err, ErrNoSource) {
+		t.Errorf("Marshal error = %v, want ErrNoSource", err)
+	}
+	if err := c.Chunks(4, func(Chunk) error { return nil }); !errors.Is(err, ErrNoSource) {
+		t.Errorf("Chunks error = %v, want ErrNoSource", err)
+	}
+}
+
+func TestLazyContent_Chunks(t *testing.T) {
+	payload := []byte("abcdefghij")
+	c := NewLazy(TypeFile, ReaderAtSource(bytes.NewReader(payload), int64(len(payload))), "")
+
+	tests := []struct {
+		size int
+		want []string
+	}{
+		{3, []string{"abc", "def", "ghi", "j"}},
+		{5, []string{"abcde", "fghij"}},
+		{64, []string{"abcdefghij"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		var offsets []int64
+		lastSeen := 0
+		err := c.Chunks(tt.size, func(ch Chunk) error {
+			got = append(got, string(ch.Data))
+			offsets = append(offsets, ch.Offset)
+			if ch.Last {
+				lastSeen++
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Chunks(%d) error: %v", tt.size, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Chunks(%d) = %q, want %q", tt.size, got, tt.want)
+		}
+		if lastSeen != 1 || offsets[len(offsets)-1] != int64(tt.size*(len(tt.want)-1)) {
+			t.Errorf("Chunks(%d): last = %d, offsets = %v", tt.size, lastSeen, offsets)
+		}
+	}
+}
+
+func TestLazyContent_Chunks_Empty(t *testing.T) {
+	c := NewLazy(TypeFile, ReaderAtSource(bytes.NewReader(nil), 0), "")
+	var chunks []Chunk
+	if err := c.Chunks(4, func(ch Chunk) error {
+		chunks = append(chunks, ch)
+		return nil
+	}); err != nil {
+		t.Fatalf("Chunks error: %v", err)
+	}
+	if len(chunks) != 1 || !chunks[0].Last || len(chunks[0].Data) != 0 {
+		t.Errorf("chunks = %+v, want one empty last chunk", chunks)
+	}
+}
+
+func TestLazyContent_Chunks_Errors(t *testing.T) {
+	c := NewLazy(TypeFile, ReaderAtSource(strings.NewReader("abcdef"), 6), "")
+	if err := c.Chunks(0, func(Chunk) error { return nil }); err == nil {
+		t.Error("Chunks(0) error = nil, want error")
+	}
+	stop := errors.New("stop")
+	calls := 0
+	err := c.Chunks(2, func(Chunk) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Chunks error = %v after %d calls, want stop after 1", err, calls)
+	}
+}
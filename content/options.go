@@ -0,0 +1,28 @@
+package content
+
+// Option configures a Builder or Registry.
+type Option func(*options)
+
+// options holds the settings shared by Builder and Registry.
+type options struct {
+	mimePolicy MIMEPolicy
+}
+
+// WithMIMEPolicy sets how MIME types are checked against payloads.
+// Builders fill missing types of the binary content they create under
+// MIMEFill and MIMEReject, and report mismatches only from Builder.Check;
+// Registry.Unmarshal applies the policy to every decoded value and fails
+// on mismatches under MIMEReject.
+func WithMIMEPolicy(p MIMEPolicy) Option {
+	return func(o *options) {
+		o.mimePolicy = p
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,149 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Factory creates an empty content value for JSON decoding. The value
+// must be a pointer so the decoder can fill it in.
+type Factory func() Content
+
+// Registry maps content types to factories for polymorphic decoding.
+//
+// Contract:
+//   - Concurrency: All methods are safe for concurrent use via sync.RWMutex.
+//   - Registration: Register replaces existing factories for the same type.
+//   - Decoding: Unmarshal dispatches on the "type" member of the JSON object.
+//   - MIME: Unmarshal applies the WithMIMEPolicy option to decoded values.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[Type]Factory
+	opts      options
+}
+
+// NewRegistry creates a registry with the built-in content types
+// (text, image, resource, audio, file) registered.
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{factories: make(map[Type]Factory), opts: newOptions(opts)}
+	r.Register(TypeText, func() Content { return &TextContent{} })
+	r.Register(TypeImage, func() Content { return &ImageContent{} })
+	r.Register(TypeResource, func() Content { return &ResourceContent{} })
+	r.Register(TypeAudio, func() Content { return &AudioContent{} })
+	r.Register(TypeFile, func() Content { return &FileContent{} })
+	return r
+}
+
+// Register adds a factory for a content type.
+// If a factory for the type exists, it is replaced.
+func (r *Registry) Register(t Type, f Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[t] = f
+}
+
+// Types returns the registered content types.
+func (r *Registry) Types() []Type {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]Type, 0, len(r.factories))
+	for t := range r.factories {
+		types = append(types, t)
+	}
+	return types
+}
+
+// Unmarshal decodes a JSON content object into the type named by its
+// "type" member. It returns ErrInvalidContent for malformed JSON or a
+// missing type, ErrUnknownType for types without a factory and, under
+// MIMEReject, a *MIMEError for a MIME type that contradicts the payload.
+func (r *Registry) Unmarshal(data []byte) (Content, error) {
+	var head struct {
+		Type Type `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidContent, err)
+	}
+	if head.Type == "" {
+		return nil, fmt.Errorf("%w: missing type", ErrInvalidContent)
+	}
+
+	r.mu.RLock()
+	f, ok := r.factories[head.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, head.Type)
+	}
+
+	c := f()
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidContent, head.Type, err)
+	}
+	if err := CheckMIME(c, r.opts.mimePolicy); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// UnmarshalList decodes a JSON array of content objects, preserving order.
+func (r *Registry) UnmarshalList(data []byte) (List, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidContent, err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	list := make(List, len(raw))
+	for i, item := range raw {
+		c, err := r.Unmarshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("content[%d]: %w", i, err)
+		}
+		list[i] = c
+	}
+	return list, nil
+}
+
+// defaultRegistry is the global registry used by Unmarshal and List.
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the registry used by Unmarshal and List.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a factory for a custom content type to the default
+// registry.
+func Register(t Type, f Factory) {
+	defaultRegistry.Register(t, f)
+}
+
+// Unmarshal decodes a JSON content object using the default registry.
+func Unmarshal(data []byte) (Content, error) {
+	return defaultRegistry.Unmarshal(data)
+}
+
+// List is an ordered list of content that decodes from JSON by each
+// element's "type", using the default registry.
+type List []Content
+
+// MarshalJSON marshals the list as a JSON array. A nil list marshals as
+// an empty array.
+func (l List) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Content(l))
+}
+
+// UnmarshalJSON unmarshals a JSON array of content objects.
+func (l *List) UnmarshalJSON(data []byte) error {
+	list, err := defaultRegistry.UnmarshalList(data)
+	if err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
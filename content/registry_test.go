@@ -0,0 +1,130 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestUnmarshal_BuiltinTypes(t *testing.T) {
+	parts := []Content{
+		&TextContent{Text: "hello", Mime: "text/markdown"},
+		&ImageContent{Data: []byte{1, 2}, Mime: "image/png", URI: "https://example.com/a.png", AltText: "chart"},
+		&ResourceContent{URI: "file:///a.txt", Mime: "text/plain", Text: "body"},
+		&AudioContent{Data: []byte{3}, Mime: "audio/ogg", Duration: 2 * time.Second},
+		&FileContent{Data: []byte{4}, Mime: "application/pdf", Path: "/a.pdf", Size: 1},
+	}
+	for _, want := range parts {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal error: %v", err)
+		}
+		got, err := Unmarshal(data)
+		if err != nil {
+			t.Fatalf("Unmarshal(%s) error: %v", data, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", data, got, want)
+		}
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		want error
+	}{
+		{"not json", ErrInvalidContent},
+		{`{"text":"untyped"}`, ErrInvalidContent},
+		{`{"type":"image","data":"!"}`, ErrInvalidContent},
+		{`{"type":"video"}`, ErrUnknownType},
+	}
+	for _, tt := range tests {
+		if _, err := Unmarshal([]byte(tt.data)); !errors.Is(err, tt.want) {
+			t.Errorf("Unmarshal(%s) error = %v, want %v", tt.data, err, tt.want)
+		}
+	}
+}
+
+// markdownContent is a custom content type for registry tests.
+type markdownContent struct {
+	Source string `json:"source"`
+}
+
+func (c *markdownContent) Type() Type             { return "markdown" }
+func (c *markdownContent) MIMEType() string       { return "text/markdown" }
+func (c *markdownContent) Bytes() ([]byte, error) { return []byte(c.Source), nil }
+func (c *markdownContent) String() string         { return c.Source }
+
+func TestRegistry_CustomType(t *testing.T) {
+	r := NewRegistry()
+	r.Register("markdown", func() Content { return &markdownContent{} })
+
+	got, err := r.Unmarshal([]byte(`{"type":"markdown","source":"# Title"}`))
+	if err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if md, ok := got.(*markdownContent); !ok || md.Source != "# Title" {
+		t.Errorf("Unmarshal = %#v, want markdown content", got)
+	}
+
+	if _, err := Unmarshal([]byte(`{"type":"markdown"}`)); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("default registry error = %v, want ErrUnknownType", err)
+	}
+}
+
+func TestRegistry_Types(t *testing.T) {
+	types := NewRegistry().Types()
+	slices.Sort(types)
+	want := []Type{TypeAudio, TypeFile, TypeImage, TypeResource, TypeText}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("Types() = %v, want %v", types, want)
+	}
+}
+
+func TestList_JSON(t *testing.T) {
+	type message struct {
+		Content List `json:"content"`
+	}
+	in := message{Content: List{
+		NewText("hello"),
+		&AudioContent{Data: []byte{1}, Mime: "audio/wav", Duration: time.Second},
+		&FileContent{Mime: "text/csv", Path: "/a.csv", Size: 10},
+	}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var out message
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestList_JSON_Empty(t *testing.T) {
+	data, err := json.Marshal(List(nil))
+	if err != nil || string(data) != "[]" {
+		t.Errorf("Marshal(nil) = %s, %v, want []", data, err)
+	}
+	var l List
+	if err := json.Unmarshal([]byte("null"), &l); err != nil || l != nil {
+		t.Errorf("Unmarshal(null) = %v, %v, want nil", l, err)
+	}
+}
+
+func TestList_UnmarshalJSON_Error(t *testing.T) {
+	var l List
+	err := json.Unmarshal([]byte(`[{"type":"text","text":"ok"},{"type":"video"}]`), &l)
+	if !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("Unmarshal error = %v, want ErrUnknownType", err)
+	}
+	if want := `content[1]: content: unknown type: "video"`; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
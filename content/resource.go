@@ -13,6 +13,9 @@ type ResourceContent struct {
 
 	// Blob is binary data for the resource.
 	Blob []byte
+
+	// Annotations are optional audience, priority and modification hints.
+	Annotations *Annotations
 }
 
 // NewResource creates a new resource content.
@@ -0,0 +1,237 @@
+package content
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// sniffLen is the number of leading payload bytes used for sniffing, as
+// with http.DetectContentType.
+const sniffLen = 512
+
+// MIMEPolicy controls how declared MIME types are checked against the
+// payload they describe.
+type MIMEPolicy int
+
+const (
+	// MIMEKeep leaves MIME types as declared (the default).
+	MIMEKeep MIMEPolicy = iota
+
+	// MIMEFill sets missing MIME types of binary content from the
+	// sniffed payload, leaving declared types alone.
+	MIMEFill
+
+	// MIMEReject fills missing MIME types like MIMEFill and rejects
+	// content whose declared type contradicts the payload.
+	MIMEReject
+)
+
+// magic is a payload signature with the MIME type it identifies.
+type magic struct {
+	mime  string
+	match func(b []byte) bool
+}
+
+// magics are the signatures recognised before falling back to
+// http.DetectContentType.
+var magics = []magic{
+	{"image/png", prefix("\x89PNG\r\n\x1a\n")},
+	{"image/jpeg", prefix("\xff\xd8\xff")},
+	{"image/gif", func(b []byte) bool {
+		return bytes.HasPrefix(b, []byte("GIF87a")) || bytes.HasPrefix(b, []byte("GIF89a"))
+	}},
+	{"image/webp", riff("WEBP")},
+	{"audio/wav", riff("WAVE")},
+	{"audio/ogg", prefix("OggS")},
+	{"application/pdf", prefix("%PDF-")},
+	{"audio/mpeg", func(b []byte) bool {
+		if bytes.HasPrefix(b, []byte("ID3")) {
+			return true
+		}
+		// An MPEG audio frame sync with a valid layer.
+		return len(b) >= 2 && b[0] == 0xff && b[1]&0xe0 == 0xe0 && b[1]&0x06 != 0
+	}},
+}
+
+func prefix(sig string) func([]byte) bool {
+	return func(b []byte) bool { return bytes.HasPrefix(b, []byte(sig)) }
+}
+
+// riff matches a RIFF container of the given form type.
+func riff(form string) func([]byte) bool {
+	return func(b []byte) bool {
+		return len(b) >= 12 && string(b[:4]) == "RIFF" && string(b[8:12]) == form
+	}
+}
+
+// mimeAliases maps alternative spellings to the type SniffMIME reports.
+var mimeAliases = map[string]string{
+	"image/jpg":         "image/jpeg",
+	"image/pjpeg":       "image/jpeg",
+	"audio/mp3":         "audio/mpeg",
+	"audio/mpeg3":       "audio/mpeg",
+	"audio/x-mp3":       "audio/mpeg",
+	"audio/x-mpeg":      "audio/mpeg",
+	"audio/wave":        "audio/wav",
+	"audio/x-wav":       "audio/wav",
+	"audio/vnd.wave":    "audio/wav",
+	"application/x-pdf": "application/pdf",
+	"application/ogg":   "audio/ogg",
+	"video/ogg":         "audio/ogg",
+	"audio/opus":        "audio/ogg",
+	"audio/vorbis":      "audio/ogg",
+}
+
+// SniffMIME returns the MIME type of a payload from its leading bytes.
+// PNG, JPEG, GIF, WebP, WAV, MP3, Ogg and PDF are recognised by their
+// signatures; anything else uses http.DetectContentType. Parameters such
+// as charset are not included.
+func SniffMIME(data []byte) string {
+	mt, _ := sniff(data)
+	return mt
+}
+
+// sniff returns the detected MIME type and whether it is specific enough
+// to contradict a declared type. Generic results (octet-stream, text and
+// ZIP, which underlies many document formats) are not.
+func sniff(data []byte) (string, bool) {
+	data = data[:min(len(data), sniffLen)]
+	for _, m := range magics {
+		if m.match(data) {
+			return m.mime, true
+		}
+	}
+	mt := normalizeMIME(http.DetectContentType(data))
+	switch {
+	case mt == "application/octet-stream", mt == "application/zip", strings.HasPrefix(mt, "text/"):
+		return mt, false
+	}
+	return mt, true
+}
+
+// normalizeMIME lowercases a MIME type, drops its parameters and
+// resolves aliases.
+func normalizeMIME(mt string) string {
+	if parsed, _, err := mime.ParseMediaType(mt); err == nil {
+		mt = parsed
+	}
+	mt = strings.ToLower(strings.TrimSpace(mt))
+	if alias, ok := mimeAliases[mt]; ok {
+		return alias
+	}
+	return mt
+}
+
+// MIMEError reports content whose declared MIME type does not match its
+// payload.
+type MIMEError struct {
+	// Type is the content type.
+	Type Type
+
+	// Declared is the declared MIME type.
+	Declared string
+
+	// Detected is the MIME type sniffed from the payload.
+	Detected string
+}
+
+// Error implements the error interface.
+func (e *MIMEError) Error() string {
+	return fmt.Sprintf("content: %s declared as %s but payload is %s", e.Type, e.Declared, e.Detected)
+}
+
+// Unwrap returns ErrMIMEMismatch.
+func (e *MIMEError) Unwrap() error {
+	return ErrMIMEMismatch
+}
+
+// ValidateMIME checks the declared MIME type of binary content (images,
+// audio, files, resource blobs and lazy content) against its sniffed
+// payload and returns a *MIMEError on a mismatch. Text, empty payloads,
+// undeclared types and the generic application/octet-stream always pass.
+func ValidateMIME(c Content) error {
+	return checkMIME(c, MIMEReject, false)
+}
+
+// CheckMIME applies a MIME policy to content: MIMEFill and MIMEReject
+// set missing MIME types from the payload, and MIMEReject also returns a
+// *MIMEError for mismatches. Errors reading lazy content are returned.
+func CheckMIME(c Content, policy MIMEPolicy) error {
+	return checkMIME(c, policy, true)
+}
+
+// checkMIME implements CheckMIME. When fill is false missing types are
+// left unset.
+func checkMIME(c Content, policy MIMEPolicy, fill bool) error {
+	if policy == MIMEKeep {
+		return nil
+	}
+	mimeField, payload := mimeTarget(c)
+	if mimeField == nil {
+		return nil
+	}
+	head, err := payload()
+	if err != nil {
+		return fmt.Errorf("sniff %s content: %w", c.Type(), err)
+	}
+	if len(head) == 0 {
+		return nil
+	}
+	detected, strong := sniff(head)
+
+	if *mimeField == "" {
+		if fill {
+			*mimeField = detected
+		}
+		return nil
+	}
+	if policy != MIMEReject || !strong {
+		return nil
+	}
+	declared := normalizeMIME(*mimeField)
+	if declared == detected || declared == "application/octet-stream" {
+		return nil
+	}
+	return &MIMEError{Type: c.Type(), Declared: *mimeField, Detected: detected}
+}
+
+// mimeTarget returns the MIME field of binary content and a function
+// reading the start of its payload, or a nil field for content that is
+// not checked.
+func mimeTarget(c Content) (*string, func() ([]byte, error)) {
+	inMemory := func(b []byte) func() ([]byte, error) {
+		return func() ([]byte, error) { return b, nil }
+	}
+	switch c := c.(type) {
+	case *ImageContent:
+		return &c.Mime, inMemory(c.Data)
+	case *AudioContent:
+		return &c.Mime, inMemory(c.Data)
+	case *FileContent:
+		return &c.Mime, inMemory(c.Data)
+	case *ResourceContent:
+		if len(c.Blob) == 0 {
+			return nil, nil
+		}
+		return &c.Mime, inMemory(c.Blob)
+	case *LazyContent:
+		return &c.Mime, func() ([]byte, error) {
+			r, err := c.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+			head := make([]byte, sniffLen)
+			n, err := io.ReadFull(r, head)
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return nil, err
+			}
+			return head[:n], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,197 @@
+package content
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	pngData  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpegData = []byte("\xff\xd8\xff\xe0\x00\x10JFIF")
+	gifData  = []byte("GIF89a\x01\x00\x01\x00")
+	webpData = []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+	wavData  = []byte("RIFF\x24\x00\x00\x00WAVEfmt ")
+	mp3Data  = []byte("ID3\x04\x00\x00\x00\x00\x00\x00")
+	mp3Frame = []byte("\xff\xfb\x90\x64\x00")
+	oggData  = []byte("OggS\x00\x02\x00\x00")
+	pdfData  = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3")
+)
+
+func TestSniffMIME(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{pngData, "image/png"},
+		{jpegData, "image/jpeg"},
+		{gifData, "image/gif"},
+		{webpData, "image/webp"},
+		{wavData, "audio/wav"},
+		{mp3Data, "audio/mpeg"},
+		{mp3Frame, "audio/mpeg"},
+		{oggData, "audio/ogg"},
+		{pdfData, "application/pdf"},
+		{[]byte("<!DOCTYPE html><html></html>"), "text/html"},
+		{[]byte("plain words"), "text/plain"},
+		{[]byte{0x00, 0x01, 0x02}, "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		if got := SniffMIME(tt.data); got != tt.want {
+			t.Errorf("SniffMIME(%q) = %q, want %q", tt.data[:min(len(tt.data), 8)], got, tt.want)
+		}
+	}
+}
+
+func TestValidateMIME(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Content
+		ok   bool
+	}{
+		{"png as png", NewImage(pngData, "image/png"), true},
+		{"jpeg alias", NewImage(jpegData, "image/jpg"), true},
+		{"parameters ignored", NewAudio(mp3Data, "audio/MPEG; rate=44100"), true},
+		{"wav alias", NewAudio(wavData, "audio/x-wav"), true},
+		{"ogg as opus", NewAudio(oggData, "audio/opus"), true},
+		{"octet-stream is generic", NewFile(pdfData, "application/octet-stream"), true},
+		{"undeclared", &ImageContent{Data: pngData}, true},
+		{"empty payload", NewImage(nil, "image/png"), true},
+		{"text payload is weak", NewFile([]byte("a,b,c"), "text/csv"), true},
+		{"zip payload is weak", NewFile([]byte("PK\x03\x04rest"), "application/vnd.openxmlformats-officedocument.wordprocessingml.document"), true},
+		{"text content not checked", &TextContent{Text: "%PDF-1.7", Mime: "text/plain"}, true},
+		{"jpeg as png", NewImage(jpegData, "image/png"), false},
+		{"pdf as image", NewImage(pdfData, "image/png"), false},
+		{"wav as mp3", NewAudio(wavData, "audio/mpeg"), false},
+		{"resource blob", &ResourceContent{URI: "file:///a", Mime: "image/gif", Blob: pngData}, false},
+		{"lazy", NewLazy(TypeImage, ReaderAtSource(bytes.NewReader(gifData), int64(len(gifData))), "image/webp"), false},
+	}
+	for _, tt := range tests {
+		err := ValidateMIME(tt.c)
+		if tt.ok && err != nil {
+			t.Errorf("%s: ValidateMIME error = %v, want nil", tt.name, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrMIMEMismatch) {
+			t.Errorf("%s: ValidateMIME error = %v, want ErrMIMEMismatch", tt.name, err)
+		}
+	}
+}
+
+func TestValidateMIME_ErrorDetails(t *testing.T) {
+	err := ValidateMIME(NewImage(jpegData, "image/png"))
+	var mimeErr *MIMEError
+	if !errors.As(err, &mimeErr) {
+		t.Fatalf("error = %v, want *MIMEError", err)
+	}
+	if mimeErr.Type != TypeImage || mimeErr.Declared != "image/png" || mimeErr.Detected != "image/jpeg" {
+		t.Errorf("MIMEError = %+v", mimeErr)
+	}
+	if want := "content: image declared as image/png but payload is image/jpeg"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+}
+
+func TestCheckMIME_Policies(t *testing.T) {
+	c := &AudioContent{Data: wavData}
+	if err := CheckMIME(c, MIMEKeep); err != nil || c.Mime != "" {
+		t.Errorf("MIMEKeep: Mime = %q, err = %v, want unchanged", c.Mime, err)
+	}
+	if err := CheckMIME(c, MIMEFill); err != nil || c.Mime != "audio/wav" {
+		t.Errorf("MIMEFill: Mime = %q, err = %v, want audio/wav", c.Mime, err)
+	}
+
+	wrong := NewAudio(wavData, "audio/mpeg")
+	if err := CheckMIME(wrong, MIMEFill); err != nil {
+		t.Errorf("MIMEFill mismatch error = %v, want nil", err)
+	}
+	if err := CheckMIME(wrong, MIMEReject); !errors.Is(err, ErrMIMEMismatch) {
+		t.Errorf("MIMEReject error = %v, want ErrMIMEMismatch", err)
+	}
+
+	lazy := NewLazy(TypeFile, ReaderAtSource(bytes.NewReader(pdfData), int64(len(pdfData))), "")
+	if err := CheckMIME(lazy, MIMEReject); err != nil || lazy.Mime != "application/pdf" {
+		t.Errorf("lazy: Mime = %q, err = %v, want application/pdf", lazy.Mime, err)
+	}
+	broken := NewLazy(TypeFile, ReaderAtSource(failingReaderAt{}, 10), "")
+	if err := CheckMIME(broken, MIMEFill); err == nil {
+		t.Error("lazy read failure: error = nil, want error")
+	}
+	sourceless := &LazyContent{Kind: TypeImage}
+	if err := CheckMIME(sourceless, MIMEFill); !errors.Is(err, ErrNoSource) {
+		t.Errorf("lazy without source: CheckMIME error = %v, want ErrNoSource", err)
+	}
+	if err := ValidateMIME(sourceless); !errors.Is(err, ErrNoSource) {
+		t.Errorf("lazy without source: ValidateMIME error = %v, want ErrNoSource", err)
+	}
+	if err := NewBuilder(WithMIMEPolicy(MIMEReject)).Check(sourceless); !errors.Is(err, ErrNoSource) {
+		t.Errorf("lazy without source: Builder.Check error = %v, want ErrNoSource", err)
+	}
+}
+
+func TestBuilder_MIMEPolicy(t *testing.T) {
+	plain := NewBuilder()
+	if got := plain.Image(pngData, "").Mime; got != "" {
+		t.Errorf("default builder Mime = %q, want empty", got)
+	}
+
+	b := NewBuilder(WithMIMEPolicy(MIMEFill))
+	if got := b.Image(pngData, "").MIMEType(); got != "image/png" {
+		t.Errorf("Image MIMEType() = %q, want image/png", got)
+	}
+	if got := b.ImageWithAlt(gifData, "", "alt").MIMEType(); got != "image/gif" {
+		t.Errorf("ImageWithAlt MIMEType() = %q, want image/gif", got)
+	}
+	if got := b.Audio(oggData, "").MIMEType(); got != "audio/ogg" {
+		t.Errorf("Audio MIMEType() = %q, want audio/ogg", got)
+	}
+	if got := b.File(pdfData, "").MIMEType(); got != "application/pdf" {
+		t.Errorf("File MIMEType() = %q, want application/pdf", got)
+	}
+	if got := b.FileWithPath(pdfData, "", "/a.pdf").MIMEType(); got != "application/pdf" {
+		t.Errorf("FileWithPath MIMEType() = %q, want application/pdf", got)
+	}
+	if got := b.Image(jpegData, "image/png").MIMEType(); got != "image/png" {
+		t.Errorf("declared MIMEType() = %q, want image/png kept", got)
+	}
+
+	strict := NewBuilder(WithMIMEPolicy(MIMEReject))
+	// Constructors keep a contradicting declared type; Check rejects it.
+	mismatched := strict.Image(jpegData, "image/png")
+	if mismatched.Mime != "image/png" {
+		t.Errorf("strict Image Mime = %q, want declared image/png kept", mismatched.Mime)
+	}
+	if err := strict.Check(mismatched); !errors.Is(err, ErrMIMEMismatch) {
+		t.Errorf("Check error = %v, want ErrMIMEMismatch", err)
+	}
+	if err := strict.Check(strict.Image(jpegData, "")); err != nil {
+		t.Errorf("Check error = %v, want nil", err)
+	}
+}
+
+func TestRegistry_MIMEPolicy(t *testing.T) {
+	mismatched := `{"type":"image","mimeType":"image/png","data":"/9j/4AAQSkZJRg=="}`
+
+	if _, err := NewRegistry().Unmarshal([]byte(mismatched)); err != nil {
+		t.Errorf("default registry error = %v, want nil", err)
+	}
+	strict := NewRegistry(WithMIMEPolicy(MIMEReject))
+	if _, err := strict.Unmarshal([]byte(mismatched)); !errors.Is(err, ErrMIMEMismatch) {
+		t.Errorf("strict registry error = %v, want ErrMIMEMismatch", err)
+	}
+
+	// Audio without a MIME type decodes with the sniffed type.
+	untyped := `{"type":"audio","data":"` + EncodeBase64(wavData) + `"}`
+	c, err := NewRegistry(WithMIMEPolicy(MIMEFill)).Unmarshal([]byte(untyped))
+	if err != nil {
+		t.Fatalf("Unmarshal error = %v", err)
+	}
+	if got := c.MIMEType(); got != "audio/wav" {
+		t.Errorf("MIMEType() = %q, want audio/wav", got)
+	}
+
+	list, err := strict.UnmarshalList([]byte("[" + mismatched + "]"))
+	if !errors.Is(err, ErrMIMEMismatch) || list != nil || !strings.HasPrefix(err.Error(), "content[0]: ") {
+		t.Errorf("UnmarshalList = %v, %v, want content[0] mismatch", list, err)
+	}
+}
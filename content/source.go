@@ -0,0 +1,105 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Source provides the payload of lazily loaded content on demand.
+//
+// Contract:
+//   - Concurrency: Implementations must be safe for concurrent use; each
+//     Open call returns an independent reader.
+//   - Size: Size must not read the payload.
+//   - Ownership: Callers close the readers returned by Open.
+type Source interface {
+	// Size returns the payload size in bytes.
+	Size() (int64, error)
+
+	// Open returns a reader positioned at the start of the payload.
+	Open() (io.ReadCloser, error)
+}
+
+// readerAtSource is a Source over an io.ReaderAt of known size.
+type readerAtSource struct {
+	r    io.ReaderAt
+	size int64
+}
+
+// ReaderAtSource returns a Source reading size bytes from r. Readers
+// returned by Open share r but keep their own offsets.
+func ReaderAtSource(r io.ReaderAt, size int64) Source {
+	return &readerAtSource{r: r, size: size}
+}
+
+func (s *readerAtSource) Size() (int64, error) {
+	return s.size, nil
+}
+
+func (s *readerAtSource) Open() (io.ReadCloser, error) {
+	return io.NopCloser(io.NewSectionReader(s.r, 0, s.size)), nil
+}
+
+// fileSource is a Source over a file on disk.
+type fileSource struct {
+	path string
+}
+
+// FileSource returns a Source reading the file at path. The file is
+// opened on each Open call and its size comes from os.Stat.
+func FileSource(path string) Source {
+	return &fileSource{path: path}
+}
+
+func (s *fileSource) Size() (int64, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return 0, fmt.Errorf("stat %s: %w", s.path, err)
+	}
+	return info.Size(), nil
+}
+
+func (s *fileSource) Open() (io.ReadCloser, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", s.path, err)
+	}
+	return f, nil
+}
+
+// fsSource is a Source over a file in an fs.FS.
+type fsSource struct {
+	fsys fs.FS
+	name string
+}
+
+// FSSource returns a Source reading the named file from fsys. Its size
+// comes from fs.Stat.
+func FSSource(fsys fs.FS, name string) Source {
+	return &fsSource{fsys: fsys, name: name}
+}
+
+func (s *fsSource) Size() (int64, error) {
+	info, err := fs.Stat(s.fsys, s.name)
+	if err != nil {
+		return 0, fmt.Errorf("stat %s: %w", s.name, err)
+	}
+	if info.IsDir() {
+		return 0, fmt.Errorf("stat %s: %w", s.name, errIsDir)
+	}
+	return info.Size(), nil
+}
+
+func (s *fsSource) Open() (io.ReadCloser, error) {
+	f, err := s.fsys.Open(s.name)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", s.name, err)
+	}
+	return f, nil
+}
+
+// errIsDir is returned when an fs.FS source names a directory.
+var errIsDir = errors.New("is a directory")
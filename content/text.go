@@ -7,6 +7,9 @@ type TextContent struct {
 
 	// Mime is the MIME type (default: text/plain).
 	Mime string
+
+	// Annotations are optional audience, priority and modification hints.
+	Annotations *Annotations
 }
 
 // NewText creates a new text content.
@@ -0,0 +1,261 @@
+package content
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+)
+
+// maxImagePixels bounds the decoded size of images TranscodeImage
+// accepts, so a small compressed payload cannot exhaust memory.
+const maxImagePixels = 64 << 20
+
+// minJPEGQuality is the lowest quality used to fit a byte budget before
+// downscaling instead.
+const minJPEGQuality = 30
+
+// imageEncoders are the formats TranscodeImage can produce, by MIME type.
+// Quality applies to JPEG only.
+var imageEncoders = map[string]func(w io.Writer, img image.Image, quality int) error{
+	"image/png": func(w io.Writer, img image.Image, _ int) error {
+		enc := png.Encoder{CompressionLevel: png.BestCompression}
+		return enc.Encode(w, img)
+	},
+	"image/jpeg": func(w io.Writer, img image.Image, quality int) error {
+		return jpeg.Encode(w, flatten(img), &jpeg.Options{Quality: quality})
+	},
+	"image/gif": func(w io.Writer, img image.Image, _ int) error {
+		return gif.Encode(w, img, nil)
+	},
+}
+
+// ImageOption configures TranscodeImage.
+type ImageOption func(*imageOptions)
+
+// imageOptions holds the settings of TranscodeImage.
+type imageOptions struct {
+	maxDimension int
+	format       string
+	quality      int
+	maxBytes     int
+}
+
+// WithMaxDimension limits the longer side of the image to px pixels,
+// keeping the aspect ratio. Images are never enlarged; 0 means no limit.
+func WithMaxDimension(px int) ImageOption {
+	return func(o *imageOptions) {
+		o.maxDimension = px
+	}
+}
+
+// WithFormat sets the output MIME type: image/png, image/jpeg or
+// image/gif. The default keeps the source format.
+func WithFormat(mimeType string) ImageOption {
+	return func(o *imageOptions) {
+		o.format = mimeType
+	}
+}
+
+// WithQuality sets the JPEG quality from 1 to 100, clamped to that range.
+// The default is jpeg.DefaultQuality. Other formats ignore it.
+func WithQuality(q int) ImageOption {
+	return func(o *imageOptions) {
+		o.quality = min(max(q, 1), 100)
+	}
+}
+
+// WithMaxBytes sets a budget for the encoded image. JPEG quality is
+// lowered first, down to 30, and then the image is downscaled until it
+// fits. 0 means no limit.
+func WithMaxBytes(n int) ImageOption {
+	return func(o *imageOptions) {
+		o.maxBytes = n
+	}
+}
+
+// TranscodeImage decodes a PNG, JPEG or GIF image, downscales it,
+// re-encodes it and fits it within a byte budget as the options direct.
+// The returned content has the new data, its MIME type and its
+// dimensions, and keeps the URI, alt text and annotations of c.
+//
+// An image that already satisfies the options is not re-encoded, so the
+// result shares c.Data. Animated GIFs keep only their first frame, and
+// transparent areas become white in JPEG output.
+//
+// Undecodable data and unknown target formats return
+// ErrUnsupportedImage; images over 64 megapixels and images that cannot
+// fit the budget even at 1x1 return ErrImageTooLarge.
+func TranscodeImage(c *ImageContent, opts ...ImageOption) (*ImageContent, error) {
+	var o imageOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(c.Data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+	source := "image/" + format
+	target := normalizeMIME(o.format)
+	if target == "" {
+		target = source
+		if _, ok := imageEncoders[target]; !ok {
+			target = "image/png"
+		}
+	}
+	if _, ok := imageEncoders[target]; !ok {
+		return nil, fmt.Errorf("%w: cannot encode %s", ErrUnsupportedImage, o.format)
+	}
+	quality := o.quality
+	if quality == 0 {
+		quality = jpeg.DefaultQuality
+	}
+
+	out := &ImageContent{URI: c.URI, AltText: c.AltText, Annotations: c.Annotations.clone()}
+	w, h := fitDimensions(cfg.Width, cfg.Height, o.maxDimension)
+	if target == source && w == cfg.Width && h == cfg.Height &&
+		(o.quality == 0 || target != "image/jpeg") &&
+		(o.maxBytes <= 0 || len(c.Data) <= o.maxBytes) {
+		out.Data, out.Mime, out.Width, out.Height = c.Data, source, w, h
+		return out, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(c.Data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	var rgba *image.RGBA
+	for {
+		img := src
+		if b := src.Bounds(); w != b.Dx() || h != b.Dy() {
+			if rgba == nil {
+				rgba = toRGBA(src)
+			}
+			img = resize(rgba, w, h)
+		}
+		data, err := encodeWithin(img, target, quality, o.maxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("encode %s: %w", target, err)
+		}
+		if o.maxBytes <= 0 || len(data) <= o.maxBytes {
+			out.Data, out.Mime, out.Width, out.Height = data, target, w, h
+			return out, nil
+		}
+		if w == 1 && h == 1 {
+			return nil, fmt.Errorf("%w: %s does not fit in %d bytes", ErrImageTooLarge, target, o.maxBytes)
+		}
+		// Encoded size roughly tracks the pixel count, so shrink in
+		// proportion to the overshoot, by at least a tenth.
+		f := math.Min(0.9, math.Sqrt(float64(o.maxBytes)/float64(len(data))))
+		w, h = max(1, int(float64(w)*f)), max(1, int(float64(h)*f))
+	}
+}
+
+// encodeWithin encodes img as target, and for JPEG over a budget
+// searches for the highest quality from minJPEGQuality up to quality that
+// fits. When none fits it returns the smallest encoding tried.
+func encodeWithin(img image.Image, target string, quality, maxBytes int) ([]byte, error) {
+	at := func(q int) ([]byte, error) {
+		var buf bytes.Buffer
+		if err := imageEncoders[target](&buf, img, q); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	data, err := at(quality)
+	if err != nil || target != "image/jpeg" || maxBytes <= 0 || len(data) <= maxBytes || quality <= minJPEGQuality {
+		return data, err
+	}
+	smallest, err := at(minJPEGQuality)
+	if err != nil || len(smallest) > maxBytes {
+		return smallest, err
+	}
+	best := smallest
+	lo, hi := minJPEGQuality+1, quality-1
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		d, err := at(mid)
+		if err != nil {
+			return nil, err
+		}
+		if len(d) <= maxBytes {
+			best, lo = d, mid+1
+		} else {
+			hi = mid - 1
+		}
+	}
+	return best, nil
+}
+
+// fitDimensions scales w by h down so that neither side exceeds limit.
+func fitDimensions(w, h, limit int) (int, int) {
+	if limit <= 0 || (w <= limit && h <= limit) {
+		return w, h
+	}
+	if w >= h {
+		return limit, max(1, int(math.Round(float64(h)*float64(limit)/float64(w))))
+	}
+	return max(1, int(math.Round(float64(w)*float64(limit)/float64(h)))), limit
+}
+
+// toRGBA returns img as an *image.RGBA with its origin at (0, 0).
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Rect, img, b.Min, draw.Src)
+	return rgba
+}
+
+// resize scales src to w by h, averaging the source pixels each
+// destination pixel covers.
+func resize(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		y0 := y * sh / h
+		y1 := max((y+1)*sh/h, y0+1)
+		for x := range w {
+			x0 := x * sw / w
+			x1 := max((x+1)*sw/w, x0+1)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[src.PixOffset(x0, sy):src.PixOffset(x1, sy)]
+				for i, v := range row {
+					sum[i%4] += int(v)
+				}
+			}
+			n := (x1 - x0) * (y1 - y0)
+			px := dst.Pix[dst.PixOffset(x, y):]
+			for i := range sum {
+				px[i] = uint8((sum[i] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+// flatten composites an image with transparency over white, since JPEG
+// has no alpha channel.
+func flatten(img image.Image) image.Image {
+	if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+		return img
+	}
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Rect, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Rect, img, b.Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,226 @@
+package content
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand/v2"
+	"testing"
+)
+
+// testPNG encodes a w by h PNG. Noisy images compress poorly, which makes
+// byte budgets bite; smooth ones are gradients.
+func testPNG(t *testing.T, w, h int, noisy bool) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	rng := rand.New(rand.NewPCG(1, 2))
+	for y := range h {
+		for x := range w {
+			c := color.NRGBA{uint8(x), uint8(y), 128, 255}
+			if noisy {
+				c = color.NRGBA{uint8(rng.Uint32()), uint8(rng.Uint32()), uint8(rng.Uint32()), 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// checkImage decodes c and verifies its data agrees with its metadata.
+func checkImage(t *testing.T, c *ImageContent) {
+	t.Helper()
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(c.Data))
+	if err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if c.Mime != "image/"+format || SniffMIME(c.Data) != c.Mime {
+		t.Errorf("Mime = %q, data is %s", c.Mime, format)
+	}
+	if c.Width != cfg.Width || c.Height != cfg.Height {
+		t.Errorf("dimensions = %dx%d, data is %dx%d", c.Width, c.Height, cfg.Width, cfg.Height)
+	}
+}
+
+func TestTranscodeImage_MaxDimension(t *testing.T) {
+	tests := []struct {
+		w, h, limit   int
+		wantW, wantH  int
+		wantUnchanged bool
+	}{
+		{w: 400, h: 200, limit: 100, wantW: 100, wantH: 50},
+		{w: 200, h: 400, limit: 100, wantW: 50, wantH: 100},
+		{w: 300, h: 1, limit: 100, wantW: 100, wantH: 1},
+		{w: 80, h: 60, limit: 100, wantW: 80, wantH: 60, wantUnchanged: true},
+	}
+	for _, tt := range tests {
+		src := NewImage(testPNG(t, tt.w, tt.h, false), "image/png")
+		got, err := TranscodeImage(src, WithMaxDimension(tt.limit))
+		if err != nil {
+			t.Fatalf("TranscodeImage(%dx%d) error = %v", tt.w, tt.h, err)
+		}
+		checkImage(t, got)
+		if got.Width != tt.wantW || got.Height != tt.wantH {
+			t.Errorf("TranscodeImage(%dx%d) = %dx%d, want %dx%d", tt.w, tt.h, got.Width, got.Height, tt.wantW, tt.wantH)
+		}
+		if unchanged := bytes.Equal(got.Data, src.Data); unchanged != tt.wantUnchanged {
+			t.Errorf("TranscodeImage(%dx%d) unchanged = %v, want %v", tt.w, tt.h, unchanged, tt.wantUnchanged)
+		}
+	}
+}
+
+func TestTranscodeImage_Format(t *testing.T) {
+	src := NewImage(testPNG(t, 64, 32, false), "")
+	for _, format := range []string{"image/jpeg", "image/jpg", "image/gif", "image/png"} {
+		got, err := TranscodeImage(src, WithFormat(format), WithQuality(90))
+		if err != nil {
+			t.Fatalf("TranscodeImage(%s) error = %v", format, err)
+		}
+		checkImage(t, got)
+		if got.Mime != normalizeMIME(format) {
+			t.Errorf("TranscodeImage(%s) Mime = %q", format, got.Mime)
+		}
+	}
+
+	if _, err := TranscodeImage(src, WithFormat("image/webp")); !errors.Is(err, ErrUnsupportedImage) {
+		t.Errorf("TranscodeImage(webp) error = %v, want ErrUnsupportedImage", err)
+	}
+}
+
+func TestTranscodeImage_JPEGFlattensTransparency(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	got, err := TranscodeImage(NewImage(buf.Bytes(), "image/png"), WithFormat("image/jpeg"))
+	if err != nil {
+		t.Fatalf("TranscodeImage error = %v", err)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(got.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := decoded.At(8, 8).RGBA(); r < 0xf000 || g < 0xf000 || b < 0xf000 {
+		t.Errorf("transparent pixel = %v, want white", decoded.At(8, 8))
+	}
+}
+
+func TestTranscodeImage_MaxBytes(t *testing.T) {
+	src := NewImage(testPNG(t, 256, 256, true), "image/png")
+	for _, format := range []string{"image/png", "image/jpeg", "image/gif"} {
+		const budget = 16 << 10
+		got, err := TranscodeImage(src, WithFormat(format), WithMaxBytes(budget))
+		if err != nil {
+			t.Fatalf("TranscodeImage(%s) error = %v", format, err)
+		}
+		checkImage(t, got)
+		if len(got.Data) > budget {
+			t.Errorf("TranscodeImage(%s) = %d bytes, want at most %d", format, len(got.Data), budget)
+		}
+		if got.Width >= 256 && format != "image/jpeg" {
+			t.Errorf("TranscodeImage(%s) width = %d, want downscaled", format, got.Width)
+		}
+	}
+}
+
+func TestTranscodeImage_MaxBytesLowersJPEGQualityFirst(t *testing.T) {
+	src := NewImage(testPNG(t, 128, 128, false), "image/png")
+	full, err := TranscodeImage(src, WithFormat("image/jpeg"), WithQuality(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget := len(full.Data) * 2 / 3
+	got, err := TranscodeImage(full, WithQuality(100), WithMaxBytes(budget))
+	if err != nil {
+		t.Fatalf("TranscodeImage error = %v", err)
+	}
+	if len(got.Data) > budget || got.Width != 128 || got.Height != 128 {
+		t.Errorf("TranscodeImage = %d bytes at %dx%d, want at most %d bytes at 128x128", len(got.Data), got.Width, got.Height, budget)
+	}
+}
+
+func TestTranscodeImage_WithinBudgetUnchanged(t *testing.T) {
+	data := testPNG(t, 32, 32, false)
+	src := &ImageContent{
+		Data:        data,
+		Mime:        "image/png",
+		URI:         "file:///shot.png",
+		AltText:     "Screenshot",
+		Annotations: &Annotations{Audience: []Role{RoleUser}},
+	}
+	got, err := TranscodeImage(src, WithMaxBytes(len(data)), WithMaxDimension(32))
+	if err != nil {
+		t.Fatalf("TranscodeImage error = %v", err)
+	}
+	if !bytes.Equal(got.Data, data) || got.Width != 32 || got.Height != 32 {
+		t.Errorf("TranscodeImage = %d bytes at %dx%d, want source unchanged", len(got.Data), got.Width, got.Height)
+	}
+	if got.URI != src.URI || got.AltText != src.AltText || !got.Annotations.For(RoleUser) || got.Annotations.For(RoleAssistant) {
+		t.Errorf("TranscodeImage metadata = %+v, want copied from %+v", got, src)
+	}
+	if got == src || got.Annotations == src.Annotations {
+		t.Error("TranscodeImage shares the source content or annotations")
+	}
+}
+
+func TestTranscodeImage_Errors(t *testing.T) {
+	if _, err := TranscodeImage(NewImage([]byte("not an image"), "image/png")); !errors.Is(err, ErrUnsupportedImage) {
+		t.Errorf("undecodable: error = %v, want ErrUnsupportedImage", err)
+	}
+
+	src := NewImage(testPNG(t, 64, 64, true), "image/png")
+	if _, err := TranscodeImage(src, WithMaxBytes(10)); !errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("tiny budget: error = %v, want ErrImageTooLarge", err)
+	}
+
+	if _, err := TranscodeImage(NewImage(pngHeader(100000, 100000), "image/png")); !errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("huge image: error = %v, want ErrImageTooLarge", err)
+	}
+}
+
+// pngHeader returns the signature and header chunk of a w by h PNG,
+// enough for image.DecodeConfig.
+func pngHeader(w, h uint32) []byte {
+	ihdr := binary.BigEndian.AppendUint32([]byte("IHDR"), w)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, h)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+	out := []byte("\x89PNG\r\n\x1a\n")
+	out = binary.BigEndian.AppendUint32(out, uint32(len(ihdr)-4))
+	out = append(out, ihdr...)
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestFitDimensions(t *testing.T) {
+	tests := []struct{ w, h, limit, wantW, wantH int }{
+		{1920, 1080, 0, 1920, 1080},
+		{1920, 1080, 1280, 1280, 720},
+		{1080, 1920, 1280, 720, 1280},
+		{1000, 1000, 500, 500, 500},
+		{5000, 2, 100, 100, 1},
+	}
+	for _, tt := range tests {
+		if w, h := fitDimensions(tt.w, tt.h, tt.limit); w != tt.wantW || h != tt.wantH {
+			t.Errorf("fitDimensions(%d, %d, %d) = %d, %d, want %d, %d", tt.w, tt.h, tt.limit, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestResize_Averages(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	src.Set(1, 0, color.RGBA{255, 0, 0, 255})
+	src.Set(0, 1, color.RGBA{0, 0, 255, 255})
+	src.Set(1, 1, color.RGBA{0, 0, 255, 255})
+	got := resize(src, 1, 1).RGBAAt(0, 0)
+	if want := (color.RGBA{128, 0, 128, 255}); got != want {
+		t.Errorf("resize = %v, want %v", got, want)
+	}
+}
@@ -2,8 +2,17 @@ package wire
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/prompt"
+	"github.com/jonwraymond/toolprotocol/resource"
+	"github.com/jonwraymond/toolprotocol/task"
 )
 
 // A2AVersion is the A2A protocol version.
@@ -35,234 +44,451 @@ type a2aRequest struct {
 	Params  map[string]any `json:"params,omitempty"`
 }
 
-// a2aResponse is the A2A JSON-RPC response format.
-type a2aResponse struct {
+// a2aMessageRequest is an A2A JSON-RPC request carrying a message.
+type a2aMessageRequest struct {
 	JSONRPC string         `json:"jsonrpc"`
 	ID      any            `json:"id"`
-	Result  map[string]any `json:"result,omitempty"`
-	Error   *jsonrpcError  `json:"error,omitempty"`
+	Method  string         `json:"method"`
+	Params  a2aMessageBody `json:"params"`
+}
+
+// a2aMessageBody is A2AMessageSendParams plus the legacy 0.2 task id.
+type a2aMessageBody struct {
+	ID       string         `json:"id,omitempty"`
+	Message  A2AMessage     `json:"message"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// a2aResponse is the A2A JSON-RPC response format.
+type a2aResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      any             `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonrpcError   `json:"error,omitempty"`
+}
+
+// a2aSkillKey is the message metadata key naming the invoked skill.
+const a2aSkillKey = "skillId"
+
+// isA2ATaskMethod reports whether method takes task-id params
+// rather than a message.
+func isA2ATaskMethod(method string) bool {
+	switch method {
+	case A2AMethodTasksGet, A2AMethodTasksCancel, A2AMethodTasksResubscribe:
+		return true
+	}
+	return strings.HasPrefix(method, "tasks/pushNotificationConfig/")
 }
 
 // EncodeRequest encodes a Request to A2A format.
+//
+// Tool invocations ("tools/call" or an empty method) become message/send.
+// The message carries the arguments as a data part and the tool name in
+// its metadata under "skillId"; Meta becomes the params metadata, except
+// "contextId" and "taskId", which are set on the message. Task methods
+// (tasks/get, tasks/cancel, tasks/resubscribe) send Arguments as params,
+// so the task ID is expected in Arguments["id"].
 func (w *A2AWire) EncodeRequest(ctx context.Context, req *Request) ([]byte, error) {
-	rpc := a2aRequest{
-		JSONRPC: "2.0",
-		ID:      req.ID,
-		Method:  req.Method,
-		Params: map[string]any{
-			"id": req.ID,
-			"message": map[string]any{
-				"role": "user",
-				"parts": []map[string]any{
-					{
-						"kind": "text",
-						"text": fmt.Sprintf("invoke %s with %v", req.ToolID, req.Arguments),
-					},
-				},
-			},
-		},
+	method := req.Method
+	if method == "" || method == "tools/call" {
+		method = A2AMethodMessageSend
 	}
 
-	// Store tool info in params
-	if req.ToolID != "" {
-		rpc.Params["skillId"] = req.ToolID
+	if isA2ATaskMethod(method) {
+		params := make(map[string]any, len(req.Arguments)+1)
+		for k, v := range req.Arguments {
+			params[k] = v
+		}
+		if len(req.Meta) > 0 {
+			params["metadata"] = req.Meta
+		}
+		return json.Marshal(a2aRequest{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Method:  method,
+			Params:  params,
+		})
+	}
+
+	msg := A2AMessage{
+		Kind:      "message",
+		MessageID: req.ID,
+		Role:      A2ARoleUser,
+		Parts:     []A2APart{},
+	}
+	if msg.MessageID == "" {
+		msg.MessageID = newMessageID()
 	}
 	if len(req.Arguments) > 0 {
-		rpc.Params["arguments"] = req.Arguments
+		msg.Parts = append(msg.Parts, A2APart{Kind: A2APartData, Data: req.Arguments})
+	}
+	if req.ToolID != "" {
+		msg.Metadata = map[string]any{a2aSkillKey: req.ToolID}
+	}
+
+	var metadata map[string]any
+	for k, v := range req.Meta {
+		switch k {
+		case "contextId":
+			if s, ok := v.(string); ok {
+				msg.ContextID = s
+				continue
+			}
+		case "taskId":
+			if s, ok := v.(string); ok {
+				msg.TaskID = s
+				continue
+			}
+		}
+		if metadata == nil {
+			metadata = make(map[string]any, len(req.Meta))
+		}
+		metadata[k] = v
 	}
-	if len(req.Meta) > 0 {
-		rpc.Params["_meta"] = req.Meta
+
+	body := a2aMessageBody{Message: msg, Metadata: metadata}
+	if method == "tasks/send" || method == "tasks/sendSubscribe" {
+		// A2A 0.2 carried the task ID in params.
+		body.ID = req.ID
 	}
+	return json.Marshal(a2aMessageRequest{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Method:  method,
+		Params:  body,
+	})
+}
 
-	return json.Marshal(rpc)
+// a2aMessageIn is the decoding form of the message in request params.
+// Data parts stay raw.
+type a2aMessageIn struct {
+	Parts []struct {
+		Kind string          `json:"kind"`
+		Type string          `json:"type"`
+		Text string          `json:"text"`
+		Data json.RawMessage `json:"data"`
+	} `json:"parts"`
+	ContextID string `json:"contextId"`
+	TaskID    string `json:"taskId"`
+	Metadata  struct {
+		SkillID looseString `json:"skillId"`
+	} `json:"metadata"`
 }
 
 // DecodeRequest decodes A2A format to a Request.
+//
+// Message requests yield the skill from message metadata, Arguments from
+// the first data part (or {"text": ...} when the message has only text),
+// and Meta from the params metadata plus the message's contextId and
+// taskId. Other methods yield their params as Arguments.
 func (w *A2AWire) DecodeRequest(ctx context.Context, data []byte) (*Request, error) {
-	var rpc a2aRequest
+	return decodeRequest(ctx, w, data)
+}
+
+// DecodeRawRequest decodes A2A format to a RawRequest, as DecodeRequest
+// does.
+func (w *A2AWire) DecodeRawRequest(ctx context.Context, data []byte) (*RawRequest, error) {
+	var rpc struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
 	if err := json.Unmarshal(data, &rpc); err != nil {
 		return nil, fmt.Errorf("decode a2a request: %w", err)
 	}
 
-	req := &Request{
+	req := &RawRequest{
+		ID:     rawID(rpc.ID),
 		Method: rpc.Method,
 	}
+	if !isJSONObject(rpc.Params) {
+		return req, nil
+	}
+	var params map[string]json.RawMessage
+	if err := json.Unmarshal(rpc.Params, &params); err != nil {
+		return nil, fmt.Errorf("decode a2a request: %w", err)
+	}
+	// Task methods never carry a message; for them a "message" param is
+	// an ordinary argument, such as a cancellation reason.
+	if isA2ATaskMethod(rpc.Method) || !isJSONObject(params["message"]) {
+		decodeA2AParams(req, params)
+		return req, nil
+	}
+	var msg a2aMessageIn
+	if err := json.Unmarshal(params["message"], &msg); err != nil {
+		return nil, fmt.Errorf("decode a2a request: %w", err)
+	}
+	var taskID, skillID looseString
+	_ = taskID.UnmarshalJSON(params["id"])
+	_ = skillID.UnmarshalJSON(params[a2aSkillKey])
+	if taskID != "" {
+		// A2A 0.2 task ID.
+		req.ID = string(taskID)
+	}
 
-	// Handle ID
-	switch id := rpc.ID.(type) {
-	case string:
-		req.ID = id
-	case float64:
-		req.ID = fmt.Sprintf("%.0f", id)
+	req.ToolID = string(msg.Metadata.SkillID)
+	if req.ToolID == "" {
+		req.ToolID = string(skillID)
 	}
 
-	if rpc.Params != nil {
-		// Try to get task ID from params
-		if taskID, ok := rpc.Params["id"].(string); ok {
-			req.ID = taskID
+	var texts []string
+	for _, p := range msg.Parts {
+		kind := p.Kind
+		if kind == "" {
+			// A2A 0.2 discriminated parts by type.
+			kind = p.Type
+		}
+		switch kind {
+		case A2APartData:
+			if req.Arguments == nil {
+				req.Arguments = objectOrNil(p.Data)
+			}
+		case A2APartText:
+			texts = append(texts, p.Text)
 		}
-		if skillID, ok := rpc.Params["skillId"].(string); ok {
-			req.ToolID = skillID
+	}
+	if req.Arguments == nil {
+		req.Arguments = objectOrNil(params["arguments"])
+	}
+	if req.Arguments == nil && len(texts) > 0 {
+		req.Arguments, _ = json.Marshal(map[string]string{"text": strings.Join(texts, "\n")})
+	}
+
+	meta := objectOrNil(params["metadata"])
+	if meta == nil {
+		meta = objectOrNil(params["_meta"])
+	}
+	if msg.ContextID != "" || msg.TaskID != "" {
+		var m map[string]json.RawMessage
+		if meta != nil {
+			if err := json.Unmarshal(meta, &m); err != nil {
+				return nil, fmt.Errorf("decode a2a request: %w", err)
+			}
+		} else {
+			m = make(map[string]json.RawMessage, 2)
 		}
-		if args, ok := rpc.Params["arguments"].(map[string]any); ok {
-			req.Arguments = args
+		if msg.ContextID != "" {
+			m["contextId"], _ = json.Marshal(msg.ContextID)
 		}
-		if meta, ok := rpc.Params["_meta"].(map[string]any); ok {
-			req.Meta = meta
+		if msg.TaskID != "" {
+			m["taskId"], _ = json.Marshal(msg.TaskID)
 		}
+		meta, _ = json.Marshal(m)
 	}
+	req.Meta = meta
 
 	return req, nil
 }
 
+// decodeA2AParams fills req from the params of a request without a
+// message: metadata, skillId and arguments keep their meaning, and the
+// remaining params become the arguments.
+func decodeA2AParams(req *RawRequest, params map[string]json.RawMessage) {
+	req.Meta = objectOrNil(params["metadata"])
+	if req.Meta == nil {
+		req.Meta = objectOrNil(params["_meta"])
+	}
+	var skill looseString
+	_ = skill.UnmarshalJSON(params[a2aSkillKey])
+	req.ToolID = string(skill)
+	req.Arguments = objectOrNil(params["arguments"])
+	if req.Arguments != nil {
+		return
+	}
+	for _, k := range []string{"metadata", "_meta", a2aSkillKey, "arguments"} {
+		delete(params, k)
+	}
+	if len(params) > 0 {
+		// Marshaling raw messages cannot fail.
+		req.Arguments, _ = json.Marshal(params)
+	}
+}
+
 // EncodeResponse encodes a Response to A2A format.
+//
+// Successful responses become an A2A Task. The task ID and context ID come
+// from Meta["taskId"] and Meta["contextId"] (the task ID defaults to the
+// response ID), and the state from Meta["status"]["state"] or
+// Meta["state"] (default "completed"). Content is returned as a single
+// artifact whose parts preserve content order; structured content is
+// appended as a data part. The remaining Meta is sent as task metadata.
 func (w *A2AWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, error) {
-	rpc := a2aResponse{
-		JSONRPC: "2.0",
-		ID:      resp.ID,
+	if resp.IsError && resp.Error != nil {
+		return json.Marshal(a2aResponse{
+			JSONRPC: "2.0",
+			ID:      resp.ID,
+			Error: &jsonrpcError{
+				Code:    resp.Error.Code,
+				Message: resp.Error.Message,
+				Data:    resp.Error.Data,
+			},
+		})
 	}
 
-	if resp.IsError && resp.Error != nil {
-		rpc.Error = &jsonrpcError{
-			Code:    resp.Error.Code,
-			Message: resp.Error.Message,
-			Data:    resp.Error.Data,
-		}
-	} else {
-		// A2A uses status and artifacts
-		artifacts := make([]map[string]any, 0)
-		for _, c := range resp.Content {
-			part := map[string]any{}
-			switch c.Type {
-			case ContentTypeText:
-				part["kind"] = "text"
-				part["text"] = c.Text
-			case ContentTypeImage:
-				part["kind"] = "data"
-				part["data"] = c.Data
-				part["mimeType"] = c.MIMEType
-			case ContentTypeResource:
-				part["kind"] = "file"
-				part["uri"] = c.URI
-				if c.MIMEType != "" {
-					part["mimeType"] = c.MIMEType
-				}
+	t := A2ATask{
+		Kind:   "task",
+		ID:     resp.ID,
+		Status: A2ATaskStatus{State: A2ATaskStateCompleted},
+	}
+	var metadata map[string]any
+	for k, v := range resp.Meta {
+		switch k {
+		case "taskId":
+			if s, ok := v.(string); ok {
+				t.ID = s
+				continue
 			}
-			artifacts = append(artifacts, map[string]any{"parts": []map[string]any{part}})
-		}
-
-		status := map[string]any{
-			"state": "completed",
-		}
-		if resp.Meta != nil {
-			if metaStatus, ok := resp.Meta["status"].(map[string]any); ok {
-				if state, ok := metaStatus["state"].(string); ok {
-					status["state"] = state
-				}
-				for k, v := range metaStatus {
-					status[k] = v
+		case "contextId":
+			if s, ok := v.(string); ok {
+				t.ContextID = s
+				continue
+			}
+		case "status":
+			// A bare {"state": ...} is what DecodeResponse synthesizes from
+			// the task status; it is carried by the status field alone.
+			if s, ok := v.(map[string]any); ok && len(s) == 1 {
+				if _, ok := s["state"].(string); ok {
+					continue
 				}
-			} else if state, ok := resp.Meta["state"].(string); ok {
-				status["state"] = state
 			}
 		}
-
-		rpc.Result = map[string]any{
-			"status":    status,
-			"artifacts": artifacts,
-		}
-		if resp.Meta != nil {
-			if taskID, ok := resp.Meta["taskId"]; ok {
-				rpc.Result["taskId"] = taskID
-			}
+		if metadata == nil {
+			metadata = make(map[string]any, len(resp.Meta))
 		}
-		if len(resp.Meta) > 0 {
-			rpc.Result["_meta"] = resp.Meta
+		metadata[k] = v
+	}
+	if state, ok := resp.Meta["state"].(string); ok {
+		t.Status.State = A2ATaskState(state)
+	}
+	if status, ok := resp.Meta["status"].(map[string]any); ok {
+		if state, ok := status["state"].(string); ok {
+			t.Status.State = A2ATaskState(state)
 		}
 	}
+	t.Metadata = metadata
+
+	if parts := A2APartsFromContent(resp.Content, resp.StructuredContent); len(parts) > 0 {
+		t.Artifacts = []A2AArtifact{{ArtifactID: "result", Parts: parts}}
+	}
 
-	return json.Marshal(rpc)
+	result, err := json.Marshal(&t)
+	if err != nil {
+		return nil, fmt.Errorf("encode a2a response: %w", err)
+	}
+	return json.Marshal(a2aResponse{
+		JSONRPC: "2.0",
+		ID:      resp.ID,
+		Result:  result,
+	})
 }
 
 // DecodeResponse decodes A2A format to a Response.
+//
+// Any A2A result kind is accepted. Content comes from artifact parts (or
+// the status message of a task without artifacts), and Meta combines the
+// result metadata with "taskId", "contextId" and "status" where present.
 func (w *A2AWire) DecodeResponse(ctx context.Context, data []byte) (*Response, error) {
 	var rpc a2aResponse
 	if err := json.Unmarshal(data, &rpc); err != nil {
 		return nil, fmt.Errorf("decode a2a response: %w", err)
 	}
 
-	resp := &Response{}
-
-	// Handle ID
-	switch id := rpc.ID.(type) {
-	case string:
-		resp.ID = id
-	case float64:
-		resp.ID = fmt.Sprintf("%.0f", id)
-	}
+	resp := &Response{ID: rpcID(rpc.ID)}
 
 	if rpc.Error != nil {
 		resp.IsError = true
-		resp.Error = &Error{
-			Code:    rpc.Error.Code,
-			Message: rpc.Error.Message,
-			Data:    rpc.Error.Data,
+		resp.Error = decodedError("a2a", rpc.Error.Code, rpc.Error.Message, rpc.Error.Data)
+		return resp, nil
+	}
+	if len(rpc.Result) == 0 || string(rpc.Result) == "null" {
+		return resp, nil
+	}
+
+	result, err := decodeA2AResult(rpc.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := map[string]any{}
+	setStatus := func(status A2ATaskStatus) {
+		if _, ok := meta["status"]; !ok && status.State != "" {
+			meta["status"] = map[string]any{"state": string(status.State)}
 		}
-	} else if rpc.Result != nil {
-		if resp.Meta == nil {
-			resp.Meta = map[string]any{}
+	}
+	switch r := result.(type) {
+	case *A2ATask:
+		maps.Copy(meta, r.Metadata)
+		for _, a := range r.Artifacts {
+			content, structured := A2APartsToContent(a.Parts)
+			resp.Content = append(resp.Content, content...)
+			if resp.StructuredContent == nil {
+				resp.StructuredContent = structured
+			}
 		}
-		if status, ok := rpc.Result["status"].(map[string]any); ok {
-			resp.Meta["status"] = status
+		if len(r.Artifacts) == 0 && r.Status.Message != nil {
+			resp.Content, resp.StructuredContent = A2APartsToContent(r.Status.Message.Parts)
 		}
-		if taskID, ok := rpc.Result["taskId"]; ok {
-			resp.Meta["taskId"] = taskID
+		putString(meta, "taskId", r.ID)
+		putString(meta, "contextId", r.ContextID)
+		setStatus(r.Status)
+		// A2A 0.2 results carried metadata under _meta.
+		var legacy struct {
+			TaskID any            `json:"taskId"`
+			Meta   map[string]any `json:"_meta"`
 		}
-		// Extract content from artifacts
-		if artifacts, ok := rpc.Result["artifacts"].([]any); ok {
-			for _, artifact := range artifacts {
-				if artMap, ok := artifact.(map[string]any); ok {
-					if parts, ok := artMap["parts"].([]any); ok {
-						for _, part := range parts {
-							if partMap, ok := part.(map[string]any); ok {
-								c := Content{}
-								if kind, ok := partMap["kind"].(string); ok {
-									switch kind {
-									case "text":
-										c.Type = ContentTypeText
-										if text, ok := partMap["text"].(string); ok {
-											c.Text = text
-										}
-									case "data":
-										c.Type = ContentTypeImage
-										if mime, ok := partMap["mimeType"].(string); ok {
-											c.MIMEType = mime
-										}
-									case "file":
-										c.Type = ContentTypeResource
-										if uri, ok := partMap["uri"].(string); ok {
-											c.URI = uri
-										}
-									}
-								}
-								resp.Content = append(resp.Content, c)
-							}
-						}
-					}
-				}
+		if json.Unmarshal(rpc.Result, &legacy) == nil {
+			if legacy.TaskID != nil {
+				meta["taskId"] = legacy.TaskID
 			}
+			maps.Copy(meta, legacy.Meta)
 		}
-		if meta, ok := rpc.Result["_meta"].(map[string]any); ok {
-			resp.Meta = meta
+	case *A2AMessage:
+		maps.Copy(meta, r.Metadata)
+		resp.Content, resp.StructuredContent = A2APartsToContent(r.Parts)
+		putString(meta, "messageId", r.MessageID)
+		putString(meta, "taskId", r.TaskID)
+		putString(meta, "contextId", r.ContextID)
+	case *A2ATaskStatusUpdateEvent:
+		maps.Copy(meta, r.Metadata)
+		if r.Status.Message != nil {
+			resp.Content, resp.StructuredContent = A2APartsToContent(r.Status.Message.Parts)
 		}
+		putString(meta, "taskId", r.TaskID)
+		putString(meta, "contextId", r.ContextID)
+		setStatus(r.Status)
+		meta["final"] = r.Final
+	case *A2ATaskArtifactUpdateEvent:
+		maps.Copy(meta, r.Metadata)
+		resp.Content, resp.StructuredContent = A2APartsToContent(r.Artifact.Parts)
+		putString(meta, "taskId", r.TaskID)
+		putString(meta, "contextId", r.ContextID)
+		putString(meta, "artifactId", r.Artifact.ArtifactID)
+	}
+	if len(meta) > 0 {
+		resp.Meta = meta
 	}
 
 	return resp, nil
 }
 
+// putString sets m[key] to v when v is non-empty and key is unset.
+func putString(m map[string]any, key, v string) {
+	if _, ok := m[key]; !ok && v != "" {
+		m[key] = v
+	}
+}
+
+// newMessageID returns a random message identifier.
+func newMessageID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
 // a2aSkillList is the A2A skills list format.
 type a2aSkillList struct {
-	Skills []a2aSkill `json:"skills"`
+	Skills     []a2aSkill `json:"skills"`
+	NextCursor string     `json:"nextCursor,omitempty"`
 }
 
 type a2aSkill struct {
@@ -274,11 +500,26 @@ type a2aSkill struct {
 
 // EncodeToolList encodes a tool list to A2A format.
 func (w *A2AWire) EncodeToolList(ctx context.Context, tools []Tool) ([]byte, error) {
+	return w.EncodeToolPage(ctx, &Page[Tool]{Items: tools})
+}
+
+// DecodeToolList decodes A2A format to a tool list.
+func (w *A2AWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+	page, err := w.DecodeToolPage(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// EncodeToolPage encodes one page of a skill list.
+func (w *A2AWire) EncodeToolPage(ctx context.Context, page *Page[Tool]) ([]byte, error) {
 	list := a2aSkillList{
-		Skills: make([]a2aSkill, 0, len(tools)),
+		Skills:     make([]a2aSkill, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
 	}
 
-	for _, t := range tools {
+	for _, t := range page.Items {
 		list.Skills = append(list.Skills, a2aSkill{
 			ID:          t.Name,
 			Name:        t.Name,
@@ -290,8 +531,8 @@ func (w *A2AWire) EncodeToolList(ctx context.Context, tools []Tool) ([]byte, err
 	return json.Marshal(list)
 }
 
-// DecodeToolList decodes A2A format to a tool list.
-func (w *A2AWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+// DecodeToolPage decodes one page of a skill list.
+func (w *A2AWire) DecodeToolPage(ctx context.Context, data []byte) (*Page[Tool], error) {
 	var list a2aSkillList
 	if err := json.Unmarshal(data, &list); err != nil {
 		return nil, fmt.Errorf("decode a2a tool list: %w", err)
@@ -310,7 +551,127 @@ func (w *A2AWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, erro
 		})
 	}
 
-	return tools, nil
+	return &Page[Tool]{Items: tools, NextCursor: list.NextCursor}, nil
+}
+
+// EncodeResourcePage returns ErrUnsupportedFeature: A2A agents return
+// files and data as message parts and artifacts, and list no resources.
+func (w *A2AWire) EncodeResourcePage(ctx context.Context, page *Page[resource.Resource]) ([]byte, error) {
+	return nil, fmt.Errorf("%w: a2a has no resource list", ErrUnsupportedFeature)
+}
+
+// DecodeResourcePage returns ErrUnsupportedFeature, as EncodeResourcePage does.
+func (w *A2AWire) DecodeResourcePage(ctx context.Context, data []byte) (*Page[resource.Resource], error) {
+	return nil, fmt.Errorf("%w: a2a has no resource list", ErrUnsupportedFeature)
+}
+
+// EncodePromptPage returns ErrUnsupportedFeature: A2A agents describe
+// what they accept as skills in their agent card, not as prompts.
+func (w *A2AWire) EncodePromptPage(ctx context.Context, page *Page[prompt.Prompt]) ([]byte, error) {
+	return nil, fmt.Errorf("%w: a2a has no prompt list", ErrUnsupportedFeature)
+}
+
+// DecodePromptPage returns ErrUnsupportedFeature, as EncodePromptPage does.
+func (w *A2AWire) DecodePromptPage(ctx context.Context, data []byte) (*Page[prompt.Prompt], error) {
+	return nil, fmt.Errorf("%w: a2a has no prompt list", ErrUnsupportedFeature)
+}
+
+// a2aTaskList is the A2A task list format.
+type a2aTaskList struct {
+	Tasks      []A2ATask `json:"tasks"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+}
+
+// EncodeTaskPage encodes one page of a task list as A2A tasks. A task's
+// message becomes the text of its status message, and its update time
+// the status timestamp; A2A tasks have no creation time.
+func (w *A2AWire) EncodeTaskPage(ctx context.Context, page *Page[*task.Task]) ([]byte, error) {
+	list := a2aTaskList{
+		Tasks:      make([]A2ATask, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, t := range page.Items {
+		at := A2ATask{Kind: "task", ID: t.ID, Status: A2ATaskStatus{State: a2aTaskState(t.State)}}
+		if t.Message != "" {
+			at.Status.Message = &A2AMessage{
+				Kind:      "message",
+				MessageID: t.ID + "-status",
+				Role:      A2ARoleAgent,
+				Parts:     []A2APart{{Kind: A2APartText, Text: t.Message}},
+				TaskID:    t.ID,
+			}
+		}
+		if !t.UpdatedAt.IsZero() {
+			at.Status.Timestamp = t.UpdatedAt.Format(time.RFC3339Nano)
+		}
+		list.Tasks = append(list.Tasks, at)
+	}
+	return json.Marshal(list)
+}
+
+// DecodeTaskPage decodes one page of an A2A task list. A2A states
+// without a task manager counterpart, such as input-required, decode as
+// running.
+func (w *A2AWire) DecodeTaskPage(ctx context.Context, data []byte) (*Page[*task.Task], error) {
+	var list a2aTaskList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decode a2a task list: %w", err)
+	}
+	tasks := make([]*task.Task, 0, len(list.Tasks))
+	for _, at := range list.Tasks {
+		t := &task.Task{ID: at.ID, State: taskStateFromA2A(at.Status.State)}
+		if m := at.Status.Message; m != nil {
+			for _, part := range m.Parts {
+				if part.Kind == A2APartText {
+					t.Message = part.Text
+					break
+				}
+			}
+		}
+		if at.Status.Timestamp != "" {
+			ts, err := time.Parse(time.RFC3339Nano, at.Status.Timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("decode a2a task list: %w: task %q timestamp: %w", ErrDecodeFailure, at.ID, err)
+			}
+			t.UpdatedAt = ts
+		}
+		tasks = append(tasks, t)
+	}
+	return &Page[*task.Task]{Items: tasks, NextCursor: list.NextCursor}, nil
+}
+
+// a2aTaskState maps task manager states to A2A task states.
+func a2aTaskState(s task.State) A2ATaskState {
+	switch s {
+	case task.StatePending:
+		return A2ATaskStateSubmitted
+	case task.StateRunning:
+		return A2ATaskStateWorking
+	case task.StateComplete:
+		return A2ATaskStateCompleted
+	case task.StateFailed:
+		return A2ATaskStateFailed
+	case task.StateCancelled:
+		return A2ATaskStateCanceled
+	default:
+		return A2ATaskStateUnknown
+	}
+}
+
+// taskStateFromA2A maps A2A task states to task manager states.
+func taskStateFromA2A(s A2ATaskState) task.State {
+	switch s {
+	case A2ATaskStateSubmitted:
+		return task.StatePending
+	case A2ATaskStateCompleted:
+		return task.StateComplete
+	case A2ATaskStateFailed, A2ATaskStateRejected:
+		return task.StateFailed
+	case A2ATaskStateCanceled:
+		return task.StateCancelled
+	default:
+		return task.StateRunning
+	}
 }
 
 // Capabilities returns A2A protocol capabilities.
@@ -0,0 +1,545 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// A2A JSON-RPC method names (A2A 0.3).
+const (
+	A2AMethodMessageSend      = "message/send"
+	A2AMethodMessageStream    = "message/stream"
+	A2AMethodTasksGet         = "tasks/get"
+	A2AMethodTasksCancel      = "tasks/cancel"
+	A2AMethodTasksResubscribe = "tasks/resubscribe"
+)
+
+// A2A part kinds.
+const (
+	A2APartText = "text"
+	A2APartFile = "file"
+	A2APartData = "data"
+)
+
+// A2ARole identifies the sender of an A2A message.
+type A2ARole string
+
+const (
+	// A2ARoleUser is a message from the client.
+	A2ARoleUser A2ARole = "user"
+
+	// A2ARoleAgent is a message from the remote agent.
+	A2ARoleAgent A2ARole = "agent"
+)
+
+// A2ATaskState is the lifecycle state of an A2A task.
+type A2ATaskState string
+
+const (
+	A2ATaskStateSubmitted     A2ATaskState = "submitted"
+	A2ATaskStateWorking       A2ATaskState = "working"
+	A2ATaskStateInputRequired A2ATaskState = "input-required"
+	A2ATaskStateCompleted     A2ATaskState = "completed"
+	A2ATaskStateCanceled      A2ATaskState = "canceled"
+	A2ATaskStateFailed        A2ATaskState = "failed"
+	A2ATaskStateRejected      A2ATaskState = "rejected"
+	A2ATaskStateAuthRequired  A2ATaskState = "auth-required"
+	A2ATaskStateUnknown       A2ATaskState = "unknown"
+)
+
+// IsTerminal reports whether no further updates follow this state.
+func (s A2ATaskState) IsTerminal() bool {
+	switch s {
+	case A2ATaskStateCompleted, A2ATaskStateCanceled, A2ATaskStateFailed, A2ATaskStateRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// A2AFile is the file payload of a file part. Exactly one of Bytes or URI
+// is set.
+type A2AFile struct {
+	Name     string `json:"name,omitempty"`
+	MIMEType string `json:"mimeType,omitempty"`
+	Bytes    []byte `json:"bytes,omitempty"`
+	URI      string `json:"uri,omitempty"`
+}
+
+// A2APart is one part of a message or artifact, discriminated by Kind.
+type A2APart struct {
+	Kind     string         `json:"kind"`
+	Text     string         `json:"text,omitempty"`
+	File     *A2AFile       `json:"file,omitempty"`
+	Data     map[string]any `json:"data,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// UnmarshalJSON decodes a part, accepting the A2A 0.2 "type"
+// discriminator when "kind" is absent.
+func (p *A2APart) UnmarshalJSON(data []byte) error {
+	type part A2APart
+	var v struct {
+		part
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*p = A2APart(v.part)
+	if p.Kind == "" {
+		p.Kind = v.Type
+	}
+	return nil
+}
+
+// A2AMessage is a single turn of communication between client and agent.
+type A2AMessage struct {
+	Kind             string         `json:"kind"`
+	MessageID        string         `json:"messageId"`
+	Role             A2ARole        `json:"role"`
+	Parts            []A2APart      `json:"parts"`
+	ContextID        string         `json:"contextId,omitempty"`
+	TaskID           string         `json:"taskId,omitempty"`
+	ReferenceTaskIDs []string       `json:"referenceTaskIds,omitempty"`
+	Extensions       []string       `json:"extensions,omitempty"`
+	Metadata         map[string]any `json:"metadata,omitempty"`
+}
+
+// A2ATaskStatus is the current status of a task.
+type A2ATaskStatus struct {
+	State     A2ATaskState `json:"state"`
+	Message   *A2AMessage  `json:"message,omitempty"`
+	Timestamp string       `json:"timestamp,omitempty"`
+}
+
+// A2AArtifact is an output produced by a task.
+type A2AArtifact struct {
+	ArtifactID  string         `json:"artifactId"`
+	Name        string         `json:"name,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Parts       []A2APart      `json:"parts"`
+	Extensions  []string       `json:"extensions,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// A2ATask is a stateful unit of work tracked by the remote agent.
+type A2ATask struct {
+	Kind      string         `json:"kind"`
+	ID        string         `json:"id"`
+	ContextID string         `json:"contextId"`
+	Status    A2ATaskStatus  `json:"status"`
+	History   []A2AMessage   `json:"history,omitempty"`
+	Artifacts []A2AArtifact  `json:"artifacts,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// A2ATaskStatusUpdateEvent is streamed when a task's status changes.
+type A2ATaskStatusUpdateEvent struct {
+	Kind      string         `json:"kind"`
+	TaskID    string         `json:"taskId"`
+	ContextID string         `json:"contextId"`
+	Status    A2ATaskStatus  `json:"status"`
+	Final     bool           `json:"final"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// A2ATaskArtifactUpdateEvent is streamed when a task produces an artifact
+// or an artifact chunk.
+type A2ATaskArtifactUpdateEvent struct {
+	Kind      string         `json:"kind"`
+	TaskID    string         `json:"taskId"`
+	ContextID string         `json:"contextId"`
+	Artifact  A2AArtifact    `json:"artifact"`
+	Append    bool           `json:"append,omitempty"`
+	LastChunk bool           `json:"lastChunk,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// A2AMessageSendConfiguration tunes message/send and message/stream.
+type A2AMessageSendConfiguration struct {
+	AcceptedOutputModes []string `json:"acceptedOutputModes,omitempty"`
+	HistoryLength       *int     `json:"historyLength,omitempty"`
+	Blocking            bool     `json:"blocking,omitempty"`
+}
+
+// A2AMessageSendParams are the params of message/send and message/stream.
+type A2AMessageSendParams struct {
+	Message       A2AMessage                   `json:"message"`
+	Configuration *A2AMessageSendConfiguration `json:"configuration,omitempty"`
+	Metadata      map[string]any               `json:"metadata,omitempty"`
+}
+
+// A2ATaskQueryParams are the params of tasks/get.
+type A2ATaskQueryParams struct {
+	ID            string         `json:"id"`
+	HistoryLength *int           `json:"historyLength,omitempty"`
+	Metadata      map[string]any `json:"metadata,omitempty"`
+}
+
+// A2ATaskIDParams are the params of tasks/cancel and tasks/resubscribe.
+type A2ATaskIDParams struct {
+	ID       string         `json:"id"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// A2AResult is a value returned by an A2A method: *A2ATask, *A2AMessage,
+// *A2ATaskStatusUpdateEvent, or *A2ATaskArtifactUpdateEvent.
+type A2AResult interface {
+	a2aKind() string
+}
+
+func (*A2ATask) a2aKind() string                    { return "task" }
+func (*A2AMessage) a2aKind() string                 { return "message" }
+func (*A2ATaskStatusUpdateEvent) a2aKind() string   { return "status-update" }
+func (*A2ATaskArtifactUpdateEvent) a2aKind() string { return "artifact-update" }
+
+// A2ACall is a decoded A2A JSON-RPC request with raw params.
+type A2ACall struct {
+	// ID is the JSON-RPC request identifier.
+	ID string
+
+	// Method is the A2A method name.
+	Method string
+
+	// Params are the undecoded method params.
+	Params json.RawMessage
+}
+
+// MessageSendParams decodes params of message/send or message/stream.
+func (c *A2ACall) MessageSendParams() (*A2AMessageSendParams, error) {
+	var p A2AMessageSendParams
+	if err := c.decodeParams(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// TaskQueryParams decodes params of tasks/get.
+func (c *A2ACall) TaskQueryParams() (*A2ATaskQueryParams, error) {
+	var p A2ATaskQueryParams
+	if err := c.decodeParams(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// TaskIDParams decodes params of tasks/cancel or tasks/resubscribe.
+func (c *A2ACall) TaskIDParams() (*A2ATaskIDParams, error) {
+	var p A2ATaskIDParams
+	if err := c.decodeParams(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (c *A2ACall) decodeParams(v any) error {
+	if len(c.Params) == 0 {
+		return fmt.Errorf("decode %s params: %w: missing params", c.Method, ErrDecodeFailure)
+	}
+	if err := json.Unmarshal(c.Params, v); err != nil {
+		return fmt.Errorf("decode %s params: %w", c.Method, err)
+	}
+	return nil
+}
+
+// EncodeCall encodes an A2A JSON-RPC request with typed params.
+func (w *A2AWire) EncodeCall(ctx context.Context, id, method string, params any) ([]byte, error) {
+	if p, ok := params.(*A2AMessageSendParams); ok && p.Message.Kind == "" {
+		cp := *p
+		cp.Message.Kind = "message"
+		params = &cp
+	}
+	return json.Marshal(struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      string `json:"id"`
+		Method  string `json:"method"`
+		Params  any    `json:"params,omitempty"`
+	}{"2.0", id, method, params})
+}
+
+// DecodeCall decodes an A2A JSON-RPC request, leaving params undecoded.
+func (w *A2AWire) DecodeCall(ctx context.Context, data []byte) (*A2ACall, error) {
+	var rpc struct {
+		ID     any             `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return nil, fmt.Errorf("decode a2a call: %w", err)
+	}
+	return &A2ACall{ID: rpcID(rpc.ID), Method: rpc.Method, Params: rpc.Params}, nil
+}
+
+// EncodeMessageSend encodes a message/send request.
+func (w *A2AWire) EncodeMessageSend(ctx context.Context, id string, params *A2AMessageSendParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, A2AMethodMessageSend, params)
+}
+
+// EncodeMessageStream encodes a message/stream request.
+func (w *A2AWire) EncodeMessageStream(ctx context.Context, id string, params *A2AMessageSendParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, A2AMethodMessageStream, params)
+}
+
+// EncodeTasksGet encodes a tasks/get request.
+func (w *A2AWire) EncodeTasksGet(ctx context.Context, id string, params *A2ATaskQueryParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, A2AMethodTasksGet, params)
+}
+
+// EncodeTasksCancel encodes a tasks/cancel request.
+func (w *A2AWire) EncodeTasksCancel(ctx context.Context, id string, params *A2ATaskIDParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, A2AMethodTasksCancel, params)
+}
+
+// EncodeTasksResubscribe encodes a tasks/resubscribe request.
+func (w *A2AWire) EncodeTasksResubscribe(ctx context.Context, id string, params *A2ATaskIDParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, A2AMethodTasksResubscribe, params)
+}
+
+var _ NotificationCodec = (*A2AWire)(nil)
+
+// EncodeProgress encodes progress as a status-update event for the task
+// named by the token, in the working state. A2A has no progress
+// notifications, so the amounts and message travel in the event
+// metadata as "progress", "total" and "message". Task IDs are strings,
+// so an integer token decodes as its decimal text.
+func (w *A2AWire) EncodeProgress(ctx context.Context, p *Progress) ([]byte, error) {
+	if p.Token.IsZero() {
+		return nil, fmt.Errorf("%w: progress token required", ErrEncodeFailure)
+	}
+	meta := map[string]any{"progress": p.Progress}
+	if p.Total > 0 {
+		meta["total"] = p.Total
+	}
+	if p.Message != "" {
+		meta["message"] = p.Message
+	}
+	return w.EncodeResult(ctx, p.Token.String(), &A2ATaskStatusUpdateEvent{
+		TaskID:   p.Token.String(),
+		Status:   A2ATaskStatus{State: A2ATaskStateWorking},
+		Metadata: meta,
+	})
+}
+
+// DecodeProgress decodes a status-update event into progress for its
+// task. Without a "message" in the metadata, the text of the status
+// message is used.
+func (w *A2AWire) DecodeProgress(ctx context.Context, data []byte) (*Progress, error) {
+	_, result, err := w.DecodeResult(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("decode progress: %w", err)
+	}
+	ev, ok := result.(*A2ATaskStatusUpdateEvent)
+	if !ok {
+		return nil, fmt.Errorf("decode progress: %w: not a status-update event", ErrDecodeFailure)
+	}
+	if ev.TaskID == "" {
+		return nil, fmt.Errorf("decode progress: %w: missing task id", ErrDecodeFailure)
+	}
+	p := &Progress{Token: StringID(ev.TaskID)}
+	p.Progress, _ = ev.Metadata["progress"].(float64)
+	p.Total, _ = ev.Metadata["total"].(float64)
+	p.Message, _ = ev.Metadata["message"].(string)
+	if p.Message == "" && ev.Status.Message != nil {
+		for _, part := range ev.Status.Message.Parts {
+			if part.Kind == A2APartText {
+				p.Message = part.Text
+				break
+			}
+		}
+	}
+	return p, nil
+}
+
+// EncodeCancellation encodes a tasks/cancel request for the task named
+// by the request ID. The reason travels in the params metadata.
+func (w *A2AWire) EncodeCancellation(ctx context.Context, c *Cancellation) ([]byte, error) {
+	if c.RequestID.IsZero() {
+		return nil, fmt.Errorf("%w: request id required", ErrEncodeFailure)
+	}
+	params := &A2ATaskIDParams{ID: c.RequestID.String()}
+	if c.Reason != "" {
+		params.Metadata = map[string]any{"reason": c.Reason}
+	}
+	return w.EncodeTasksCancel(ctx, c.RequestID.String(), params)
+}
+
+// DecodeCancellation decodes a tasks/cancel request into a cancellation
+// whose request ID is the task ID.
+func (w *A2AWire) DecodeCancellation(ctx context.Context, data []byte) (*Cancellation, error) {
+	call, err := w.DecodeCall(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("decode cancellation: %w", err)
+	}
+	if call.Method != A2AMethodTasksCancel {
+		return nil, fmt.Errorf("decode cancellation: %w: not a tasks/cancel request", ErrDecodeFailure)
+	}
+	params, err := call.TaskIDParams()
+	if err != nil {
+		return nil, fmt.Errorf("decode cancellation: %w", err)
+	}
+	if params.ID == "" {
+		return nil, fmt.Errorf("decode cancellation: %w: missing task id", ErrDecodeFailure)
+	}
+	reason, _ := params.Metadata["reason"].(string)
+	return &Cancellation{RequestID: StringID(params.ID), Reason: reason}, nil
+}
+
+// EncodeResult encodes a JSON-RPC response carrying an A2A result.
+// For message/stream, each streamed event is encoded with EncodeResult.
+// The Kind discriminator of the result is filled in automatically.
+func (w *A2AWire) EncodeResult(ctx context.Context, id string, result A2AResult) ([]byte, error) {
+	// Copy before setting Kind so the caller's value is not modified.
+	switch r := result.(type) {
+	case *A2ATask:
+		cp := *r
+		cp.Kind = r.a2aKind()
+		result = &cp
+	case *A2AMessage:
+		cp := *r
+		cp.Kind = r.a2aKind()
+		result = &cp
+	case *A2ATaskStatusUpdateEvent:
+		cp := *r
+		cp.Kind = r.a2aKind()
+		result = &cp
+	case *A2ATaskArtifactUpdateEvent:
+		cp := *r
+		cp.Kind = r.a2aKind()
+		result = &cp
+	default:
+		return nil, fmt.Errorf("encode a2a result: %w: unsupported result %T", ErrEncodeFailure, result)
+	}
+	return json.Marshal(struct {
+		JSONRPC string    `json:"jsonrpc"`
+		ID      string    `json:"id"`
+		Result  A2AResult `json:"result"`
+	}{"2.0", id, result})
+}
+
+// DecodeResult decodes a JSON-RPC response carrying an A2A result,
+// dispatching on the result's "kind". JSON-RPC errors are returned as *Error.
+func (w *A2AWire) DecodeResult(ctx context.Context, data []byte) (string, A2AResult, error) {
+	var rpc struct {
+		ID     any             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *jsonrpcError   `json:"error"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return "", nil, fmt.Errorf("decode a2a result: %w", err)
+	}
+	id := rpcID(rpc.ID)
+	if rpc.Error != nil {
+		return id, nil, decodedError("a2a", rpc.Error.Code, rpc.Error.Message, rpc.Error.Data)
+	}
+	if len(rpc.Result) == 0 {
+		return id, nil, fmt.Errorf("decode a2a result: %w: missing result", ErrDecodeFailure)
+	}
+	result, err := decodeA2AResult(rpc.Result)
+	if err != nil {
+		return id, nil, err
+	}
+	return id, result, nil
+}
+
+// decodeA2AResult decodes a result object by its kind discriminator.
+// Results without a kind are treated as tasks for A2A 0.2 compatibility.
+func decodeA2AResult(data json.RawMessage) (A2AResult, error) {
+	var head struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("decode a2a result: %w", err)
+	}
+	var result A2AResult
+	switch head.Kind {
+	case "task", "":
+		result = &A2ATask{}
+	case "message":
+		result = &A2AMessage{}
+	case "status-update":
+		result = &A2ATaskStatusUpdateEvent{}
+	case "artifact-update":
+		result = &A2ATaskArtifactUpdateEvent{}
+	default:
+		return nil, fmt.Errorf("decode a2a result: %w: unknown kind %q", ErrDecodeFailure, head.Kind)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("decode a2a result: %w", err)
+	}
+	return result, nil
+}
+
+// A2APartsFromContent converts response content to A2A parts.
+// Text becomes a text part, images and audio become inline file parts,
+// files become named file parts, resources become file parts by URI, and
+// structured content becomes a data part.
+func A2APartsFromContent(content []Content, structured map[string]any) []A2APart {
+	parts := make([]A2APart, 0, len(content)+1)
+	for _, c := range content {
+		switch c.Type {
+		case ContentTypeText:
+			parts = append(parts, A2APart{Kind: A2APartText, Text: c.Text})
+		case ContentTypeImage, ContentTypeAudio:
+			parts = append(parts, A2APart{Kind: A2APartFile, File: &A2AFile{MIMEType: c.MIMEType, Bytes: c.Data}})
+		case ContentTypeFile:
+			file := &A2AFile{Name: c.Name, MIMEType: c.MIMEType, Bytes: c.Data}
+			if len(c.Data) == 0 {
+				file.URI = c.URI
+			}
+			parts = append(parts, A2APart{Kind: A2APartFile, File: file})
+		case ContentTypeResource:
+			parts = append(parts, A2APart{Kind: A2APartFile, File: &A2AFile{MIMEType: c.MIMEType, URI: c.URI}})
+		}
+	}
+	if structured != nil {
+		parts = append(parts, A2APart{Kind: A2APartData, Data: structured})
+	}
+	return parts
+}
+
+// A2APartsToContent converts A2A parts to response content. The first data
+// part is returned as structured content; later data parts are rendered as
+// JSON text.
+func A2APartsToContent(parts []A2APart) ([]Content, map[string]any) {
+	var content []Content
+	var structured map[string]any
+	for _, p := range parts {
+		switch p.Kind {
+		case A2APartText:
+			content = append(content, Content{Type: ContentTypeText, Text: p.Text})
+		case A2APartFile:
+			if p.File == nil {
+				continue
+			}
+			content = append(content, a2aFileContent(p.File))
+		case A2APartData:
+			if structured == nil {
+				structured = p.Data
+				continue
+			}
+			text, err := json.Marshal(p.Data)
+			if err == nil {
+				content = append(content, Content{Type: ContentTypeText, Text: string(text)})
+			}
+		}
+	}
+	return content, structured
+}
+
+// a2aFileContent converts the file of a file part to content. Named files
+// become file content; otherwise URIs become resources, audio bytes audio
+// and other bytes images.
+func a2aFileContent(f *A2AFile) Content {
+	switch {
+	case f.Name != "":
+		return Content{Type: ContentTypeFile, Name: f.Name, Data: f.Bytes, URI: f.URI, MIMEType: f.MIMEType}
+	case f.URI != "":
+		return Content{Type: ContentTypeResource, URI: f.URI, MIMEType: f.MIMEType}
+	case strings.HasPrefix(f.MIMEType, "audio/"):
+		return Content{Type: ContentTypeAudio, Data: f.Bytes, MIMEType: f.MIMEType}
+	}
+	return Content{Type: ContentTypeImage, Data: f.Bytes, MIMEType: f.MIMEType}
+}
@@ -0,0 +1,436 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestA2AWire_MessageSend_RoundTrip(t *testing.T) {
+	w := NewA2A()
+	ctx := context.Background()
+
+	params := &A2AMessageSendParams{
+		Message: A2AMessage{
+			MessageID: "msg-1",
+			Role:      A2ARoleUser,
+			ContextID: "ctx-1",
+			Parts: []A2APart{
+				{Kind: A2APartText, Text: "find docs"},
+				{Kind: A2APartData, Data: map[string]any{"query": "golang"}},
+			},
+		},
+		Configuration: &A2AMessageSendConfiguration{AcceptedOutputModes: []string{"text/plain"}, Blocking: true},
+	}
+	data, err := w.EncodeMessageSend(ctx, "1", params)
+	if err != nil {
+		t.Fatalf("EncodeMessageSend error = %v", err)
+	}
+	if params.Message.Kind != "" {
+		t.Errorf("EncodeMessageSend modified caller's message kind")
+	}
+
+	var raw struct {
+		Method string `json:"method"`
+		Params struct {
+			Message map[string]any `json:"message"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if raw.Method != A2AMethodMessageSend {
+		t.Errorf("method = %q, want %q", raw.Method, A2AMethodMessageSend)
+	}
+	if raw.Params.Message["kind"] != "message" {
+		t.Errorf("message.kind = %v, want %q", raw.Params.Message["kind"], "message")
+	}
+
+	call, err := w.DecodeCall(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeCall error = %v", err)
+	}
+	if call.ID != "1" || call.Method != A2AMethodMessageSend {
+		t.Errorf("call = %s %s, want 1 %s", call.ID, call.Method, A2AMethodMessageSend)
+	}
+	got, err := call.MessageSendParams()
+	if err != nil {
+		t.Fatalf("MessageSendParams error = %v", err)
+	}
+	if got.Message.ContextID != "ctx-1" || len(got.Message.Parts) != 2 {
+		t.Errorf("message = %+v, want contextId and two parts", got.Message)
+	}
+	if got.Message.Parts[1].Data["query"] != "golang" {
+		t.Errorf("data part = %v, want query", got.Message.Parts[1].Data)
+	}
+	if got.Configuration == nil || !got.Configuration.Blocking {
+		t.Errorf("configuration = %+v, want blocking", got.Configuration)
+	}
+}
+
+func TestA2AWire_TaskMethods(t *testing.T) {
+	w := NewA2A()
+	ctx := context.Background()
+	history := 2
+
+	tests := []struct {
+		method string
+		encode func() ([]byte, error)
+	}{
+		{A2AMethodTasksGet, func() ([]byte, error) {
+			return w.EncodeTasksGet(ctx, "1", &A2ATaskQueryParams{ID: "task-1", HistoryLength: &history})
+		}},
+		{A2AMethodTasksCancel, func() ([]byte, error) {
+			return w.EncodeTasksCancel(ctx, "1", &A2ATaskIDParams{ID: "task-1"})
+		}},
+		{A2AMethodTasksResubscribe, func() ([]byte, error) {
+			return w.EncodeTasksResubscribe(ctx, "1", &A2ATaskIDParams{ID: "task-1"})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			data, err := tt.encode()
+			if err != nil {
+				t.Fatalf("encode error = %v", err)
+			}
+			call, err := w.DecodeCall(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeCall error = %v", err)
+			}
+			if call.Method != tt.method {
+				t.Errorf("Method = %q, want %q", call.Method, tt.method)
+			}
+			p, err := call.TaskIDParams()
+			if err != nil {
+				t.Fatalf("TaskIDParams error = %v", err)
+			}
+			if p.ID != "task-1" {
+				t.Errorf("ID = %q, want %q", p.ID, "task-1")
+			}
+
+			// The generic decoder exposes task params as arguments.
+			req, err := w.DecodeRequest(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeRequest error = %v", err)
+			}
+			if req.Method != tt.method || req.Arguments["id"] != "task-1" {
+				t.Errorf("Request = %+v, want %s with id argument", req, tt.method)
+			}
+		})
+	}
+
+	data, _ := w.EncodeTasksGet(ctx, "1", &A2ATaskQueryParams{ID: "task-1", HistoryLength: &history})
+	call, _ := w.DecodeCall(ctx, data)
+	q, err := call.TaskQueryParams()
+	if err != nil {
+		t.Fatalf("TaskQueryParams error = %v", err)
+	}
+	if q.HistoryLength == nil || *q.HistoryLength != 2 {
+		t.Errorf("HistoryLength = %v, want 2", q.HistoryLength)
+	}
+}
+
+func TestA2ACall_MissingParams(t *testing.T) {
+	call, err := NewA2A().DecodeCall(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tasks/get"}`))
+	if err != nil {
+		t.Fatalf("DecodeCall error = %v", err)
+	}
+	if call.ID != "1" {
+		t.Errorf("ID = %q, want %q", call.ID, "1")
+	}
+	if _, err := call.TaskQueryParams(); !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("TaskQueryParams error = %v, want ErrDecodeFailure", err)
+	}
+}
+
+func TestA2AWire_Result_RoundTrip(t *testing.T) {
+	w := NewA2A()
+	ctx := context.Background()
+
+	results := []A2AResult{
+		&A2ATask{
+			ID:        "task-1",
+			ContextID: "ctx-1",
+			Status:    A2ATaskStatus{State: A2ATaskStateCompleted},
+			Artifacts: []A2AArtifact{{ArtifactID: "a1", Parts: []A2APart{{Kind: A2APartText, Text: "done"}}}},
+		},
+		&A2AMessage{MessageID: "m1", Role: A2ARoleAgent, Parts: []A2APart{{Kind: A2APartText, Text: "hi"}}},
+		&A2ATaskStatusUpdateEvent{TaskID: "task-1", ContextID: "ctx-1", Status: A2ATaskStatus{State: A2ATaskStateWorking}},
+		&A2ATaskArtifactUpdateEvent{TaskID: "task-1", ContextID: "ctx-1", Artifact: A2AArtifact{ArtifactID: "a1"}, Append: true, LastChunk: true},
+	}
+	for _, want := range results {
+		t.Run(want.a2aKind(), func(t *testing.T) {
+			data, err := w.EncodeResult(ctx, "7", want)
+			if err != nil {
+				t.Fatalf("EncodeResult error = %v", err)
+			}
+			id, got, err := w.DecodeResult(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeResult error = %v", err)
+			}
+			if id != "7" {
+				t.Errorf("id = %q, want %q", id, "7")
+			}
+			if reflect.TypeOf(got) != reflect.TypeOf(want) {
+				t.Fatalf("result = %T, want %T", got, want)
+			}
+			// Encoding again must reproduce the same payload.
+			again, err := w.EncodeResult(ctx, "7", got)
+			if err != nil {
+				t.Fatalf("EncodeResult(again) error = %v", err)
+			}
+			if string(again) != string(data) {
+				t.Errorf("re-encoded = %s, want %s", again, data)
+			}
+		})
+	}
+}
+
+func TestA2AWire_Notifications(t *testing.T) {
+	w := NewA2A()
+	ctx := context.Background()
+
+	data, err := w.EncodeProgress(ctx, &Progress{Token: StringID("task-1"), Progress: 3, Total: 10, Message: "indexing"})
+	if err != nil {
+		t.Fatalf("EncodeProgress error = %v", err)
+	}
+	id, result, err := w.DecodeResult(ctx, data)
+	ev, ok := result.(*A2ATaskStatusUpdateEvent)
+	if err != nil || !ok || id != "task-1" || ev.TaskID != "task-1" || ev.Status.State != A2ATaskStateWorking || ev.Final {
+		t.Fatalf("EncodeProgress = %s, want a working status-update for task-1", data)
+	}
+	got, err := w.DecodeProgress(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeProgress error = %v", err)
+	}
+	if want := (Progress{Token: StringID("task-1"), Progress: 3, Total: 10, Message: "indexing"}); *got != want {
+		t.Errorf("DecodeProgress = %+v, want %+v", *got, want)
+	}
+
+	// Events from other agents carry their message as a status message.
+	data, _ = w.EncodeResult(ctx, "rpc-1", &A2ATaskStatusUpdateEvent{
+		TaskID: "task-2",
+		Status: A2ATaskStatus{State: A2ATaskStateWorking, Message: &A2AMessage{
+			Role:  A2ARoleAgent,
+			Parts: []A2APart{{Kind: A2APartText, Text: "half way"}},
+		}},
+	})
+	if got, err := w.DecodeProgress(ctx, data); err != nil || got.Token != StringID("task-2") || got.Message != "half way" {
+		t.Errorf("DecodeProgress(status message) = %+v, %v", got, err)
+	}
+
+	data, err = w.EncodeCancellation(ctx, &Cancellation{RequestID: StringID("task-1"), Reason: "user aborted"})
+	if err != nil {
+		t.Fatalf("EncodeCancellation error = %v", err)
+	}
+	call, err := w.DecodeCall(ctx, data)
+	if err != nil || call.Method != A2AMethodTasksCancel {
+		t.Fatalf("EncodeCancellation = %s, want a tasks/cancel request", data)
+	}
+	c, err := w.DecodeCancellation(ctx, data)
+	if err != nil || c.RequestID != StringID("task-1") || c.Reason != "user aborted" {
+		t.Errorf("DecodeCancellation = %+v, %v", c, err)
+	}
+
+	if _, err := w.EncodeProgress(ctx, &Progress{Progress: 1}); !errors.Is(err, ErrEncodeFailure) {
+		t.Errorf("EncodeProgress without token error = %v, want ErrEncodeFailure", err)
+	}
+	if _, err := w.EncodeCancellation(ctx, &Cancellation{}); !errors.Is(err, ErrEncodeFailure) {
+		t.Errorf("EncodeCancellation without id error = %v, want ErrEncodeFailure", err)
+	}
+	task, _ := w.EncodeResult(ctx, "1", &A2ATask{ID: "task-1"})
+	if _, err := w.DecodeProgress(ctx, task); !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("DecodeProgress(task) error = %v, want ErrDecodeFailure", err)
+	}
+	get, _ := w.EncodeTasksGet(ctx, "1", &A2ATaskQueryParams{ID: "task-1"})
+	if _, err := w.DecodeCancellation(ctx, get); !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("DecodeCancellation(tasks/get) error = %v, want ErrDecodeFailure", err)
+	}
+	noID, _ := w.EncodeTasksCancel(ctx, "1", &A2ATaskIDParams{})
+	if _, err := w.DecodeCancellation(ctx, noID); !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("DecodeCancellation without task id error = %v, want ErrDecodeFailure", err)
+	}
+}
+
+func TestA2AWire_DecodeResult_Errors(t *testing.T) {
+	w := NewA2A()
+	ctx := context.Background()
+
+	_, _, err := w.DecodeResult(ctx, []byte(`{"jsonrpc":"2.0","id":"1","error":{"code":-32001,"message":"task not found"}}`))
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32001 {
+		t.Errorf("DecodeResult error = %v, want *Error with code -32001", err)
+	}
+
+	_, _, err = w.DecodeResult(ctx, []byte(`{"jsonrpc":"2.0","id":"1","result":{"kind":"bogus"}}`))
+	if !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("DecodeResult(unknown kind) error = %v, want ErrDecodeFailure", err)
+	}
+
+	// A2A 0.2 tasks carry no kind.
+	_, result, err := w.DecodeResult(ctx, []byte(`{"jsonrpc":"2.0","id":"1","result":{"id":"t","status":{"state":"working"}}}`))
+	if err != nil {
+		t.Fatalf("DecodeResult(legacy) error = %v", err)
+	}
+	if task, ok := result.(*A2ATask); !ok || task.Status.State != A2ATaskStateWorking {
+		t.Errorf("result = %+v, want working task", result)
+	}
+}
+
+func TestA2AWire_Request_MessageMapping(t *testing.T) {
+	w := NewA2A()
+	ctx := context.Background()
+
+	req := &Request{
+		ID:        "1",
+		Method:    "tools/call",
+		ToolID:    "search",
+		Arguments: map[string]any{"query": "golang"},
+		Meta:      map[string]any{"contextId": "ctx-1", "trace": "abc"},
+	}
+	data, err := w.EncodeRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("EncodeRequest error = %v", err)
+	}
+	call, err := w.DecodeCall(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeCall error = %v", err)
+	}
+	if call.Method != A2AMethodMessageSend {
+		t.Errorf("Method = %q, want %q", call.Method, A2AMethodMessageSend)
+	}
+	p, err := call.MessageSendParams()
+	if err != nil {
+		t.Fatalf("MessageSendParams error = %v", err)
+	}
+	if p.Message.ContextID != "ctx-1" || p.Message.Metadata["skillId"] != "search" {
+		t.Errorf("message = %+v, want contextId and skillId", p.Message)
+	}
+	if p.Metadata["trace"] != "abc" {
+		t.Errorf("metadata = %v, want trace", p.Metadata)
+	}
+
+	got, err := w.DecodeRequest(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeRequest error = %v", err)
+	}
+	if got.ToolID != "search" || got.Arguments["query"] != "golang" {
+		t.Errorf("Request = %+v, want search with query", got)
+	}
+	if got.Meta["contextId"] != "ctx-1" || got.Meta["trace"] != "abc" {
+		t.Errorf("Meta = %v, want contextId and trace", got.Meta)
+	}
+}
+
+func TestA2AWire_DecodeRequest_TextOnlyMessage(t *testing.T) {
+	data := []byte(`{"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"kind":"message","messageId":"m","role":"user","parts":[{"kind":"text","text":"hello"},{"kind":"text","text":"world"}]}}}`)
+	req, err := NewA2A().DecodeRequest(context.Background(), data)
+	if err != nil {
+		t.Fatalf("DecodeRequest error = %v", err)
+	}
+	if req.Arguments["text"] != "hello\nworld" {
+		t.Errorf("Arguments = %v, want joined text", req.Arguments)
+	}
+}
+
+func TestA2AWire_LegacyPartType(t *testing.T) {
+	ctx := context.Background()
+	w := NewA2A()
+
+	req, err := w.DecodeRequest(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tasks/send","params":{"id":"t-1","message":{"role":"user","parts":[{"type":"text","text":"tell me a joke"}]}}}`))
+	if err != nil {
+		t.Fatalf("DecodeRequest error = %v", err)
+	}
+	if req.ID != "t-1" || req.Arguments["text"] != "tell me a joke" {
+		t.Errorf("DecodeRequest = %+v, want task t-1 with text", req)
+	}
+
+	resp, err := w.DecodeResponse(ctx, []byte(`{"jsonrpc":"2.0","id":1,"result":{"id":"t-1","status":{"state":"completed"},"artifacts":[{"parts":[{"type":"text","text":"joke"}]}]}}`))
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if len(resp.Content) != 1 || resp.Content[0].Text != "joke" {
+		t.Errorf("Content = %+v, want the artifact text", resp.Content)
+	}
+}
+
+func TestA2AWire_Response_TaskEncoding(t *testing.T) {
+	w := NewA2A()
+	ctx := context.Background()
+
+	resp := &Response{
+		ID: "1",
+		Content: []Content{
+			{Type: ContentTypeText, Text: "first"},
+			{Type: ContentTypeResource, URI: "file:///a.txt", MIMEType: "text/plain"},
+			{Type: ContentTypeText, Text: "second"},
+		},
+		StructuredContent: map[string]any{"count": float64(2)},
+		Meta:              map[string]any{"taskId": "task-1", "contextId": "ctx-1", "state": "working"},
+	}
+	data, err := w.EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	_, result, err := w.DecodeResult(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeResult error = %v", err)
+	}
+	task, ok := result.(*A2ATask)
+	if !ok {
+		t.Fatalf("result = %T, want *A2ATask", result)
+	}
+	if task.ID != "task-1" || task.ContextID != "ctx-1" || task.Status.State != A2ATaskStateWorking {
+		t.Errorf("task = %s/%s/%s, want task-1/ctx-1/working", task.ID, task.ContextID, task.Status.State)
+	}
+
+	got, err := w.DecodeResponse(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Content, resp.Content) {
+		t.Errorf("Content = %+v, want %+v", got.Content, resp.Content)
+	}
+	if !reflect.DeepEqual(got.StructuredContent, resp.StructuredContent) {
+		t.Errorf("StructuredContent = %v, want %v", got.StructuredContent, resp.StructuredContent)
+	}
+	if got.Meta["taskId"] != "task-1" || got.Meta["contextId"] != "ctx-1" {
+		t.Errorf("Meta = %v, want taskId and contextId", got.Meta)
+	}
+}
+
+func TestA2ATaskState_IsTerminal(t *testing.T) {
+	terminal := map[A2ATaskState]bool{
+		A2ATaskStateSubmitted:     false,
+		A2ATaskStateWorking:       false,
+		A2ATaskStateInputRequired: false,
+		A2ATaskStateAuthRequired:  false,
+		A2ATaskStateCompleted:     true,
+		A2ATaskStateCanceled:      true,
+		A2ATaskStateFailed:        true,
+		A2ATaskStateRejected:      true,
+	}
+	for state, want := range terminal {
+		if got := state.IsTerminal(); got != want {
+			t.Errorf("%s.IsTerminal() = %v, want %v", state, got, want)
+		}
+	}
+}
+
+func TestA2APartsContent_AudioAndFiles(t *testing.T) {
+	content := []Content{
+		{Type: ContentTypeAudio, Data: []byte("RIFF"), MIMEType: "audio/wav"},
+		{Type: ContentTypeFile, Name: "report.pdf", Data: []byte("%PDF"), MIMEType: "application/pdf"},
+		{Type: ContentTypeFile, Name: "big.iso", URI: "https://example.com/big.iso"},
+	}
+	parts := A2APartsFromContent(content, nil)
+	if len(parts) != 3 || parts[1].File.Name != "report.pdf" || parts[2].File.URI != "https://example.com/big.iso" {
+		t.Fatalf("parts = %+v", parts)
+	}
+	got, _ := A2APartsToContent(parts)
+	if !reflect.DeepEqual(got, content) {
+		t.Errorf("content = %+v, want %+v", got, content)
+	}
+}
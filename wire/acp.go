@@ -1,15 +1,30 @@
 package wire
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jonwraymond/toolprotocol/prompt"
+	"github.com/jonwraymond/toolprotocol/resource"
+	"github.com/jonwraymond/toolprotocol/task"
 )
 
 // ACPVersion is the ACP protocol version.
 const ACPVersion = "1.0.0"
 
-// ACPWire implements Wire for IBM's Agent Communication Protocol.
+// ACPWire implements Wire for the Agent Client Protocol used between code
+// editors and coding agents.
+//
+// The generic Wire methods map Agent Client Protocol methods directly and
+// fall back to an agentId/input invocation for other methods. Typed
+// session methods are available through [ACPWire.EncodeCall],
+// [ACPWire.DecodeCall] and the Encode* helpers.
 type ACPWire struct{}
 
 // NewACP creates a new ACP wire format handler.
@@ -37,24 +52,48 @@ type acpRequest struct {
 
 // acpResponse is the ACP JSON-RPC response format.
 type acpResponse struct {
-	JSONRPC string         `json:"jsonrpc"`
-	ID      any            `json:"id"`
-	Result  map[string]any `json:"result,omitempty"`
-	Error   *jsonrpcError  `json:"error,omitempty"`
+	JSONRPC string        `json:"jsonrpc"`
+	ID      any           `json:"id"`
+	Result  *acpResult    `json:"result,omitempty"`
+	Error   *jsonrpcError `json:"error,omitempty"`
+}
+
+// acpResult carries ordered output blocks. StopReason lets the same
+// result answer a session/prompt request.
+type acpResult struct {
+	Status     string          `json:"status,omitempty"`
+	StopReason string          `json:"stopReason,omitempty"`
+	Output     json.RawMessage `json:"output,omitempty"`
+	Metadata   map[string]any  `json:"metadata,omitempty"`
 }
 
 // EncodeRequest encodes a Request to ACP format.
+//
+// Agent Client Protocol methods (initialize, session/*, fs/*, terminal/*)
+// send Arguments as params with Meta under "_meta". Other methods use the
+// generic agentId/input invocation.
 func (w *ACPWire) EncodeRequest(ctx context.Context, req *Request) ([]byte, error) {
 	rpc := acpRequest{
 		JSONRPC: "2.0",
 		ID:      req.ID,
 		Method:  req.Method,
-		Params: map[string]any{
-			"agentId": req.ToolID,
-			"input":   req.Arguments,
-		},
 	}
 
+	if isACPMethod(req.Method) {
+		rpc.Params = maps.Clone(req.Arguments)
+		if rpc.Params == nil {
+			rpc.Params = make(map[string]any)
+		}
+		if len(req.Meta) > 0 {
+			rpc.Params["_meta"] = req.Meta
+		}
+		return json.Marshal(rpc)
+	}
+
+	rpc.Params = map[string]any{
+		"agentId": req.ToolID,
+		"input":   req.Arguments,
+	}
 	if len(req.Meta) > 0 {
 		rpc.Params["metadata"] = req.Meta
 	}
@@ -64,39 +103,73 @@ func (w *ACPWire) EncodeRequest(ctx context.Context, req *Request) ([]byte, erro
 
 // DecodeRequest decodes ACP format to a Request.
 func (w *ACPWire) DecodeRequest(ctx context.Context, data []byte) (*Request, error) {
-	var rpc acpRequest
+	return decodeRequest(ctx, w, data)
+}
+
+// DecodeRawRequest decodes ACP format to a RawRequest. Agent Client
+// Protocol methods yield their params, less "_meta", as arguments.
+func (w *ACPWire) DecodeRawRequest(ctx context.Context, data []byte) (*RawRequest, error) {
+	var rpc struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
 	if err := json.Unmarshal(data, &rpc); err != nil {
 		return nil, fmt.Errorf("decode acp request: %w", err)
 	}
 
-	req := &Request{
+	req := &RawRequest{
+		ID:     rawID(rpc.ID),
 		Method: rpc.Method,
 	}
-
-	// Handle ID
-	switch id := rpc.ID.(type) {
-	case string:
-		req.ID = id
-	case float64:
-		req.ID = fmt.Sprintf("%.0f", id)
+	if isJSONNull(rpc.Params) {
+		return req, nil
+	}
+	if !isJSONObject(rpc.Params) {
+		return nil, fmt.Errorf("decode acp request: %w: params is not an object", ErrDecodeFailure)
 	}
 
-	if rpc.Params != nil {
-		if agentID, ok := rpc.Params["agentId"].(string); ok {
-			req.ToolID = agentID
+	if isACPMethod(rpc.Method) {
+		var params map[string]json.RawMessage
+		if err := json.Unmarshal(rpc.Params, &params); err != nil {
+			return nil, fmt.Errorf("decode acp request: %w", err)
 		}
-		if input, ok := rpc.Params["input"].(map[string]any); ok {
-			req.Arguments = input
-		}
-		if meta, ok := rpc.Params["metadata"].(map[string]any); ok {
-			req.Meta = meta
+		meta, hasMeta := params["_meta"]
+		req.Meta = objectOrNil(meta)
+		switch {
+		case len(params) == 0 || len(params) == 1 && hasMeta:
+		case !hasMeta:
+			req.Arguments = rpc.Params
+		default:
+			delete(params, "_meta")
+			args, err := json.Marshal(params)
+			if err != nil {
+				return nil, fmt.Errorf("decode acp request: %w", err)
+			}
+			req.Arguments = args
 		}
+		return req, nil
 	}
 
+	var params struct {
+		AgentID  looseString     `json:"agentId"`
+		Input    json.RawMessage `json:"input"`
+		Metadata json.RawMessage `json:"metadata"`
+	}
+	if err := json.Unmarshal(rpc.Params, &params); err != nil {
+		return nil, fmt.Errorf("decode acp request: %w", err)
+	}
+	req.ToolID = string(params.AgentID)
+	req.Arguments = objectOrNil(params.Input)
+	req.Meta = objectOrNil(params.Metadata)
+
 	return req, nil
 }
 
 // EncodeResponse encodes a Response to ACP format.
+//
+// Content is encoded in order as ACP content blocks under "output". A
+// "stopReason" in Meta is promoted to the result.
 func (w *ACPWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, error) {
 	rpc := acpResponse{
 		JSONRPC: "2.0",
@@ -109,106 +182,122 @@ func (w *ACPWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, e
 			Message: resp.Error.Message,
 			Data:    resp.Error.Data,
 		}
-	} else {
-		output := make(map[string]any)
-		for i, c := range resp.Content {
-			key := fmt.Sprintf("content_%d", i)
-			switch c.Type {
-			case ContentTypeText:
-				output[key] = map[string]any{
-					"type": "text",
-					"text": c.Text,
-				}
-			case ContentTypeImage:
-				output[key] = map[string]any{
-					"type":     "binary",
-					"data":     c.Data,
-					"mimeType": c.MIMEType,
-				}
-			case ContentTypeResource:
-				output[key] = map[string]any{
-					"type": "resource",
-					"uri":  c.URI,
-				}
-			}
-		}
+		return json.Marshal(rpc)
+	}
 
-		rpc.Result = map[string]any{
-			"status": "success",
-			"output": output,
-		}
-		if len(resp.Meta) > 0 {
-			rpc.Result["metadata"] = resp.Meta
-		}
+	output, err := json.Marshal(ACPBlocksFromContent(resp.Content))
+	if err != nil {
+		return nil, fmt.Errorf("encode acp response: %w", err)
+	}
+	result := &acpResult{
+		Status: "success",
+		Output: output,
+	}
+	meta := resp.Meta
+	if reason, ok := meta["stopReason"].(string); ok {
+		result.StopReason = reason
+		meta = maps.Clone(meta)
+		delete(meta, "stopReason")
+	}
+	if len(meta) > 0 {
+		result.Metadata = meta
 	}
+	rpc.Result = result
 
 	return json.Marshal(rpc)
 }
 
 // DecodeResponse decodes ACP format to a Response.
+//
+// Output may be an ordered array of content blocks or the legacy map of
+// "content_N" keys, which is decoded in index order.
 func (w *ACPWire) DecodeResponse(ctx context.Context, data []byte) (*Response, error) {
 	var rpc acpResponse
 	if err := json.Unmarshal(data, &rpc); err != nil {
 		return nil, fmt.Errorf("decode acp response: %w", err)
 	}
 
-	resp := &Response{}
-
-	// Handle ID
-	switch id := rpc.ID.(type) {
-	case string:
-		resp.ID = id
-	case float64:
-		resp.ID = fmt.Sprintf("%.0f", id)
-	}
+	resp := &Response{ID: rpcID(rpc.ID)}
 
 	if rpc.Error != nil {
 		resp.IsError = true
-		resp.Error = &Error{
-			Code:    rpc.Error.Code,
-			Message: rpc.Error.Message,
-			Data:    rpc.Error.Data,
+		resp.Error = decodedError("acp", rpc.Error.Code, rpc.Error.Message, rpc.Error.Data)
+		return resp, nil
+	}
+	if rpc.Result == nil {
+		return resp, nil
+	}
+
+	output := bytes.TrimSpace(rpc.Result.Output)
+	switch {
+	case len(output) == 0:
+	case output[0] == '[':
+		var blocks []ACPContentBlock
+		if err := json.Unmarshal(output, &blocks); err != nil {
+			return nil, fmt.Errorf("decode acp response: %w", err)
 		}
-	} else if rpc.Result != nil {
-		// Extract content from output
-		if output, ok := rpc.Result["output"].(map[string]any); ok {
-			for _, v := range output {
-				if item, ok := v.(map[string]any); ok {
-					c := Content{}
-					if t, ok := item["type"].(string); ok {
-						switch t {
-						case "text":
-							c.Type = ContentTypeText
-							if text, ok := item["text"].(string); ok {
-								c.Text = text
-							}
-						case "binary":
-							c.Type = ContentTypeImage
-							if mime, ok := item["mimeType"].(string); ok {
-								c.MIMEType = mime
-							}
-						case "resource":
-							c.Type = ContentTypeResource
-							if uri, ok := item["uri"].(string); ok {
-								c.URI = uri
-							}
-						}
-					}
-					resp.Content = append(resp.Content, c)
-				}
-			}
+		resp.Content = ACPBlocksToContent(blocks)
+	default:
+		var legacy map[string]any
+		if err := json.Unmarshal(output, &legacy); err != nil {
+			return nil, fmt.Errorf("decode acp response: %w", err)
 		}
-		if meta, ok := rpc.Result["metadata"].(map[string]any); ok {
-			resp.Meta = meta
+		resp.Content = decodeLegacyACPOutput(legacy)
+	}
+
+	resp.Meta = rpc.Result.Metadata
+	if rpc.Result.StopReason != "" {
+		if resp.Meta == nil {
+			resp.Meta = make(map[string]any)
 		}
+		resp.Meta["stopReason"] = rpc.Result.StopReason
 	}
 
 	return resp, nil
 }
 
+// decodeLegacyACPOutput decodes an output map of "content_N" items in
+// index order. Entries that are not content items are ignored.
+func decodeLegacyACPOutput(output map[string]any) []Content {
+	type indexed struct {
+		index int
+		item  map[string]any
+	}
+	var items []indexed
+	for k, v := range output {
+		n, err := strconv.Atoi(strings.TrimPrefix(k, "content_"))
+		item, ok := v.(map[string]any)
+		if err != nil || !ok || !strings.HasPrefix(k, "content_") {
+			continue
+		}
+		items = append(items, indexed{n, item})
+	}
+	slices.SortFunc(items, func(a, b indexed) int { return a.index - b.index })
+
+	content := make([]Content, 0, len(items))
+	for _, it := range items {
+		c := Content{}
+		switch it.item["type"] {
+		case "text":
+			c.Type = ContentTypeText
+			c.Text, _ = it.item["text"].(string)
+		case "binary":
+			c.Type = ContentTypeImage
+			c.MIMEType, _ = it.item["mimeType"].(string)
+			c.Data = decodeData(it.item["data"])
+		case "resource":
+			c.Type = ContentTypeResource
+			c.URI, _ = it.item["uri"].(string)
+		}
+		content = append(content, c)
+	}
+	return content
+}
+
 // acpAgentList is the ACP agents list format.
 type acpAgentList struct {
-	Agents []acpAgent `json:"agents"`
+	Agents     []acpAgent `json:"agents"`
+	NextCursor string     `json:"nextCursor,omitempty"`
 }
 
 type acpAgent struct {
@@ -220,11 +309,26 @@ type acpAgent struct {
 
 // EncodeToolList encodes a tool list to ACP format.
 func (w *ACPWire) EncodeToolList(ctx context.Context, tools []Tool) ([]byte, error) {
+	return w.EncodeToolPage(ctx, &Page[Tool]{Items: tools})
+}
+
+// DecodeToolList decodes ACP format to a tool list.
+func (w *ACPWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+	page, err := w.DecodeToolPage(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// EncodeToolPage encodes one page of an agent list.
+func (w *ACPWire) EncodeToolPage(ctx context.Context, page *Page[Tool]) ([]byte, error) {
 	list := acpAgentList{
-		Agents: make([]acpAgent, 0, len(tools)),
+		Agents:     make([]acpAgent, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
 	}
 
-	for _, t := range tools {
+	for _, t := range page.Items {
 		list.Agents = append(list.Agents, acpAgent{
 			ID:          t.Name,
 			Name:        t.Name,
@@ -236,8 +340,8 @@ func (w *ACPWire) EncodeToolList(ctx context.Context, tools []Tool) ([]byte, err
 	return json.Marshal(list)
 }
 
-// DecodeToolList decodes ACP format to a tool list.
-func (w *ACPWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+// DecodeToolPage decodes one page of an agent list.
+func (w *ACPWire) DecodeToolPage(ctx context.Context, data []byte) (*Page[Tool], error) {
 	var list acpAgentList
 	if err := json.Unmarshal(data, &list); err != nil {
 		return nil, fmt.Errorf("decode acp tool list: %w", err)
@@ -256,7 +360,40 @@ func (w *ACPWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, erro
 		})
 	}
 
-	return tools, nil
+	return &Page[Tool]{Items: tools, NextCursor: list.NextCursor}, nil
+}
+
+// EncodeResourcePage returns ErrUnsupportedFeature: ACP clients pass
+// resources to agents inside prompts, and agents list none.
+func (w *ACPWire) EncodeResourcePage(ctx context.Context, page *Page[resource.Resource]) ([]byte, error) {
+	return nil, fmt.Errorf("%w: acp has no resource list", ErrUnsupportedFeature)
+}
+
+// DecodeResourcePage returns ErrUnsupportedFeature, as EncodeResourcePage does.
+func (w *ACPWire) DecodeResourcePage(ctx context.Context, data []byte) (*Page[resource.Resource], error) {
+	return nil, fmt.Errorf("%w: acp has no resource list", ErrUnsupportedFeature)
+}
+
+// EncodePromptPage returns ErrUnsupportedFeature: ACP agents advertise
+// slash commands in session/update notifications, not a prompt list.
+func (w *ACPWire) EncodePromptPage(ctx context.Context, page *Page[prompt.Prompt]) ([]byte, error) {
+	return nil, fmt.Errorf("%w: acp has no prompt list", ErrUnsupportedFeature)
+}
+
+// DecodePromptPage returns ErrUnsupportedFeature, as EncodePromptPage does.
+func (w *ACPWire) DecodePromptPage(ctx context.Context, data []byte) (*Page[prompt.Prompt], error) {
+	return nil, fmt.Errorf("%w: acp has no prompt list", ErrUnsupportedFeature)
+}
+
+// EncodeTaskPage returns ErrUnsupportedFeature: ACP work runs as prompt
+// turns within sessions, which have no task list.
+func (w *ACPWire) EncodeTaskPage(ctx context.Context, page *Page[*task.Task]) ([]byte, error) {
+	return nil, fmt.Errorf("%w: acp has no task list", ErrUnsupportedFeature)
+}
+
+// DecodeTaskPage returns ErrUnsupportedFeature, as EncodeTaskPage does.
+func (w *ACPWire) DecodeTaskPage(ctx context.Context, data []byte) (*Page[*task.Task], error) {
+	return nil, fmt.Errorf("%w: acp has no task list", ErrUnsupportedFeature)
 }
 
 // Capabilities returns ACP protocol capabilities.
@@ -0,0 +1,658 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ACPProtocolVersion is the Agent Client Protocol version negotiated in
+// initialize.
+const ACPProtocolVersion = 1
+
+// Agent Client Protocol method names.
+const (
+	ACPMethodInitialize        = "initialize"
+	ACPMethodSessionNew        = "session/new"
+	ACPMethodSessionLoad       = "session/load"
+	ACPMethodSessionPrompt     = "session/prompt"
+	ACPMethodSessionCancel     = "session/cancel"
+	ACPMethodSessionUpdate     = "session/update"
+	ACPMethodRequestPermission = "session/request_permission"
+)
+
+// ACP content block types.
+const (
+	ACPContentText         = "text"
+	ACPContentImage        = "image"
+	ACPContentAudio        = "audio"
+	ACPContentResourceLink = "resource_link"
+	ACPContentResource     = "resource"
+)
+
+// ACP session update kinds.
+const (
+	ACPUpdateUserMessageChunk  = "user_message_chunk"
+	ACPUpdateAgentMessageChunk = "agent_message_chunk"
+	ACPUpdateAgentThoughtChunk = "agent_thought_chunk"
+	ACPUpdateToolCall          = "tool_call"
+	ACPUpdateToolCallUpdate    = "tool_call_update"
+	ACPUpdatePlan              = "plan"
+)
+
+// ACP prompt turn stop reasons.
+const (
+	ACPStopEndTurn         = "end_turn"
+	ACPStopMaxTokens       = "max_tokens"
+	ACPStopMaxTurnRequests = "max_turn_requests"
+	ACPStopRefusal         = "refusal"
+	ACPStopCancelled       = "cancelled"
+)
+
+// ACP tool call and plan entry statuses.
+const (
+	ACPStatusPending    = "pending"
+	ACPStatusInProgress = "in_progress"
+	ACPStatusCompleted  = "completed"
+	ACPStatusFailed     = "failed"
+)
+
+// ACP permission option kinds.
+const (
+	ACPPermissionAllowOnce    = "allow_once"
+	ACPPermissionAllowAlways  = "allow_always"
+	ACPPermissionRejectOnce   = "reject_once"
+	ACPPermissionRejectAlways = "reject_always"
+)
+
+// ACPContentBlock is a unit of prompt or message content, discriminated by Type.
+type ACPContentBlock struct {
+	Type        string               `json:"type"`
+	Text        string               `json:"text"`
+	Data        []byte               `json:"data,omitempty"`
+	MIMEType    string               `json:"mimeType,omitempty"`
+	URI         string               `json:"uri,omitempty"`
+	Name        string               `json:"name,omitempty"`
+	Title       string               `json:"title,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Size        *int64               `json:"size,omitempty"`
+	Resource    *ACPEmbeddedResource `json:"resource,omitempty"`
+	Annotations map[string]any       `json:"annotations,omitempty"`
+}
+
+// acpBlockJSON is the encoding of blocks other than text, which omit
+// an empty "text".
+type acpBlockJSON struct {
+	Type        string               `json:"type"`
+	Text        string               `json:"text,omitempty"`
+	Data        []byte               `json:"data,omitempty"`
+	MIMEType    string               `json:"mimeType,omitempty"`
+	URI         string               `json:"uri,omitempty"`
+	Name        string               `json:"name,omitempty"`
+	Title       string               `json:"title,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Size        *int64               `json:"size,omitempty"`
+	Resource    *ACPEmbeddedResource `json:"resource,omitempty"`
+	Annotations map[string]any       `json:"annotations,omitempty"`
+}
+
+// MarshalJSON encodes the block. Text blocks keep "text" even when it
+// is empty; other blocks omit it.
+func (b ACPContentBlock) MarshalJSON() ([]byte, error) {
+	if b.Type == ACPContentText {
+		type block ACPContentBlock
+		return json.Marshal(block(b))
+	}
+	return json.Marshal(acpBlockJSON(b))
+}
+
+// ACPEmbeddedResource is the payload of a resource content block. Exactly
+// one of Text or Blob is set.
+type ACPEmbeddedResource struct {
+	URI      string `json:"uri"`
+	MIMEType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     []byte `json:"blob,omitempty"`
+}
+
+// ACPFileSystemCapability declares the client's file system methods.
+type ACPFileSystemCapability struct {
+	ReadTextFile  bool `json:"readTextFile"`
+	WriteTextFile bool `json:"writeTextFile"`
+}
+
+// ACPClientCapabilities declares optional client features.
+type ACPClientCapabilities struct {
+	FS       ACPFileSystemCapability `json:"fs"`
+	Terminal bool                    `json:"terminal,omitempty"`
+}
+
+// ACPPromptCapabilities declares which content the agent accepts in prompts.
+type ACPPromptCapabilities struct {
+	Image           bool `json:"image,omitempty"`
+	Audio           bool `json:"audio,omitempty"`
+	EmbeddedContext bool `json:"embeddedContext,omitempty"`
+}
+
+// ACPAgentCapabilities declares optional agent features.
+type ACPAgentCapabilities struct {
+	LoadSession        bool                  `json:"loadSession,omitempty"`
+	PromptCapabilities ACPPromptCapabilities `json:"promptCapabilities"`
+}
+
+// ACPAuthMethod describes an authentication method offered by the agent.
+type ACPAuthMethod struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ACPInitializeParams are the params of initialize.
+type ACPInitializeParams struct {
+	ProtocolVersion    int                   `json:"protocolVersion"`
+	ClientCapabilities ACPClientCapabilities `json:"clientCapabilities"`
+}
+
+// ACPInitializeResult is the result of initialize.
+type ACPInitializeResult struct {
+	ProtocolVersion   int                  `json:"protocolVersion"`
+	AgentCapabilities ACPAgentCapabilities `json:"agentCapabilities"`
+	AuthMethods       []ACPAuthMethod      `json:"authMethods"`
+}
+
+// ACPEnvVariable is an environment variable passed to an MCP server.
+type ACPEnvVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ACPMCPServer is an MCP server the agent should connect to over stdio.
+type ACPMCPServer struct {
+	Name    string           `json:"name"`
+	Command string           `json:"command"`
+	Args    []string         `json:"args"`
+	Env     []ACPEnvVariable `json:"env"`
+}
+
+// ACPNewSessionParams are the params of session/new.
+type ACPNewSessionParams struct {
+	CWD        string         `json:"cwd"`
+	MCPServers []ACPMCPServer `json:"mcpServers"`
+}
+
+// ACPNewSessionResult is the result of session/new.
+type ACPNewSessionResult struct {
+	SessionID string `json:"sessionId"`
+}
+
+// ACPLoadSessionParams are the params of session/load. The agent replays
+// the conversation as session/update notifications before responding.
+type ACPLoadSessionParams struct {
+	SessionID  string         `json:"sessionId"`
+	CWD        string         `json:"cwd"`
+	MCPServers []ACPMCPServer `json:"mcpServers"`
+}
+
+// ACPPromptParams are the params of session/prompt.
+type ACPPromptParams struct {
+	SessionID string            `json:"sessionId"`
+	Prompt    []ACPContentBlock `json:"prompt"`
+}
+
+// ACPPromptResult is the result of session/prompt.
+type ACPPromptResult struct {
+	StopReason string `json:"stopReason"`
+}
+
+// ACPCancelParams are the params of the session/cancel notification.
+type ACPCancelParams struct {
+	SessionID string `json:"sessionId"`
+}
+
+// ACPSessionUpdate is the payload of a session/update notification:
+// *ACPMessageChunk, *ACPToolCall, *ACPToolCallUpdate, or *ACPPlan.
+type ACPSessionUpdate interface {
+	acpUpdateKind() string
+}
+
+// ACPMessageChunk streams a piece of a user message, agent message or
+// agent thought. SessionUpdate selects which; it defaults to
+// agent_message_chunk.
+type ACPMessageChunk struct {
+	SessionUpdate string          `json:"sessionUpdate"`
+	Content       ACPContentBlock `json:"content"`
+}
+
+// ACPToolCallContent is content produced by a tool call: a content block,
+// a file diff, or an embedded terminal, discriminated by Type.
+type ACPToolCallContent struct {
+	Type       string           `json:"type"`
+	Content    *ACPContentBlock `json:"content,omitempty"`
+	Path       string           `json:"path,omitempty"`
+	OldText    *string          `json:"oldText,omitempty"`
+	NewText    string           `json:"newText,omitempty"`
+	TerminalID string           `json:"terminalId,omitempty"`
+}
+
+// ACPToolCallLocation is a file location affected by a tool call.
+type ACPToolCallLocation struct {
+	Path string `json:"path"`
+	Line *int   `json:"line,omitempty"`
+}
+
+// ACPToolCall reports a new tool call.
+type ACPToolCall struct {
+	SessionUpdate string                `json:"sessionUpdate,omitempty"`
+	ToolCallID    string                `json:"toolCallId"`
+	Title         string                `json:"title"`
+	Kind          string                `json:"kind,omitempty"`
+	Status        string                `json:"status,omitempty"`
+	Content       []ACPToolCallContent  `json:"content,omitempty"`
+	Locations     []ACPToolCallLocation `json:"locations,omitempty"`
+	RawInput      any                   `json:"rawInput,omitempty"`
+	RawOutput     any                   `json:"rawOutput,omitempty"`
+}
+
+// ACPToolCallUpdate changes fields of an existing tool call. Empty fields
+// are left unchanged.
+type ACPToolCallUpdate struct {
+	SessionUpdate string                `json:"sessionUpdate,omitempty"`
+	ToolCallID    string                `json:"toolCallId"`
+	Title         string                `json:"title,omitempty"`
+	Kind          string                `json:"kind,omitempty"`
+	Status        string                `json:"status,omitempty"`
+	Content       []ACPToolCallContent  `json:"content,omitempty"`
+	Locations     []ACPToolCallLocation `json:"locations,omitempty"`
+	RawInput      any                   `json:"rawInput,omitempty"`
+	RawOutput     any                   `json:"rawOutput,omitempty"`
+}
+
+// ACPPlanEntry is one step of an agent plan.
+type ACPPlanEntry struct {
+	Content  string `json:"content"`
+	Priority string `json:"priority"`
+	Status   string `json:"status"`
+}
+
+// ACPPlan replaces the agent's current execution plan.
+type ACPPlan struct {
+	SessionUpdate string         `json:"sessionUpdate"`
+	Entries       []ACPPlanEntry `json:"entries"`
+}
+
+func (u *ACPMessageChunk) acpUpdateKind() string {
+	if u.SessionUpdate == "" {
+		return ACPUpdateAgentMessageChunk
+	}
+	return u.SessionUpdate
+}
+func (*ACPToolCall) acpUpdateKind() string       { return ACPUpdateToolCall }
+func (*ACPToolCallUpdate) acpUpdateKind() string { return ACPUpdateToolCallUpdate }
+func (*ACPPlan) acpUpdateKind() string           { return ACPUpdatePlan }
+
+// ACPSessionNotification is the params of session/update.
+type ACPSessionNotification struct {
+	SessionID string
+	Update    ACPSessionUpdate
+}
+
+// MarshalJSON encodes the notification, filling in the update's
+// sessionUpdate discriminator.
+func (n ACPSessionNotification) MarshalJSON() ([]byte, error) {
+	var update any
+	switch u := n.Update.(type) {
+	case *ACPMessageChunk:
+		cp := *u
+		cp.SessionUpdate = u.acpUpdateKind()
+		update = &cp
+	case *ACPToolCall:
+		cp := *u
+		cp.SessionUpdate = u.acpUpdateKind()
+		update = &cp
+	case *ACPToolCallUpdate:
+		cp := *u
+		cp.SessionUpdate = u.acpUpdateKind()
+		update = &cp
+	case *ACPPlan:
+		cp := *u
+		cp.SessionUpdate = u.acpUpdateKind()
+		update = &cp
+	default:
+		return nil, fmt.Errorf("%w: unsupported session update %T", ErrEncodeFailure, n.Update)
+	}
+	return json.Marshal(struct {
+		SessionID string `json:"sessionId"`
+		Update    any    `json:"update"`
+	}{n.SessionID, update})
+}
+
+// UnmarshalJSON decodes the notification, dispatching on sessionUpdate.
+func (n *ACPSessionNotification) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		SessionID string          `json:"sessionId"`
+		Update    json.RawMessage `json:"update"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var head struct {
+		SessionUpdate string `json:"sessionUpdate"`
+	}
+	if err := json.Unmarshal(raw.Update, &head); err != nil {
+		return err
+	}
+	var update ACPSessionUpdate
+	switch head.SessionUpdate {
+	case ACPUpdateUserMessageChunk, ACPUpdateAgentMessageChunk, ACPUpdateAgentThoughtChunk:
+		update = &ACPMessageChunk{}
+	case ACPUpdateToolCall:
+		update = &ACPToolCall{}
+	case ACPUpdateToolCallUpdate:
+		update = &ACPToolCallUpdate{}
+	case ACPUpdatePlan:
+		update = &ACPPlan{}
+	default:
+		return fmt.Errorf("%w: unknown session update %q", ErrDecodeFailure, head.SessionUpdate)
+	}
+	if err := json.Unmarshal(raw.Update, update); err != nil {
+		return err
+	}
+	n.SessionID = raw.SessionID
+	n.Update = update
+	return nil
+}
+
+// ACPPermissionOption is a choice offered in a permission request.
+type ACPPermissionOption struct {
+	OptionID string `json:"optionId"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+}
+
+// ACPRequestPermissionParams are the params of session/request_permission,
+// sent by the agent to ask the user to authorize a tool call.
+type ACPRequestPermissionParams struct {
+	SessionID string                `json:"sessionId"`
+	ToolCall  ACPToolCallUpdate     `json:"toolCall"`
+	Options   []ACPPermissionOption `json:"options"`
+}
+
+// ACPPermissionOutcome is the user's decision. Outcome is "selected" with
+// an OptionID, or "cancelled" when the prompt turn was cancelled.
+type ACPPermissionOutcome struct {
+	Outcome  string `json:"outcome"`
+	OptionID string `json:"optionId,omitempty"`
+}
+
+// ACPRequestPermissionResult is the result of session/request_permission.
+type ACPRequestPermissionResult struct {
+	Outcome ACPPermissionOutcome `json:"outcome"`
+}
+
+// ACPCall is a decoded Agent Client Protocol request or notification with
+// raw params.
+type ACPCall struct {
+	// ID is the JSON-RPC request identifier; empty for notifications.
+	ID string
+
+	// Method is the ACP method name.
+	Method string
+
+	// Params are the undecoded method params.
+	Params json.RawMessage
+}
+
+// DecodeParams decodes the call's params into v, typically one of the
+// ACP*Params types or *ACPSessionNotification.
+func (c *ACPCall) DecodeParams(v any) error {
+	if len(c.Params) == 0 {
+		return fmt.Errorf("decode %s params: %w: missing params", c.Method, ErrDecodeFailure)
+	}
+	if err := json.Unmarshal(c.Params, v); err != nil {
+		return fmt.Errorf("decode %s params: %w", c.Method, err)
+	}
+	return nil
+}
+
+// EncodeCall encodes an ACP JSON-RPC request with typed params.
+func (w *ACPWire) EncodeCall(ctx context.Context, id, method string, params any) ([]byte, error) {
+	return json.Marshal(struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      string `json:"id"`
+		Method  string `json:"method"`
+		Params  any    `json:"params,omitempty"`
+	}{"2.0", id, method, params})
+}
+
+// EncodeNotification encodes an ACP JSON-RPC notification, which carries no ID.
+func (w *ACPWire) EncodeNotification(ctx context.Context, method string, params any) ([]byte, error) {
+	return json.Marshal(struct {
+		JSONRPC string `json:"jsonrpc"`
+		Method  string `json:"method"`
+		Params  any    `json:"params,omitempty"`
+	}{"2.0", method, params})
+}
+
+// DecodeCall decodes an ACP request or notification, leaving params undecoded.
+func (w *ACPWire) DecodeCall(ctx context.Context, data []byte) (*ACPCall, error) {
+	var rpc struct {
+		ID     any             `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return nil, fmt.Errorf("decode acp call: %w", err)
+	}
+	if rpc.Method == "" {
+		return nil, fmt.Errorf("decode acp call: %w: missing method", ErrDecodeFailure)
+	}
+	return &ACPCall{ID: rpcID(rpc.ID), Method: rpc.Method, Params: rpc.Params}, nil
+}
+
+// EncodeInitialize encodes an initialize request.
+func (w *ACPWire) EncodeInitialize(ctx context.Context, id string, params *ACPInitializeParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, ACPMethodInitialize, params)
+}
+
+// EncodeNewSession encodes a session/new request.
+func (w *ACPWire) EncodeNewSession(ctx context.Context, id string, params *ACPNewSessionParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, ACPMethodSessionNew, params)
+}
+
+// EncodeLoadSession encodes a session/load request.
+func (w *ACPWire) EncodeLoadSession(ctx context.Context, id string, params *ACPLoadSessionParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, ACPMethodSessionLoad, params)
+}
+
+// EncodePrompt encodes a session/prompt request.
+func (w *ACPWire) EncodePrompt(ctx context.Context, id string, params *ACPPromptParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, ACPMethodSessionPrompt, params)
+}
+
+// EncodeCancel encodes a session/cancel notification.
+func (w *ACPWire) EncodeCancel(ctx context.Context, params *ACPCancelParams) ([]byte, error) {
+	return w.EncodeNotification(ctx, ACPMethodSessionCancel, params)
+}
+
+var _ NotificationCodec = (*ACPWire)(nil)
+
+// EncodeProgress returns ErrUnsupportedFeature: ACP reports progress as
+// session/update tool call updates and plans, which carry no amounts.
+func (w *ACPWire) EncodeProgress(ctx context.Context, p *Progress) ([]byte, error) {
+	return nil, fmt.Errorf("%w: acp has no progress notifications", ErrUnsupportedFeature)
+}
+
+// DecodeProgress returns ErrUnsupportedFeature, as EncodeProgress does.
+func (w *ACPWire) DecodeProgress(ctx context.Context, data []byte) (*Progress, error) {
+	return nil, fmt.Errorf("decode progress: %w: acp has no progress notifications", ErrUnsupportedFeature)
+}
+
+// EncodeCancellation encodes a session/cancel notification. ACP cancels
+// the prompt turn of a session, so the request ID names the session.
+// ACP carries no reason, so Reason is dropped.
+func (w *ACPWire) EncodeCancellation(ctx context.Context, c *Cancellation) ([]byte, error) {
+	if c.RequestID.IsZero() {
+		return nil, fmt.Errorf("%w: session id required", ErrEncodeFailure)
+	}
+	return w.EncodeCancel(ctx, &ACPCancelParams{SessionID: c.RequestID.String()})
+}
+
+// DecodeCancellation decodes a session/cancel notification into a
+// cancellation whose request ID is the session ID.
+func (w *ACPWire) DecodeCancellation(ctx context.Context, data []byte) (*Cancellation, error) {
+	call, err := w.DecodeCall(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("decode cancellation: %w", err)
+	}
+	if call.Method != ACPMethodSessionCancel {
+		return nil, fmt.Errorf("decode cancellation: %w: not a session/cancel notification", ErrDecodeFailure)
+	}
+	var params ACPCancelParams
+	if err := call.DecodeParams(&params); err != nil {
+		return nil, fmt.Errorf("decode cancellation: %w", err)
+	}
+	if params.SessionID == "" {
+		return nil, fmt.Errorf("decode cancellation: %w: missing session id", ErrDecodeFailure)
+	}
+	return &Cancellation{RequestID: StringID(params.SessionID)}, nil
+}
+
+// EncodeSessionUpdate encodes a session/update notification.
+func (w *ACPWire) EncodeSessionUpdate(ctx context.Context, n *ACPSessionNotification) ([]byte, error) {
+	return w.EncodeNotification(ctx, ACPMethodSessionUpdate, n)
+}
+
+// EncodeRequestPermission encodes a session/request_permission request.
+func (w *ACPWire) EncodeRequestPermission(ctx context.Context, id string, params *ACPRequestPermissionParams) ([]byte, error) {
+	return w.EncodeCall(ctx, id, ACPMethodRequestPermission, params)
+}
+
+// EncodeResult encodes a JSON-RPC response carrying an ACP result, such
+// as *ACPInitializeResult or *ACPPromptResult. A nil result encodes as null.
+func (w *ACPWire) EncodeResult(ctx context.Context, id string, result any) ([]byte, error) {
+	return json.Marshal(struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      string `json:"id"`
+		Result  any    `json:"result"`
+	}{"2.0", id, result})
+}
+
+// DecodeResult decodes a JSON-RPC response into result and returns its ID.
+// JSON-RPC errors are returned as *Error.
+func (w *ACPWire) DecodeResult(ctx context.Context, data []byte, result any) (string, error) {
+	var rpc struct {
+		ID     any             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *jsonrpcError   `json:"error"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return "", fmt.Errorf("decode acp result: %w", err)
+	}
+	id := rpcID(rpc.ID)
+	if rpc.Error != nil {
+		return id, decodedError("acp", rpc.Error.Code, rpc.Error.Message, rpc.Error.Data)
+	}
+	if len(rpc.Result) == 0 {
+		return id, fmt.Errorf("decode acp result: %w: missing result", ErrDecodeFailure)
+	}
+	if result == nil {
+		return id, nil
+	}
+	if err := json.Unmarshal(rpc.Result, result); err != nil {
+		return id, fmt.Errorf("decode acp result: %w", err)
+	}
+	return id, nil
+}
+
+// isACPMethod reports whether method belongs to the Agent Client Protocol
+// rather than the generic agentId/input invocation.
+func isACPMethod(method string) bool {
+	return method == ACPMethodInitialize || hasPrefix(method, acpMethodPrefixes)
+}
+
+// ACPBlocksFromContent converts content to ACP content blocks, preserving
+// order. Audio, and images with an audio/* MIME type, become audio
+// blocks; resources with text or data become embedded resources; files
+// become embedded blobs or resource links addressed by their name.
+// Annotations carry over to the blocks.
+func ACPBlocksFromContent(content []Content) []ACPContentBlock {
+	blocks := make([]ACPContentBlock, 0, len(content))
+	for _, c := range content {
+		n := len(blocks)
+		switch c.Type {
+		case ContentTypeText:
+			blocks = append(blocks, ACPContentBlock{Type: ACPContentText, Text: c.Text})
+		case ContentTypeImage:
+			typ := ACPContentImage
+			if strings.HasPrefix(c.MIMEType, "audio/") {
+				typ = ACPContentAudio
+			}
+			blocks = append(blocks, ACPContentBlock{Type: typ, Data: c.Data, MIMEType: c.MIMEType})
+		case ContentTypeAudio:
+			blocks = append(blocks, ACPContentBlock{Type: ACPContentAudio, Data: c.Data, MIMEType: c.MIMEType})
+		case ContentTypeResource:
+			if c.Text != "" || len(c.Data) > 0 {
+				blocks = append(blocks, ACPContentBlock{
+					Type:     ACPContentResource,
+					Resource: &ACPEmbeddedResource{URI: c.URI, MIMEType: c.MIMEType, Text: c.Text, Blob: c.Data},
+				})
+				break
+			}
+			blocks = append(blocks, ACPContentBlock{Type: ACPContentResourceLink, URI: c.URI, Name: c.URI, MIMEType: c.MIMEType})
+		case ContentTypeFile:
+			// ACP has no file block: inline data travels as an embedded
+			// blob and bare files as links, both addressed by path.
+			if len(c.Data) > 0 {
+				blocks = append(blocks, ACPContentBlock{
+					Type:     ACPContentResource,
+					Resource: &ACPEmbeddedResource{URI: c.Name, MIMEType: c.MIMEType, Blob: c.Data},
+				})
+				break
+			}
+			block := ACPContentBlock{Type: ACPContentResourceLink, URI: c.Name, Name: c.Name, MIMEType: c.MIMEType}
+			if c.Size != 0 {
+				size := c.Size
+				block.Size = &size
+			}
+			blocks = append(blocks, block)
+		}
+		if len(blocks) > n {
+			blocks[n].Annotations = annotationsMap(c.Annotations)
+		}
+	}
+	return blocks
+}
+
+// ACPBlocksToContent converts ACP content blocks to content, preserving order.
+func ACPBlocksToContent(blocks []ACPContentBlock) []Content {
+	content := make([]Content, 0, len(blocks))
+	for _, b := range blocks {
+		n := len(content)
+		switch b.Type {
+		case ACPContentText:
+			content = append(content, Content{Type: ContentTypeText, Text: b.Text})
+		case ACPContentImage:
+			content = append(content, Content{Type: ContentTypeImage, Data: b.Data, MIMEType: b.MIMEType})
+		case ACPContentAudio:
+			content = append(content, Content{Type: ContentTypeAudio, Data: b.Data, MIMEType: b.MIMEType})
+		case ACPContentResourceLink:
+			content = append(content, Content{Type: ContentTypeResource, URI: b.URI, MIMEType: b.MIMEType})
+		case ACPContentResource:
+			if b.Resource == nil {
+				continue
+			}
+			content = append(content, Content{
+				Type:     ContentTypeResource,
+				URI:      b.Resource.URI,
+				MIMEType: b.Resource.MIMEType,
+				Text:     b.Resource.Text,
+				Data:     b.Resource.Blob,
+			})
+		}
+		if len(content) > n {
+			content[n].Annotations = annotationsFromMap(b.Annotations)
+		}
+	}
+	return content
+}
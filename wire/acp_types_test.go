@@ -0,0 +1,420 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestACPWire_SessionCalls(t *testing.T) {
+	w := NewACP()
+	ctx := context.Background()
+
+	data, err := w.EncodeInitialize(ctx, "0", &ACPInitializeParams{
+		ProtocolVersion:    ACPProtocolVersion,
+		ClientCapabilities: ACPClientCapabilities{FS: ACPFileSystemCapability{ReadTextFile: true}},
+	})
+	if err != nil {
+		t.Fatalf("EncodeInitialize error = %v", err)
+	}
+	call, err := w.DecodeCall(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeCall error = %v", err)
+	}
+	var init ACPInitializeParams
+	if err := call.DecodeParams(&init); err != nil {
+		t.Fatalf("DecodeParams error = %v", err)
+	}
+	if call.Method != ACPMethodInitialize || init.ProtocolVersion != 1 || !init.ClientCapabilities.FS.ReadTextFile {
+		t.Errorf("initialize = %s %+v", call.Method, init)
+	}
+
+	data, err = w.EncodeNewSession(ctx, "1", &ACPNewSessionParams{CWD: "/work", MCPServers: []ACPMCPServer{}})
+	if err != nil {
+		t.Fatalf("EncodeNewSession error = %v", err)
+	}
+	if !strings.Contains(string(data), `"mcpServers":[]`) {
+		t.Errorf("session/new = %s, want empty mcpServers array", data)
+	}
+
+	prompt := &ACPPromptParams{
+		SessionID: "sess-1",
+		Prompt: []ACPContentBlock{
+			{Type: ACPContentText, Text: "explain"},
+			{Type: ACPContentResource, Resource: &ACPEmbeddedResource{URI: "file:///main.go", Text: "package main"}},
+			{Type: ACPContentText, Text: "briefly"},
+		},
+	}
+	data, err = w.EncodePrompt(ctx, "2", prompt)
+	if err != nil {
+		t.Fatalf("EncodePrompt error = %v", err)
+	}
+	call, err = w.DecodeCall(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeCall error = %v", err)
+	}
+	var got ACPPromptParams
+	if err := call.DecodeParams(&got); err != nil {
+		t.Fatalf("DecodeParams error = %v", err)
+	}
+	if !reflect.DeepEqual(&got, prompt) {
+		t.Errorf("prompt = %+v, want %+v", got, prompt)
+	}
+}
+
+func TestACPWire_Cancel_IsNotification(t *testing.T) {
+	w := NewACP()
+	ctx := context.Background()
+
+	data, err := w.EncodeCancel(ctx, &ACPCancelParams{SessionID: "sess-1"})
+	if err != nil {
+		t.Fatalf("EncodeCancel error = %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if _, ok := raw["id"]; ok {
+		t.Errorf("session/cancel has id: %s", data)
+	}
+	call, err := w.DecodeCall(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeCall error = %v", err)
+	}
+	if call.ID != "" || call.Method != ACPMethodSessionCancel {
+		t.Errorf("call = %q %q, want notification %s", call.ID, call.Method, ACPMethodSessionCancel)
+	}
+}
+
+func TestACPWire_Cancellation(t *testing.T) {
+	w := NewACP()
+	ctx := context.Background()
+
+	data, err := w.EncodeCancellation(ctx, &Cancellation{RequestID: StringID("sess-1"), Reason: "user aborted"})
+	if err != nil {
+		t.Fatalf("EncodeCancellation error = %v", err)
+	}
+	if want := `{"jsonrpc":"2.0","method":"session/cancel","params":{"sessionId":"sess-1"}}`; string(data) != want {
+		t.Errorf("EncodeCancellation = %s, want %s", data, want)
+	}
+	got, err := w.DecodeCancellation(ctx, data)
+	if err != nil || got.RequestID != StringID("sess-1") {
+		t.Errorf("DecodeCancellation = %+v, %v", got, err)
+	}
+
+	if _, err := w.EncodeCancellation(ctx, &Cancellation{}); !errors.Is(err, ErrEncodeFailure) {
+		t.Errorf("EncodeCancellation without id error = %v, want ErrEncodeFailure", err)
+	}
+	for name, data := range map[string]string{
+		"other method": `{"jsonrpc":"2.0","method":"session/update","params":{"sessionId":"s"}}`,
+		"no session":   `{"jsonrpc":"2.0","method":"session/cancel","params":{}}`,
+		"no params":    `{"jsonrpc":"2.0","method":"session/cancel"}`,
+	} {
+		if _, err := w.DecodeCancellation(ctx, []byte(data)); !errors.Is(err, ErrDecodeFailure) {
+			t.Errorf("%s: DecodeCancellation error = %v, want ErrDecodeFailure", name, err)
+		}
+	}
+	if _, err := w.EncodeProgress(ctx, &Progress{Token: StringID("t")}); !errors.Is(err, ErrUnsupportedFeature) {
+		t.Errorf("EncodeProgress error = %v, want ErrUnsupportedFeature", err)
+	}
+}
+
+func TestACPContentBlock_EmptyText(t *testing.T) {
+	data, err := json.Marshal([]ACPContentBlock{
+		{Type: ACPContentText},
+		{Type: ACPContentImage, Data: []byte{1}, MIMEType: "image/png"},
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal error = %v", err)
+	}
+	want := `[{"type":"text","text":""},{"type":"image","data":"AQ==","mimeType":"image/png"}]`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+}
+
+func TestACPWire_SessionUpdate_RoundTrip(t *testing.T) {
+	w := NewACP()
+	ctx := context.Background()
+	old := "a"
+
+	updates := []ACPSessionUpdate{
+		&ACPMessageChunk{Content: ACPContentBlock{Type: ACPContentText, Text: "Hello"}},
+		&ACPMessageChunk{SessionUpdate: ACPUpdateAgentThoughtChunk, Content: ACPContentBlock{Type: ACPContentText, Text: "Thinking"}},
+		&ACPToolCall{ToolCallID: "call-1", Title: "Read file", Kind: "read", Status: ACPStatusPending,
+			Locations: []ACPToolCallLocation{{Path: "/work/main.go"}}},
+		&ACPToolCallUpdate{ToolCallID: "call-1", Status: ACPStatusCompleted, Content: []ACPToolCallContent{
+			{Type: "content", Content: &ACPContentBlock{Type: ACPContentText, Text: "done"}},
+			{Type: "diff", Path: "/work/main.go", OldText: &old, NewText: "b"},
+		}},
+		&ACPPlan{Entries: []ACPPlanEntry{
+			{Content: "Read code", Priority: "high", Status: ACPStatusCompleted},
+			{Content: "Fix bug", Priority: "medium", Status: ACPStatusPending},
+		}},
+	}
+	for _, update := range updates {
+		t.Run(update.acpUpdateKind(), func(t *testing.T) {
+			data, err := w.EncodeSessionUpdate(ctx, &ACPSessionNotification{SessionID: "sess-1", Update: update})
+			if err != nil {
+				t.Fatalf("EncodeSessionUpdate error = %v", err)
+			}
+			if !strings.Contains(string(data), `"sessionUpdate":"`+update.acpUpdateKind()+`"`) {
+				t.Errorf("encoded = %s, want sessionUpdate %q", data, update.acpUpdateKind())
+			}
+			call, err := w.DecodeCall(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeCall error = %v", err)
+			}
+			var n ACPSessionNotification
+			if err := call.DecodeParams(&n); err != nil {
+				t.Fatalf("DecodeParams error = %v", err)
+			}
+			if n.SessionID != "sess-1" {
+				t.Errorf("SessionID = %q, want %q", n.SessionID, "sess-1")
+			}
+			if reflect.TypeOf(n.Update) != reflect.TypeOf(update) || n.Update.acpUpdateKind() != update.acpUpdateKind() {
+				t.Fatalf("Update = %T(%s), want %T(%s)", n.Update, n.Update.acpUpdateKind(), update, update.acpUpdateKind())
+			}
+			again, err := w.EncodeSessionUpdate(ctx, &n)
+			if err != nil {
+				t.Fatalf("EncodeSessionUpdate(again) error = %v", err)
+			}
+			if string(again) != string(data) {
+				t.Errorf("re-encoded = %s, want %s", again, data)
+			}
+		})
+	}
+}
+
+func TestACPWire_SessionUpdate_Fixture(t *testing.T) {
+	data := readFixture(t, "testdata/detect/acp/1/session_update.json")
+	call, err := NewACP().DecodeCall(context.Background(), data)
+	if err != nil {
+		t.Fatalf("DecodeCall error = %v", err)
+	}
+	var n ACPSessionNotification
+	if err := call.DecodeParams(&n); err != nil {
+		t.Fatalf("DecodeParams error = %v", err)
+	}
+	chunk, ok := n.Update.(*ACPMessageChunk)
+	if !ok || chunk.SessionUpdate != ACPUpdateAgentMessageChunk || chunk.Content.Text == "" {
+		t.Errorf("Update = %+v, want agent message chunk", n.Update)
+	}
+}
+
+func TestACPSessionNotification_UnknownUpdate(t *testing.T) {
+	var n ACPSessionNotification
+	err := json.Unmarshal([]byte(`{"sessionId":"s","update":{"sessionUpdate":"weather"}}`), &n)
+	if !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("Unmarshal error = %v, want ErrDecodeFailure", err)
+	}
+	if _, err := json.Marshal(ACPSessionNotification{SessionID: "s"}); !errors.Is(err, ErrEncodeFailure) {
+		t.Errorf("Marshal(nil update) error = %v, want ErrEncodeFailure", err)
+	}
+}
+
+func TestACPWire_RequestPermission(t *testing.T) {
+	w := NewACP()
+	ctx := context.Background()
+
+	params := &ACPRequestPermissionParams{
+		SessionID: "sess-1",
+		ToolCall:  ACPToolCallUpdate{ToolCallID: "call-1", Title: "Delete file"},
+		Options: []ACPPermissionOption{
+			{OptionID: "allow", Name: "Allow", Kind: ACPPermissionAllowOnce},
+			{OptionID: "reject", Name: "Reject", Kind: ACPPermissionRejectOnce},
+		},
+	}
+	data, err := w.EncodeRequestPermission(ctx, "5", params)
+	if err != nil {
+		t.Fatalf("EncodeRequestPermission error = %v", err)
+	}
+	call, err := w.DecodeCall(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeCall error = %v", err)
+	}
+	var got ACPRequestPermissionParams
+	if err := call.DecodeParams(&got); err != nil {
+		t.Fatalf("DecodeParams error = %v", err)
+	}
+	if !reflect.DeepEqual(&got, params) {
+		t.Errorf("params = %+v, want %+v", got, params)
+	}
+
+	data, err = w.EncodeResult(ctx, "5", &ACPRequestPermissionResult{Outcome: ACPPermissionOutcome{Outcome: "selected", OptionID: "allow"}})
+	if err != nil {
+		t.Fatalf("EncodeResult error = %v", err)
+	}
+	var result ACPRequestPermissionResult
+	id, err := w.DecodeResult(ctx, data, &result)
+	if err != nil {
+		t.Fatalf("DecodeResult error = %v", err)
+	}
+	if id != "5" || result.Outcome.OptionID != "allow" {
+		t.Errorf("result = %s %+v, want 5 allow", id, result)
+	}
+}
+
+func TestACPWire_DecodeResult_Errors(t *testing.T) {
+	w := NewACP()
+	ctx := context.Background()
+
+	var result ACPPromptResult
+	_, err := w.DecodeResult(ctx, []byte(`{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"bad prompt"}}`), &result)
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32602 {
+		t.Errorf("DecodeResult error = %v, want *Error -32602", err)
+	}
+	if _, err := w.DecodeResult(ctx, []byte(`{"jsonrpc":"2.0","id":2}`), &result); !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("DecodeResult(no result) error = %v, want ErrDecodeFailure", err)
+	}
+
+	id, err := w.DecodeResult(ctx, readFixture(t, "testdata/detect/acp/1/prompt_result.json"), &result)
+	if err != nil {
+		t.Fatalf("DecodeResult(fixture) error = %v", err)
+	}
+	if id != "2" || result.StopReason != ACPStopEndTurn {
+		t.Errorf("result = %s %+v, want 2 end_turn", id, result)
+	}
+}
+
+func TestACPWire_Request_SessionMethods(t *testing.T) {
+	w := NewACP()
+	ctx := context.Background()
+
+	req, err := w.DecodeRequest(ctx, readFixture(t, "testdata/detect/acp/1/session_prompt.json"))
+	if err != nil {
+		t.Fatalf("DecodeRequest error = %v", err)
+	}
+	if req.ID != "2" || req.Method != ACPMethodSessionPrompt {
+		t.Errorf("Request = %s %s, want 2 %s", req.ID, req.Method, ACPMethodSessionPrompt)
+	}
+	if req.Arguments["sessionId"] != "sess_abc123def456" {
+		t.Errorf("Arguments = %v, want sessionId", req.Arguments)
+	}
+	if _, ok := req.Arguments["prompt"].([]any); !ok {
+		t.Errorf("prompt = %T, want array", req.Arguments["prompt"])
+	}
+
+	req.Meta = map[string]any{"trace": "abc"}
+	data, err := w.EncodeRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("EncodeRequest error = %v", err)
+	}
+	if strings.Contains(string(data), "agentId") {
+		t.Errorf("session/prompt encoded as invocation: %s", data)
+	}
+	got, err := w.DecodeRequest(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeRequest error = %v", err)
+	}
+	if !reflect.DeepEqual(got, req) {
+		t.Errorf("round trip = %+v, want %+v", got, req)
+	}
+}
+
+func TestACPWire_Response_Ordered(t *testing.T) {
+	w := NewACP()
+	ctx := context.Background()
+
+	content := make([]Content, 12)
+	for i := range content {
+		content[i] = Content{Type: ContentTypeText, Text: strings.Repeat("x", i+1)}
+	}
+	content[3] = Content{Type: ContentTypeAudio, Data: []byte{1, 2}, MIMEType: "audio/wav"}
+	content[5] = Content{Type: ContentTypeResource, URI: "file:///a.txt", MIMEType: "text/plain"}
+	content[7] = Content{Type: ContentTypeResource, URI: "file:///b.txt", MIMEType: "text/plain", Text: "inline"}
+
+	resp := &Response{ID: "1", Content: content, Meta: map[string]any{"stopReason": ACPStopEndTurn, "k": "v"}}
+	data, err := w.EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	var raw struct {
+		Result struct {
+			StopReason string            `json:"stopReason"`
+			Output     []ACPContentBlock `json:"output"`
+			Metadata   map[string]any    `json:"metadata"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if raw.Result.StopReason != ACPStopEndTurn {
+		t.Errorf("stopReason = %q, want %q", raw.Result.StopReason, ACPStopEndTurn)
+	}
+	if _, ok := raw.Result.Metadata["stopReason"]; ok {
+		t.Errorf("metadata still has stopReason: %v", raw.Result.Metadata)
+	}
+	if raw.Result.Output[3].Type != ACPContentAudio || raw.Result.Output[5].Type != ACPContentResourceLink || raw.Result.Output[7].Type != ACPContentResource {
+		t.Errorf("block types = %s/%s/%s, want audio/resource_link/resource",
+			raw.Result.Output[3].Type, raw.Result.Output[5].Type, raw.Result.Output[7].Type)
+	}
+
+	got, err := w.DecodeResponse(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Content, content) {
+		t.Errorf("Content = %+v, want %+v", got.Content, content)
+	}
+	if !reflect.DeepEqual(got.Meta, resp.Meta) {
+		t.Errorf("Meta = %v, want %v", got.Meta, resp.Meta)
+	}
+	if resp.Meta["stopReason"] != ACPStopEndTurn {
+		t.Errorf("EncodeResponse modified caller's Meta")
+	}
+}
+
+func TestACPWire_DecodeResponse_LegacyOutputOrder(t *testing.T) {
+	data := []byte(`{"jsonrpc":"2.0","id":"1","result":{"status":"success","output":{
+		"content_10":{"type":"text","text":"k"},
+		"content_2":{"type":"text","text":"c"},
+		"content_0":{"type":"text","text":"a"},
+		"content_1":{"type":"resource","uri":"file:///b"},
+		"summary":"ignored"}}}`)
+	resp, err := NewACP().DecodeResponse(context.Background(), data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	var order []string
+	for _, c := range resp.Content {
+		order = append(order, c.Text+c.URI)
+	}
+	if want := []string{"a", "file:///b", "c", "k"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func readFixture(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile error = %v", err)
+	}
+	return data
+}
+
+func TestACPBlocksFromContent_Files(t *testing.T) {
+	blocks := ACPBlocksFromContent([]Content{
+		{Type: ContentTypeFile, Name: "file:///a.csv", MIMEType: "text/csv", Data: []byte("a,b")},
+		{Type: ContentTypeFile, Name: "file:///big.iso", Size: 4096},
+		{Type: ContentTypeResource, URI: "file:///a.bin", Data: []byte{1}},
+	})
+	if len(blocks) != 3 {
+		t.Fatalf("blocks = %+v, want 3", blocks)
+	}
+	if b := blocks[0]; b.Type != ACPContentResource || b.Resource.URI != "file:///a.csv" || string(b.Resource.Blob) != "a,b" {
+		t.Errorf("inline file block = %+v, want embedded blob", b)
+	}
+	if b := blocks[1]; b.Type != ACPContentResourceLink || b.Name != "file:///big.iso" || b.Size == nil || *b.Size != 4096 {
+		t.Errorf("bare file block = %+v, want sized resource link", b)
+	}
+	if b := blocks[2]; b.Type != ACPContentResource || len(b.Resource.Blob) != 1 {
+		t.Errorf("resource block = %+v, want embedded blob", b)
+	}
+}
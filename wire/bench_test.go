@@ -1,8 +1,13 @@
 package wire
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -351,3 +356,350 @@ func BenchmarkMCP_LargeResponse(b *testing.B) {
 		_, _ = w.EncodeResponse(ctx, resp)
 	}
 }
+
+// BenchmarkEncoder_WriteMessage measures framing overhead per message.
+func BenchmarkEncoder_WriteMessage(b *testing.B) {
+	payload := []byte(`{"jsonrpc":"2.0","id":"req-1","method":"tools/call","params":{"name":"search","arguments":{"query":"test"}}}`)
+
+	for _, framing := range []Framing{FramingNDJSON, FramingContentLength, FramingSSE} {
+		b.Run(framing.Name(), func(b *testing.B) {
+			enc := NewEncoder(io.Discard, NewMCP(), framing)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = enc.WriteMessage(payload)
+			}
+		})
+	}
+}
+
+// BenchmarkDecoder_ReadMessage measures frame parsing per message.
+func BenchmarkDecoder_ReadMessage(b *testing.B) {
+	payload := []byte(`{"jsonrpc":"2.0","id":"req-1","method":"tools/call","params":{"name":"search","arguments":{"query":"test"}}}`)
+
+	for _, framing := range []Framing{FramingNDJSON, FramingContentLength, FramingSSE} {
+		b.Run(framing.Name(), func(b *testing.B) {
+			frame := framing.AppendFrame(nil, payload)
+			r := bytes.NewReader(frame)
+			dec := NewDecoder(r, NewMCP(), framing)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r.Reset(frame)
+				dec.r.Reset(r)
+				_, _ = dec.ReadMessage()
+			}
+		})
+	}
+}
+
+// BenchmarkCanonical_EncodeRequest measures the overhead of canonical mode.
+func BenchmarkCanonical_EncodeRequest(b *testing.B) {
+	w := NewCanonical(NewMCP())
+	ctx := context.Background()
+	req := &Request{
+		ID:     "req-1",
+		Method: "tools/call",
+		ToolID: "search",
+		Arguments: map[string]any{
+			"query": "test query",
+			"limit": 10,
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = w.EncodeRequest(ctx, req)
+	}
+}
+
+// BenchmarkSchema_Validate measures argument validation against a compiled schema.
+func BenchmarkSchema_Validate(b *testing.B) {
+	s, err := CompileSchema(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string", "minLength": 1.0},
+			"limit": map[string]any{"type": "integer", "minimum": 1.0, "maximum": 100.0},
+		},
+		"required": []any{"query"},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	args := map[string]any{"query": "test query", "limit": 10.0}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.Validate(args)
+	}
+}
+
+// BenchmarkPaginate measures paging through a 1000-item registry.
+func BenchmarkPaginate(b *testing.B) {
+	tools := make([]Tool, 1000)
+	for i := range tools {
+		tools[i] = Tool{Name: fmt.Sprintf("tool-%04d", i)}
+	}
+	p := Pager{Codec: NewCursorCodec([]byte("bench")), Scope: "tools/list"}
+	first, err := Paginate(p, tools, func(t Tool) string { return t.Name }, "")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Paginate(p, tools, func(t Tool) string { return t.Name }, first.NextCursor)
+	}
+}
+
+// binaryBenchWires are the codecs compared by the binary benchmarks.
+func binaryBenchWires() []Wire {
+	return []Wire{NewMCP(), NewBinary(EncodingJSON), NewBinary(EncodingCBOR), NewBinary(EncodingMsgPack)}
+}
+
+func binaryBenchName(w Wire) string {
+	if bw, ok := w.(*BinaryWire); ok {
+		return "binary-" + bw.Name()
+	}
+	return w.Name()
+}
+
+// BenchmarkBinary_EncodeRequest compares request encoding across the
+// JSON and binary encodings.
+func BenchmarkBinary_EncodeRequest(b *testing.B) {
+	ctx := context.Background()
+	req := &Request{
+		ID:     "1",
+		Method: "tools/call",
+		ToolID: "search",
+		Arguments: map[string]any{
+			"query":   "golang concurrency",
+			"limit":   10,
+			"filters": []any{"recent", "popular"},
+		},
+	}
+	for _, w := range binaryBenchWires() {
+		b.Run(binaryBenchName(w), func(b *testing.B) {
+			var data []byte
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				data, _ = w.EncodeRequest(ctx, req)
+			}
+			b.ReportMetric(float64(len(data)), "bytes/msg")
+		})
+	}
+}
+
+// BenchmarkBinary_EncodeImageResponse compares encoding a response
+// carrying a 64KB image, where JSON pays for base64.
+func BenchmarkBinary_EncodeImageResponse(b *testing.B) {
+	ctx := context.Background()
+	resp := &Response{
+		ID:      "1",
+		Content: []Content{{Type: ContentTypeImage, MIMEType: "image/png", Data: make([]byte, 64<<10)}},
+	}
+	for _, w := range binaryBenchWires() {
+		b.Run(binaryBenchName(w), func(b *testing.B) {
+			var data []byte
+			b.SetBytes(int64(len(resp.Content[0].Data)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				data, _ = w.EncodeResponse(ctx, resp)
+			}
+			b.ReportMetric(float64(len(data)), "bytes/msg")
+		})
+	}
+}
+
+// BenchmarkBinary_DecodeImageResponse compares decoding a response
+// carrying a 64KB image.
+func BenchmarkBinary_DecodeImageResponse(b *testing.B) {
+	ctx := context.Background()
+	resp := &Response{
+		ID:      "1",
+		Content: []Content{{Type: ContentTypeImage, MIMEType: "image/png", Data: make([]byte, 64<<10)}},
+	}
+	for _, w := range binaryBenchWires() {
+		b.Run(binaryBenchName(w), func(b *testing.B) {
+			data, err := w.EncodeResponse(ctx, resp)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.SetBytes(int64(len(resp.Content[0].Data)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = w.DecodeResponse(ctx, data)
+			}
+		})
+	}
+}
+
+// BenchmarkDecodeRequest_Typed compares decoding a tools/call request
+// into Request.Arguments with decoding it raw and then into a struct.
+// The eager case is the baseline DecodeRequest had before the raw form.
+func BenchmarkDecodeRequest_Typed(b *testing.B) {
+	type args struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit"`
+	}
+	codecs := []struct {
+		name string
+		wire interface {
+			Wire
+			RawRequestDecoder
+		}
+		data  []byte
+		eager func([]byte) (*Request, error)
+	}{
+		{"mcp", NewMCP(), []byte(`{"jsonrpc":"2.0","id":"req-1","method":"tools/call","params":{"name":"search","arguments":{"query":"test","limit":10}}}`), eagerDecodeMCP},
+		{"a2a", NewA2A(), []byte(`{"jsonrpc":"2.0","id":"req-1","method":"message/send","params":{"message":{"kind":"message","messageId":"m1","role":"user","metadata":{"skillId":"search"},"parts":[{"kind":"data","data":{"query":"test","limit":10}}]}}}`), eagerDecodeA2A},
+		{"acp", NewACP(), []byte(`{"jsonrpc":"2.0","id":"req-1","method":"agent/invoke","params":{"agentId":"search","input":{"query":"test","limit":10}}}`), eagerDecodeACP},
+	}
+	ctx := context.Background()
+	for _, c := range codecs {
+		want, err := c.wire.DecodeRequest(ctx, c.data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if got, err := c.eager(c.data); err != nil || !reflect.DeepEqual(got, want) {
+			b.Fatalf("%s eager decode = %+v, %v, want %+v", c.name, got, err, want)
+		}
+		b.Run(c.name+"/eager", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = c.eager(c.data)
+			}
+		})
+		b.Run(c.name+"/map", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = c.wire.DecodeRequest(ctx, c.data)
+			}
+		})
+		b.Run(c.name+"/raw", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = c.wire.DecodeRawRequest(ctx, c.data)
+			}
+		})
+		b.Run(c.name+"/typed", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				raw, _ := c.wire.DecodeRawRequest(ctx, c.data)
+				_, _ = DecodeRawArguments[args](raw)
+			}
+		})
+	}
+}
+
+// The eagerDecode functions are DecodeRequest as it was before the
+// codecs decoded into typed envelopes with raw arguments: the params are
+// unmarshaled into generic maps and the fields picked out of them. They
+// cover the tool invocations BenchmarkDecodeRequest_Typed decodes.
+
+func eagerDecodeMCP(data []byte) (*Request, error) {
+	var rpc struct {
+		ID     any            `json:"id"`
+		Method string         `json:"method"`
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return nil, err
+	}
+	req := &Request{ID: rpcID(rpc.ID), Method: rpc.Method}
+	req.ToolID, _ = rpc.Params["name"].(string)
+	req.Arguments, _ = rpc.Params["arguments"].(map[string]any)
+	req.Meta, _ = rpc.Params["_meta"].(map[string]any)
+	return req, nil
+}
+
+func eagerDecodeA2A(data []byte) (*Request, error) {
+	var rpc struct {
+		ID     any             `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return nil, err
+	}
+	req := &Request{ID: rpcID(rpc.ID), Method: rpc.Method}
+	// The params were decoded once to find the message, then again into
+	// the message body.
+	var params map[string]any
+	if err := json.Unmarshal(rpc.Params, &params); err != nil || params["message"] == nil {
+		return req, nil
+	}
+	var body struct {
+		Message struct {
+			Parts []struct {
+				Kind string         `json:"kind"`
+				Text string         `json:"text"`
+				Data map[string]any `json:"data"`
+			} `json:"parts"`
+			Metadata map[string]any `json:"metadata"`
+		} `json:"message"`
+		Metadata map[string]any `json:"metadata"`
+	}
+	if err := json.Unmarshal(rpc.Params, &body); err != nil {
+		return nil, err
+	}
+	req.ToolID, _ = body.Message.Metadata[a2aSkillKey].(string)
+	for _, p := range body.Message.Parts {
+		if p.Kind == A2APartData && req.Arguments == nil {
+			req.Arguments = p.Data
+		}
+	}
+	req.Meta = body.Metadata
+	return req, nil
+}
+
+func eagerDecodeACP(data []byte) (*Request, error) {
+	var rpc struct {
+		ID     any            `json:"id"`
+		Method string         `json:"method"`
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return nil, err
+	}
+	req := &Request{ID: rpcID(rpc.ID), Method: rpc.Method}
+	req.ToolID, _ = rpc.Params["agentId"].(string)
+	req.Arguments, _ = rpc.Params["input"].(map[string]any)
+	req.Meta, _ = rpc.Params["metadata"].(map[string]any)
+	return req, nil
+}
+
+// BenchmarkIntercept_EncodeRequest measures the overhead of an
+// interceptor chain over the bare codec.
+func BenchmarkIntercept_EncodeRequest(b *testing.B) {
+	ctx := context.Background()
+	req := &Request{
+		ID:        "1",
+		Method:    "tools/call",
+		ToolID:    "login",
+		Arguments: map[string]any{"user": "ada", "password": "hunter2"},
+	}
+	redactor, err := NewRedactor("$..password")
+	if err != nil {
+		b.Fatal(err)
+	}
+	wires := map[string]Wire{
+		"bare":    NewMCP(),
+		"limit":   Intercept(NewMCP(), LimitSize(1<<20)),
+		"redact":  Intercept(NewMCP(), Redact(redactor)),
+		"logging": Intercept(NewMCP(), Logging(slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug})))),
+	}
+	for _, name := range []string{"bare", "limit", "redact", "logging"} {
+		w := wires[name]
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = w.EncodeRequest(ctx, req)
+			}
+		})
+	}
+}
@@ -0,0 +1,571 @@
+package wire
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/prompt"
+	"github.com/jonwraymond/toolprotocol/resource"
+	"github.com/jonwraymond/toolprotocol/task"
+)
+
+// BinaryVersion is the version of the BinaryWire message layout.
+const BinaryVersion = "1"
+
+// BinaryWire implements Wire by serializing the Request, Response and
+// Tool model directly with an Encoding, for internal traffic where both
+// ends are this package. Content data travels as raw bytes rather than
+// base64 in CBOR and MessagePack.
+//
+// Messages are maps keyed by the model's field names in camel case
+// ("id", "method", "toolId", "arguments", "content", "isError", ...);
+// empty fields are omitted, and content durations are nanoseconds.
+// Decoded numbers in arguments, metadata and schemas are float64, as
+// with the JSON codecs.
+//
+// Contract:
+//   - Concurrency: Stateless and safe for concurrent use.
+//   - Errors: Encode methods wrap ErrEncodeFailure and decode methods
+//     wrap ErrDecodeFailure, with the operation as context.
+type BinaryWire struct {
+	enc Encoding
+}
+
+var _ PageCodec = (*BinaryWire)(nil)
+
+// NewBinary creates a wire format handler that serializes the model with
+// enc, usually EncodingCBOR or EncodingMsgPack.
+func NewBinary(enc Encoding) *BinaryWire {
+	return &BinaryWire{enc: enc}
+}
+
+// Name returns the encoding name (e.g., "cbor").
+func (w *BinaryWire) Name() string {
+	return w.enc.Name()
+}
+
+// Version returns BinaryVersion.
+func (w *BinaryWire) Version() string {
+	return BinaryVersion
+}
+
+// ContentType returns the media type of encoded messages.
+func (w *BinaryWire) ContentType() string {
+	return w.enc.ContentType()
+}
+
+// Encoding returns the encoding messages are serialized with.
+func (w *BinaryWire) Encoding() Encoding {
+	return w.enc
+}
+
+// EncodeRequest encodes a request.
+func (w *BinaryWire) EncodeRequest(ctx context.Context, req *Request) ([]byte, error) {
+	return w.marshal("encode request", requestMap(req))
+}
+
+// DecodeRequest decodes a request.
+func (w *BinaryWire) DecodeRequest(ctx context.Context, data []byte) (*Request, error) {
+	m, err := w.unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+	req, err := requestFromMap(m)
+	if err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+	return req, nil
+}
+
+// EncodeResponse encodes a response.
+func (w *BinaryWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, error) {
+	return w.marshal("encode response", responseMap(resp))
+}
+
+// DecodeResponse decodes a response.
+func (w *BinaryWire) DecodeResponse(ctx context.Context, data []byte) (*Response, error) {
+	m, err := w.unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	resp, err := responseFromMap(m)
+	if err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return resp, nil
+}
+
+// EncodeToolList encodes a list of tools.
+func (w *BinaryWire) EncodeToolList(ctx context.Context, tools []Tool) ([]byte, error) {
+	return w.EncodeToolPage(ctx, &Page[Tool]{Items: tools})
+}
+
+// DecodeToolList decodes a list of tools.
+func (w *BinaryWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+	page, err := w.DecodeToolPage(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// EncodeToolPage encodes one page of a tool list with its next cursor.
+func (w *BinaryWire) EncodeToolPage(ctx context.Context, page *Page[Tool]) ([]byte, error) {
+	tools := make([]any, len(page.Items))
+	for i, t := range page.Items {
+		tm := map[string]any{}
+		putString(tm, "name", t.Name)
+		putString(tm, "description", t.Description)
+		putMap(tm, "inputSchema", t.InputSchema)
+		putMap(tm, "outputSchema", t.OutputSchema)
+		putMap(tm, "annotations", t.Annotations)
+		tools[i] = tm
+	}
+	m := map[string]any{"tools": tools}
+	putString(m, "nextCursor", page.NextCursor)
+	return w.marshal("encode tool list", m)
+}
+
+// DecodeToolPage decodes one page of a tool list and its next cursor.
+func (w *BinaryWire) DecodeToolPage(ctx context.Context, data []byte) (*Page[Tool], error) {
+	m, err := w.unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode tool list: %w", err)
+	}
+	f := fields{m: m}
+	page := &Page[Tool]{NextCursor: f.string("nextCursor")}
+	for _, item := range f.array("tools") {
+		tf := fields{m: f.asObject("tools", item)}
+		page.Items = append(page.Items, Tool{
+			Name:         tf.string("name"),
+			Description:  tf.string("description"),
+			InputSchema:  tf.object("inputSchema"),
+			OutputSchema: tf.object("outputSchema"),
+			Annotations:  tf.object("annotations"),
+		})
+		f.merge(tf.err)
+	}
+	if f.err != nil {
+		return nil, fmt.Errorf("decode tool list: %w", f.err)
+	}
+	return page, nil
+}
+
+// EncodeResourcePage encodes one page of a resource list.
+func (w *BinaryWire) EncodeResourcePage(ctx context.Context, page *Page[resource.Resource]) ([]byte, error) {
+	resources := make([]any, len(page.Items))
+	for i, r := range page.Items {
+		rm := map[string]any{}
+		putString(rm, "uri", r.URI)
+		putString(rm, "name", r.Name)
+		putString(rm, "description", r.Description)
+		putString(rm, "mimeType", r.MIMEType)
+		putMap(rm, "annotations", r.Annotations)
+		resources[i] = rm
+	}
+	m := map[string]any{"resources": resources}
+	putString(m, "nextCursor", page.NextCursor)
+	return w.marshal("encode resource list", m)
+}
+
+// DecodeResourcePage decodes one page of a resource list.
+func (w *BinaryWire) DecodeResourcePage(ctx context.Context, data []byte) (*Page[resource.Resource], error) {
+	m, err := w.unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode resource list: %w", err)
+	}
+	f := fields{m: m}
+	page := &Page[resource.Resource]{NextCursor: f.string("nextCursor")}
+	for _, item := range f.array("resources") {
+		rf := fields{m: f.asObject("resources", item)}
+		page.Items = append(page.Items, resource.Resource{
+			URI:         rf.string("uri"),
+			Name:        rf.string("name"),
+			Description: rf.string("description"),
+			MIMEType:    rf.string("mimeType"),
+			Annotations: rf.object("annotations"),
+		})
+		f.merge(rf.err)
+	}
+	if f.err != nil {
+		return nil, fmt.Errorf("decode resource list: %w", f.err)
+	}
+	return page, nil
+}
+
+// EncodePromptPage encodes one page of a prompt list.
+func (w *BinaryWire) EncodePromptPage(ctx context.Context, page *Page[prompt.Prompt]) ([]byte, error) {
+	prompts := make([]any, len(page.Items))
+	for i, p := range page.Items {
+		pm := map[string]any{}
+		putString(pm, "name", p.Name)
+		putString(pm, "description", p.Description)
+		if len(p.Arguments) > 0 {
+			args := make([]any, len(p.Arguments))
+			for j, a := range p.Arguments {
+				am := map[string]any{}
+				putString(am, "name", a.Name)
+				putString(am, "description", a.Description)
+				if a.Required {
+					am["required"] = true
+				}
+				args[j] = am
+			}
+			pm["arguments"] = args
+		}
+		prompts[i] = pm
+	}
+	m := map[string]any{"prompts": prompts}
+	putString(m, "nextCursor", page.NextCursor)
+	return w.marshal("encode prompt list", m)
+}
+
+// DecodePromptPage decodes one page of a prompt list.
+func (w *BinaryWire) DecodePromptPage(ctx context.Context, data []byte) (*Page[prompt.Prompt], error) {
+	m, err := w.unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode prompt list: %w", err)
+	}
+	f := fields{m: m}
+	page := &Page[prompt.Prompt]{NextCursor: f.string("nextCursor")}
+	for _, item := range f.array("prompts") {
+		pf := fields{m: f.asObject("prompts", item)}
+		p := prompt.Prompt{Name: pf.string("name"), Description: pf.string("description")}
+		for _, arg := range pf.array("arguments") {
+			af := fields{m: pf.asObject("arguments", arg)}
+			p.Arguments = append(p.Arguments, prompt.Argument{
+				Name:        af.string("name"),
+				Description: af.string("description"),
+				Required:    af.bool("required"),
+			})
+			pf.merge(af.err)
+		}
+		page.Items = append(page.Items, p)
+		f.merge(pf.err)
+	}
+	if f.err != nil {
+		return nil, fmt.Errorf("decode prompt list: %w", f.err)
+	}
+	return page, nil
+}
+
+// EncodeTaskPage encodes one page of a task list. Times are RFC 3339
+// strings; results and errors are not listed.
+func (w *BinaryWire) EncodeTaskPage(ctx context.Context, page *Page[*task.Task]) ([]byte, error) {
+	tasks := make([]any, len(page.Items))
+	for i, t := range page.Items {
+		tm := map[string]any{}
+		putString(tm, "id", t.ID)
+		putString(tm, "state", string(t.State))
+		if t.Progress != 0 {
+			tm["progress"] = t.Progress
+		}
+		putString(tm, "message", t.Message)
+		putTime(tm, "createdAt", t.CreatedAt)
+		putTime(tm, "updatedAt", t.UpdatedAt)
+		if t.CompletedAt != nil {
+			putTime(tm, "completedAt", *t.CompletedAt)
+		}
+		tasks[i] = tm
+	}
+	m := map[string]any{"tasks": tasks}
+	putString(m, "nextCursor", page.NextCursor)
+	return w.marshal("encode task list", m)
+}
+
+// DecodeTaskPage decodes one page of a task list.
+func (w *BinaryWire) DecodeTaskPage(ctx context.Context, data []byte) (*Page[*task.Task], error) {
+	m, err := w.unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode task list: %w", err)
+	}
+	f := fields{m: m}
+	page := &Page[*task.Task]{NextCursor: f.string("nextCursor")}
+	for _, item := range f.array("tasks") {
+		tf := fields{m: f.asObject("tasks", item)}
+		t := &task.Task{
+			ID:        tf.string("id"),
+			State:     task.State(tf.string("state")),
+			Progress:  tf.number("progress"),
+			Message:   tf.string("message"),
+			CreatedAt: tf.time("createdAt"),
+			UpdatedAt: tf.time("updatedAt"),
+		}
+		if completed := tf.time("completedAt"); !completed.IsZero() {
+			t.CompletedAt = &completed
+		}
+		page.Items = append(page.Items, t)
+		f.merge(tf.err)
+	}
+	if f.err != nil {
+		return nil, fmt.Errorf("decode task list: %w", f.err)
+	}
+	return page, nil
+}
+
+// Capabilities returns the binary layout's capabilities. It carries the
+// request/response model only; streaming and notifications stay with
+// the protocol codecs.
+func (w *BinaryWire) Capabilities() *Capabilities {
+	return &Capabilities{}
+}
+
+func (w *BinaryWire) marshal(op string, m map[string]any) ([]byte, error) {
+	data, err := w.enc.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return data, nil
+}
+
+func (w *BinaryWire) unmarshal(data []byte) (map[string]any, error) {
+	v, err := w.enc.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: message is %T, not a map", ErrDecodeFailure, v)
+	}
+	return m, nil
+}
+
+// requestMap returns the map layout of a request, keyed by the model's
+// field names in camel case with empty fields omitted.
+func requestMap(req *Request) map[string]any {
+	m := map[string]any{}
+	putString(m, "id", req.ID)
+	putString(m, "method", req.Method)
+	putString(m, "toolId", req.ToolID)
+	putMap(m, "arguments", req.Arguments)
+	putMap(m, "meta", req.Meta)
+	return m
+}
+
+// requestFromMap is the inverse of requestMap.
+func requestFromMap(m map[string]any) (*Request, error) {
+	f := fields{m: m}
+	req := &Request{
+		ID:        f.string("id"),
+		Method:    f.string("method"),
+		ToolID:    f.string("toolId"),
+		Arguments: f.object("arguments"),
+		Meta:      f.object("meta"),
+	}
+	return req, f.err
+}
+
+// responseMap returns the map layout of a response.
+func responseMap(resp *Response) map[string]any {
+	m := map[string]any{}
+	putString(m, "id", resp.ID)
+	if len(resp.Content) > 0 {
+		content := make([]any, len(resp.Content))
+		for i, c := range resp.Content {
+			cm := map[string]any{}
+			putString(cm, "type", string(c.Type))
+			putString(cm, "text", c.Text)
+			putString(cm, "mimeType", c.MIMEType)
+			if len(c.Data) > 0 {
+				cm["data"] = c.Data
+			}
+			putString(cm, "uri", c.URI)
+			putString(cm, "altText", c.AltText)
+			if c.Width != 0 {
+				cm["width"] = float64(c.Width)
+			}
+			if c.Height != 0 {
+				cm["height"] = float64(c.Height)
+			}
+			putString(cm, "name", c.Name)
+			if c.Size != 0 {
+				cm["size"] = float64(c.Size)
+			}
+			if c.Duration != 0 {
+				cm["duration"] = float64(c.Duration)
+			}
+			putMap(cm, "annotations", annotationsMap(c.Annotations))
+			content[i] = cm
+		}
+		m["content"] = content
+	}
+	putMap(m, "structuredContent", resp.StructuredContent)
+	if resp.IsError {
+		m["isError"] = true
+	}
+	if resp.Error != nil {
+		em := map[string]any{"code": float64(resp.Error.Code), "message": resp.Error.Message}
+		if resp.Error.Data != nil {
+			em["data"] = resp.Error.Data
+		}
+		m["error"] = em
+	}
+	putMap(m, "meta", resp.Meta)
+	return m
+}
+
+// responseFromMap is the inverse of responseMap.
+func responseFromMap(m map[string]any) (*Response, error) {
+	f := fields{m: m}
+	resp := &Response{
+		ID:                f.string("id"),
+		StructuredContent: f.object("structuredContent"),
+		IsError:           f.bool("isError"),
+		Meta:              f.object("meta"),
+	}
+	for _, item := range f.array("content") {
+		cf := fields{m: f.asObject("content", item)}
+		resp.Content = append(resp.Content, Content{
+			Type:        ContentType(cf.string("type")),
+			Text:        cf.string("text"),
+			MIMEType:    cf.string("mimeType"),
+			Data:        cf.bytes("data"),
+			URI:         cf.string("uri"),
+			AltText:     cf.string("altText"),
+			Width:       int(cf.number("width")),
+			Height:      int(cf.number("height")),
+			Name:        cf.string("name"),
+			Size:        int64(cf.number("size")),
+			Duration:    time.Duration(cf.number("duration")),
+			Annotations: annotationsFromMap(cf.object("annotations")),
+		})
+		f.merge(cf.err)
+	}
+	if em := f.object("error"); em != nil {
+		ef := fields{m: em}
+		resp.Error = &Error{
+			Code:    int(ef.number("code")),
+			Message: ef.string("message"),
+			Data:    em["data"],
+		}
+		f.merge(ef.err)
+	}
+	return resp, f.err
+}
+
+func putMap(m map[string]any, key string, v map[string]any) {
+	if v != nil {
+		m[key] = v
+	}
+}
+
+func putTime(m map[string]any, key string, t time.Time) {
+	if !t.IsZero() {
+		m[key] = t.Format(time.RFC3339Nano)
+	}
+}
+
+// fields reads typed fields from a decoded map, recording the first
+// type mismatch.
+type fields struct {
+	m   map[string]any
+	err error
+}
+
+func (f *fields) merge(err error) {
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+func (f *fields) mismatch(key string, v any, want string) {
+	f.merge(fmt.Errorf("%w: field %q is %T, want %s", ErrDecodeFailure, key, v, want))
+}
+
+func (f *fields) string(key string) string {
+	v, ok := f.m[key]
+	if !ok || v == nil {
+		return ""
+	}
+	s, ok := v.(string)
+	if !ok {
+		f.mismatch(key, v, "string")
+	}
+	return s
+}
+
+func (f *fields) bool(key string) bool {
+	v, ok := f.m[key]
+	if !ok || v == nil {
+		return false
+	}
+	b, ok := v.(bool)
+	if !ok {
+		f.mismatch(key, v, "bool")
+	}
+	return b
+}
+
+func (f *fields) number(key string) float64 {
+	v, ok := f.m[key]
+	if !ok || v == nil {
+		return 0
+	}
+	n, ok := v.(float64)
+	if !ok {
+		f.mismatch(key, v, "number")
+	}
+	return n
+}
+
+// bytes reads a byte string; JSON encodes them as base64 strings.
+func (f *fields) bytes(key string) []byte {
+	switch v := f.m[key].(type) {
+	case nil:
+		return nil
+	case []byte:
+		return v
+	case string:
+		b, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			f.merge(fmt.Errorf("%w: field %q: %w", ErrDecodeFailure, key, err))
+		}
+		return b
+	default:
+		f.mismatch(key, v, "bytes")
+		return nil
+	}
+}
+
+// time reads an RFC 3339 timestamp string.
+func (f *fields) time(key string) time.Time {
+	s := f.string(key)
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		f.merge(fmt.Errorf("%w: field %q: %w", ErrDecodeFailure, key, err))
+	}
+	return t
+}
+
+func (f *fields) object(key string) map[string]any {
+	v, ok := f.m[key]
+	if !ok || v == nil {
+		return nil
+	}
+	return f.asObject(key, v)
+}
+
+func (f *fields) asObject(key string, v any) map[string]any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		f.mismatch(key, v, "map")
+	}
+	return m
+}
+
+func (f *fields) array(key string) []any {
+	v, ok := f.m[key]
+	if !ok || v == nil {
+		return nil
+	}
+	a, ok := v.([]any)
+	if !ok {
+		f.mismatch(key, v, "array")
+	}
+	return a
+}
@@ -0,0 +1,189 @@
+package wire
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var binaryEncodings = []Encoding{EncodingJSON, EncodingCBOR, EncodingMsgPack}
+
+func TestBinaryWire_Request(t *testing.T) {
+	ctx := context.Background()
+	req := &Request{
+		ID:        "7",
+		Method:    "tools/call",
+		ToolID:    "search",
+		Arguments: map[string]any{"query": "golang", "limit": 10.0, "tags": []any{"a", "b"}},
+		Meta:      map[string]any{"progressToken": "p1"},
+	}
+	for _, enc := range binaryEncodings {
+		t.Run(enc.Name(), func(t *testing.T) {
+			w := NewBinary(enc)
+			data, err := w.EncodeRequest(ctx, req)
+			if err != nil {
+				t.Fatalf("EncodeRequest error: %v", err)
+			}
+			got, err := w.DecodeRequest(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeRequest error: %v", err)
+			}
+			if !reflect.DeepEqual(got, req) {
+				t.Errorf("DecodeRequest = %+v, want %+v", got, req)
+			}
+		})
+	}
+}
+
+func TestBinaryWire_Response(t *testing.T) {
+	ctx := context.Background()
+	image := bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, 256)
+	resp := &Response{
+		ID: "7",
+		Content: []Content{
+			{Type: ContentTypeText, Text: "found"},
+			{Type: ContentTypeImage, MIMEType: "image/png", Data: image},
+			{Type: ContentTypeResource, URI: "file:///a.txt", MIMEType: "text/plain"},
+			{Type: ContentTypeAudio, MIMEType: "audio/wav", Data: []byte("RIFF"), Duration: 1500 * time.Millisecond},
+			{Type: ContentTypeFile, MIMEType: "text/csv", Name: "/reports/q1.csv", Size: 2048},
+		},
+		StructuredContent: map[string]any{"count": 2.0},
+		IsError:           true,
+		Error:             &Error{Code: CodeInvalidParams, Message: "bad", Data: map[string]any{"field": "q"}},
+		Meta:              map[string]any{"taskId": "t1"},
+	}
+	for _, enc := range binaryEncodings {
+		t.Run(enc.Name(), func(t *testing.T) {
+			w := NewBinary(enc)
+			data, err := w.EncodeResponse(ctx, resp)
+			if err != nil {
+				t.Fatalf("EncodeResponse error: %v", err)
+			}
+			got, err := w.DecodeResponse(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeResponse error: %v", err)
+			}
+			if !reflect.DeepEqual(got, resp) {
+				t.Errorf("DecodeResponse = %+v, want %+v", got, resp)
+			}
+			// Binary encodings carry image data as raw bytes.
+			if enc != EncodingJSON && !bytes.Contains(data, image) {
+				t.Error("image data is not carried as raw bytes")
+			}
+		})
+	}
+}
+
+func TestBinaryWire_ToolPage(t *testing.T) {
+	ctx := context.Background()
+	page := &Page[Tool]{
+		Items: []Tool{{
+			Name:         "search",
+			Description:  "Search",
+			InputSchema:  map[string]any{"type": "object", "required": []any{"q"}},
+			OutputSchema: map[string]any{"type": "object"},
+		}, {Name: "noop"}},
+		NextCursor: "c",
+	}
+	for _, enc := range binaryEncodings {
+		t.Run(enc.Name(), func(t *testing.T) {
+			w := NewBinary(enc)
+			data, err := w.EncodeToolPage(ctx, page)
+			if err != nil {
+				t.Fatalf("EncodeToolPage error: %v", err)
+			}
+			got, err := w.DecodeToolPage(ctx, data)
+			if err != nil || !reflect.DeepEqual(got, page) {
+				t.Errorf("DecodeToolPage = %+v, %v, want %+v", got, err, page)
+			}
+			tools, err := w.DecodeToolList(ctx, data)
+			if err != nil || len(tools) != 2 {
+				t.Errorf("DecodeToolList = %v, %v", tools, err)
+			}
+		})
+	}
+}
+
+func TestBinaryWire_Metadata(t *testing.T) {
+	w := NewBinary(EncodingCBOR)
+	if w.Name() != "cbor" || w.Version() != BinaryVersion || w.ContentType() != MediaTypeCBOR {
+		t.Errorf("metadata = %s %s %s", w.Name(), w.Version(), w.ContentType())
+	}
+	if w.Encoding() != EncodingCBOR {
+		t.Error("Encoding() is not the codec's encoding")
+	}
+	if *w.Capabilities() != (Capabilities{}) {
+		t.Errorf("Capabilities = %+v, want none", w.Capabilities())
+	}
+}
+
+func TestBinaryWire_DecodeErrors(t *testing.T) {
+	ctx := context.Background()
+	w := NewBinary(EncodingMsgPack)
+	bad := func(v any) []byte {
+		data, err := EncodingMsgPack.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	tests := map[string]func() error{
+		"not a map": func() error {
+			_, err := w.DecodeRequest(ctx, bad([]any{1}))
+			return err
+		},
+		"malformed": func() error {
+			_, err := w.DecodeRequest(ctx, []byte{0xc1})
+			return err
+		},
+		"id type": func() error {
+			_, err := w.DecodeRequest(ctx, bad(map[string]any{"id": 1}))
+			return err
+		},
+		"arguments type": func() error {
+			_, err := w.DecodeRequest(ctx, bad(map[string]any{"arguments": "x"}))
+			return err
+		},
+		"content item": func() error {
+			_, err := w.DecodeResponse(ctx, bad(map[string]any{"content": []any{"x"}}))
+			return err
+		},
+		"content data": func() error {
+			_, err := w.DecodeResponse(ctx, bad(map[string]any{"content": []any{map[string]any{"data": 1}}}))
+			return err
+		},
+		"error code": func() error {
+			_, err := w.DecodeResponse(ctx, bad(map[string]any{"error": map[string]any{"code": "x"}}))
+			return err
+		},
+		"tools type": func() error {
+			_, err := w.DecodeToolPage(ctx, bad(map[string]any{"tools": map[string]any{}}))
+			return err
+		},
+	}
+	for name, decode := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := decode(); !errors.Is(err, ErrDecodeFailure) {
+				t.Errorf("error = %v, want ErrDecodeFailure", err)
+			}
+		})
+	}
+}
+
+func TestBinaryWire_Smaller(t *testing.T) {
+	ctx := context.Background()
+	resp := &Response{
+		ID:      "1",
+		Content: []Content{{Type: ContentTypeImage, MIMEType: "image/png", Data: make([]byte, 4096)}},
+	}
+	jsonData, _ := NewMCP().EncodeResponse(ctx, resp)
+	for _, enc := range []Encoding{EncodingCBOR, EncodingMsgPack} {
+		data, _ := NewBinary(enc).EncodeResponse(ctx, resp)
+		if len(data) >= len(jsonData)*4/5 {
+			t.Errorf("%s response is %d bytes, MCP JSON is %d", enc.Name(), len(data), len(jsonData))
+		}
+	}
+}
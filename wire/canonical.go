@@ -0,0 +1,251 @@
+package wire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Canonicalize rewrites a JSON document in the RFC 8785 JSON
+// Canonicalization Scheme (JCS): object members sorted by UTF-16 code
+// units, no insignificant whitespace, minimal string escaping and
+// ECMAScript number formatting.
+//
+// Duplicate object keys, numbers outside the IEEE 754 double range and
+// trailing data are rejected with ErrEncodeFailure.
+func Canonicalize(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	if err := writeCanonical(dec, &buf); err != nil {
+		return nil, fmt.Errorf("%w: canonicalize: %w", ErrEncodeFailure, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: canonicalize: trailing data", ErrEncodeFailure)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonical reads one JSON value from dec and writes its canonical form.
+func writeCanonical(dec *json.Decoder, buf *bytes.Buffer) error {
+	tok, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	switch v := tok.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case string:
+		writeCanonicalString(buf, v)
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return fmt.Errorf("number %s: %w", v, err)
+		}
+		buf.WriteString(formatES6Number(f))
+	case json.Delim:
+		if v == '[' {
+			return writeCanonicalArray(dec, buf)
+		}
+		return writeCanonicalObject(dec, buf)
+	}
+	return nil
+}
+
+func writeCanonicalArray(dec *json.Decoder, buf *bytes.Buffer) error {
+	buf.WriteByte('[')
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeCanonical(dec, buf); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	_, err := dec.Token()
+	return err
+}
+
+func writeCanonicalObject(dec *json.Decoder, buf *bytes.Buffer) error {
+	type member struct {
+		key   string
+		units []uint16
+		value []byte
+	}
+	var members []member
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		var value bytes.Buffer
+		if err := writeCanonical(dec, &value); err != nil {
+			return err
+		}
+		members = append(members, member{key, utf16.Encode([]rune(key)), value.Bytes()})
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	// Members sort by UTF-16 code units, not UTF-8 bytes.
+	slices.SortFunc(members, func(a, b member) int {
+		return slices.Compare(a.units, b.units)
+	})
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			if m.key == members[i-1].key {
+				return fmt.Errorf("duplicate key %q", m.key)
+			}
+			buf.WriteByte(',')
+		}
+		writeCanonicalString(buf, m.key)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xf])
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// formatES6Number formats f like ECMAScript Number.prototype.toString.
+func formatES6Number(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+	abs := math.Abs(f)
+	if abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(s, "e")
+	sign := exp[0]
+	exp = strings.TrimLeft(exp[1:], "0")
+	return mantissa + "e" + string(sign) + exp
+}
+
+// CanonicalWire wraps a Wire so that every encoded message is in RFC 8785
+// canonical form. Equal messages therefore encode to identical bytes,
+// which makes encoded requests suitable as hash or cache keys.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use if the wrapped Wire is.
+//   - Determinism: For any payload x produced by the wrapper,
+//     encoding the decoded value of x yields x again byte for byte.
+//   - Decoding: Delegated unchanged; any valid input is accepted.
+type CanonicalWire struct {
+	w Wire
+}
+
+// NewCanonical wraps w in canonical encoding mode.
+// Wrapping a CanonicalWire again returns it unchanged.
+func NewCanonical(w Wire) *CanonicalWire {
+	if c, ok := w.(*CanonicalWire); ok {
+		return c
+	}
+	return &CanonicalWire{w: w}
+}
+
+// Unwrap returns the wrapped Wire.
+func (c *CanonicalWire) Unwrap() Wire {
+	return c.w
+}
+
+// Name returns the wrapped protocol name.
+func (c *CanonicalWire) Name() string {
+	return c.w.Name()
+}
+
+// Version returns the wrapped protocol version.
+func (c *CanonicalWire) Version() string {
+	return c.w.Version()
+}
+
+// EncodeRequest encodes a request in canonical form.
+func (c *CanonicalWire) EncodeRequest(ctx context.Context, req *Request) ([]byte, error) {
+	return canonical(c.w.EncodeRequest(ctx, req))
+}
+
+// DecodeRequest decodes a request.
+func (c *CanonicalWire) DecodeRequest(ctx context.Context, data []byte) (*Request, error) {
+	return c.w.DecodeRequest(ctx, data)
+}
+
+// EncodeResponse encodes a response in canonical form.
+func (c *CanonicalWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, error) {
+	return canonical(c.w.EncodeResponse(ctx, resp))
+}
+
+// DecodeResponse decodes a response.
+func (c *CanonicalWire) DecodeResponse(ctx context.Context, data []byte) (*Response, error) {
+	return c.w.DecodeResponse(ctx, data)
+}
+
+// EncodeToolList encodes a tool list in canonical form.
+func (c *CanonicalWire) EncodeToolList(ctx context.Context, tools []Tool) ([]byte, error) {
+	return canonical(c.w.EncodeToolList(ctx, tools))
+}
+
+// DecodeToolList decodes a tool list.
+func (c *CanonicalWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+	return c.w.DecodeToolList(ctx, data)
+}
+
+// Capabilities returns the wrapped protocol capabilities.
+func (c *CanonicalWire) Capabilities() *Capabilities {
+	return c.w.Capabilities()
+}
+
+func canonical(data []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(data)
+}
@@ -0,0 +1,232 @@
+package wire
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"b":1,"a":[true,null,"x"]}`, `{"a":[true,null,"x"],"b":1}`},
+		{` { "a" : { "d" : 1 , "c" : 2 } } `, `{"a":{"c":2,"d":1}}`},
+		{`[1.0,1e21,1e-7,0.000001,-0,123456789012345680000,4.50,-1.5e300]`, `[1,1e+21,1e-7,0.000001,0,123456789012345680000,4.5,-1.5e+300]`},
+		{`"\u003c\u00e9\u001f\n\/"`, "\"<é\\u001f\\n/\""},
+		// U+E000 sorts before U+1F600 in UTF-8 but after it in UTF-16.
+		{"{\"\ue000\":2,\"\U0001F600\":1}", "{\"\U0001F600\":1,\"\ue000\":2}"},
+		// RFC 8785 section 3.2.3 sorting example.
+		{`{"\u20ac":"Euro Sign","\r":"Carriage Return","\ufb33":"Hebrew Letter Dalet With Dagesh","1":"One","\ud83d\ude00":"Emoji: Grinning Face","\u0080":"Control","\u00f6":"Latin Small Letter O With Diaeresis"}`,
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"\U0001F600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}"},
+	}
+	for _, tt := range tests {
+		got, err := Canonicalize([]byte(tt.in))
+		if err != nil {
+			t.Fatalf("Canonicalize(%s) error = %v", tt.in, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Canonicalize(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalize_Errors(t *testing.T) {
+	for _, in := range []string{
+		`{"a":1,"a":2}`,
+		`[1e400]`,
+		`{"a":1} {"b":2}`,
+		`{"a":`,
+		``,
+	} {
+		if _, err := Canonicalize([]byte(in)); !errors.Is(err, ErrEncodeFailure) {
+			t.Errorf("Canonicalize(%q) error = %v, want ErrEncodeFailure", in, err)
+		}
+	}
+}
+
+func TestNewCanonical(t *testing.T) {
+	c := NewCanonical(NewMCP())
+	if NewCanonical(c) != c {
+		t.Error("NewCanonical(CanonicalWire) wrapped twice")
+	}
+	if c.Name() != "mcp" || c.Version() != MCPVersion || c.Unwrap().Name() != "mcp" {
+		t.Errorf("CanonicalWire = %s %s, want delegated mcp", c.Name(), c.Version())
+	}
+	var _ Wire = c
+}
+
+// TestCanonicalWire_Idempotent checks that encode(decode(x)) == x for
+// payloads x produced by every canonical codec.
+func TestCanonicalWire_Idempotent(t *testing.T) {
+	ctx := context.Background()
+	codecs := []Wire{NewMCP(), NewA2A(), NewACP()}
+	for _, v := range MCPSupportedVersions() {
+		w, _ := NewMCPVersion(v)
+		codecs = append(codecs, w)
+	}
+
+	for _, inner := range codecs {
+		w := NewCanonical(inner)
+		t.Run(inner.Name()+"/"+inner.Version(), func(t *testing.T) {
+			r := rand.New(rand.NewPCG(1, 2))
+			for i := range 300 {
+				req := randRequest(r)
+				x, err := w.EncodeRequest(ctx, req)
+				if err != nil {
+					t.Fatalf("EncodeRequest(%d) error = %v", i, err)
+				}
+				decoded, err := w.DecodeRequest(ctx, x)
+				if err != nil {
+					t.Fatalf("DecodeRequest(%d) error = %v", i, err)
+				}
+				assertIdempotent(t, "request", x, func() ([]byte, error) { return w.EncodeRequest(ctx, decoded) })
+
+				resp := randResponse(r)
+				x, err = w.EncodeResponse(ctx, resp)
+				if err != nil {
+					t.Fatalf("EncodeResponse(%d) error = %v", i, err)
+				}
+				decodedResp, err := w.DecodeResponse(ctx, x)
+				if err != nil {
+					t.Fatalf("DecodeResponse(%d) error = %v", i, err)
+				}
+				assertIdempotent(t, "response", x, func() ([]byte, error) { return w.EncodeResponse(ctx, decodedResp) })
+
+				tools := randTools(r)
+				x, err = w.EncodeToolList(ctx, tools)
+				if err != nil {
+					t.Fatalf("EncodeToolList(%d) error = %v", i, err)
+				}
+				decodedTools, err := w.DecodeToolList(ctx, x)
+				if err != nil {
+					t.Fatalf("DecodeToolList(%d) error = %v", i, err)
+				}
+				assertIdempotent(t, "tool list", x, func() ([]byte, error) { return w.EncodeToolList(ctx, decodedTools) })
+			}
+		})
+	}
+}
+
+func assertIdempotent(t *testing.T, kind string, x []byte, encode func() ([]byte, error)) {
+	t.Helper()
+	y, err := encode()
+	if err != nil {
+		t.Fatalf("re-encode %s error = %v", kind, err)
+	}
+	if !bytes.Equal(x, y) {
+		t.Fatalf("%s not idempotent:\n x = %s\n y = %s", kind, x, y)
+	}
+	if c, _ := Canonicalize(x); !bytes.Equal(c, x) {
+		t.Fatalf("%s not canonical: %s", kind, x)
+	}
+}
+
+var randKeys = []string{"a", "b", "query", "<tag>", "a&b", "é", "😀", "\ue000", "line\nbreak", "Z", "1"}
+
+func randString(r *rand.Rand) string {
+	return randKeys[r.IntN(len(randKeys))] + fmt.Sprint(r.IntN(100))
+}
+
+func randValue(r *rand.Rand, depth int) any {
+	n := 6
+	if depth > 2 {
+		n = 4
+	}
+	switch r.IntN(n) {
+	case 0:
+		return nil
+	case 1:
+		return r.IntN(2) == 0
+	case 2:
+		return randString(r)
+	case 3:
+		// Mix integers, fractions and extreme magnitudes.
+		switch r.IntN(3) {
+		case 0:
+			return float64(r.Int64N(1<<53) - 1<<52)
+		case 1:
+			return r.NormFloat64()
+		default:
+			return r.NormFloat64() * 1e25
+		}
+	case 4:
+		s := make([]any, r.IntN(4))
+		for i := range s {
+			s[i] = randValue(r, depth+1)
+		}
+		return s
+	default:
+		return randMap(r, depth+1)
+	}
+}
+
+func randMap(r *rand.Rand, depth int) map[string]any {
+	m := make(map[string]any)
+	for range r.IntN(4) + 1 {
+		m[randString(r)] = randValue(r, depth)
+	}
+	return m
+}
+
+func randRequest(r *rand.Rand) *Request {
+	req := &Request{
+		ID:     randString(r),
+		Method: "tools/call",
+		ToolID: randString(r),
+	}
+	if r.IntN(4) > 0 {
+		req.Arguments = randMap(r, 0)
+	}
+	if r.IntN(2) == 0 {
+		req.Meta = randMap(r, 0)
+	}
+	return req
+}
+
+func randResponse(r *rand.Rand) *Response {
+	resp := &Response{ID: randString(r)}
+	if r.IntN(6) == 0 {
+		resp.IsError = true
+		resp.Error = &Error{Code: -32000 - r.IntN(100), Message: randString(r)}
+		if r.IntN(2) == 0 {
+			resp.Error.Data = randMap(r, 0)
+		}
+		return resp
+	}
+	for range r.IntN(4) {
+		switch r.IntN(3) {
+		case 0:
+			resp.Content = append(resp.Content, Content{Type: ContentTypeText, Text: randString(r)})
+		case 1:
+			resp.Content = append(resp.Content, Content{Type: ContentTypeImage, MIMEType: "image/png", Data: []byte(randString(r))})
+		default:
+			resp.Content = append(resp.Content, Content{Type: ContentTypeResource, URI: "file:///" + fmt.Sprint(r.IntN(100)), MIMEType: "text/plain"})
+		}
+	}
+	if r.IntN(3) == 0 {
+		resp.StructuredContent = randMap(r, 0)
+	}
+	if r.IntN(2) == 0 {
+		resp.Meta = randMap(r, 0)
+	}
+	return resp
+}
+
+func randTools(r *rand.Rand) []Tool {
+	tools := make([]Tool, r.IntN(3))
+	for i := range tools {
+		tools[i] = Tool{Name: randString(r), Description: randString(r)}
+		if r.IntN(2) == 0 {
+			tools[i].InputSchema = randMap(r, 0)
+		}
+		if r.IntN(3) == 0 {
+			tools[i].OutputSchema = randMap(r, 0)
+		}
+	}
+	return tools
+}
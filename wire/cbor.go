@@ -0,0 +1,333 @@
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// CBOR major types (RFC 8949 section 3.1).
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborArray  = 4
+	cborMap    = 5
+	cborTag    = 6
+	cborSimple = 7
+)
+
+// cborBreak ends an indefinite-length item.
+const cborBreak = 0xff
+
+var errCBORBreak = errors.New("unexpected break")
+
+// cborEncoding encodes values in CBOR's preferred serialization: the
+// shortest argument length, integers for integral numbers and float32
+// where it is exact.
+//
+// Decoding accepts any well-formed CBOR whose map keys are text strings,
+// including indefinite-length items and half-precision floats. Tags are
+// ignored in favour of their content, and undefined decodes as nil.
+type cborEncoding struct{}
+
+func (cborEncoding) Name() string        { return "cbor" }
+func (cborEncoding) ContentType() string { return MediaTypeCBOR }
+
+func (cborEncoding) Marshal(v any) ([]byte, error) {
+	var w cborWriter
+	if err := writeValue(&w, v, 0); err != nil {
+		return nil, fmt.Errorf("%w: cbor: %w", ErrEncodeFailure, err)
+	}
+	return w.buf, nil
+}
+
+func (cborEncoding) Unmarshal(data []byte) (any, error) {
+	r := &cborReader{data: data}
+	v, err := r.value(0)
+	if err == nil && r.off != len(data) {
+		err = errors.New("trailing data")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: cbor: %w", ErrDecodeFailure, malformedError{err})
+	}
+	return v, nil
+}
+
+type cborWriter struct {
+	buf []byte
+}
+
+// head writes an initial byte and argument in the shortest form.
+func (w *cborWriter) head(major byte, arg uint64) {
+	m := major << 5
+	switch {
+	case arg < 24:
+		w.buf = append(w.buf, m|byte(arg))
+	case arg <= math.MaxUint8:
+		w.buf = append(w.buf, m|24, byte(arg))
+	case arg <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, m|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, m|26), uint32(arg))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, m|27), arg)
+	}
+}
+
+func (w *cborWriter) writeNil() { w.buf = append(w.buf, cborSimple<<5|22) }
+
+func (w *cborWriter) writeBool(b bool) {
+	if b {
+		w.buf = append(w.buf, cborSimple<<5|21)
+		return
+	}
+	w.buf = append(w.buf, cborSimple<<5|20)
+}
+
+func (w *cborWriter) writeInt(n int64) {
+	if n < 0 {
+		w.head(cborNegInt, uint64(-1-n))
+		return
+	}
+	w.head(cborUint, uint64(n))
+}
+
+func (w *cborWriter) writeUint(n uint64) { w.head(cborUint, n) }
+
+func (w *cborWriter) writeFloat(f float64) {
+	if f32 := float32(f); float64(f32) == f {
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, cborSimple<<5|26), math.Float32bits(f32))
+		return
+	}
+	w.buf = binary.BigEndian.AppendUint64(append(w.buf, cborSimple<<5|27), math.Float64bits(f))
+}
+
+func (w *cborWriter) writeString(s string) {
+	w.head(cborText, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *cborWriter) writeBytes(b []byte) {
+	w.head(cborBytes, uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *cborWriter) writeArrayHeader(n int) { w.head(cborArray, uint64(n)) }
+func (w *cborWriter) writeMapHeader(n int)   { w.head(cborMap, uint64(n)) }
+
+type cborReader struct {
+	data []byte
+	off  int
+}
+
+func (r *cborReader) next(n uint64) ([]byte, error) {
+	if n > uint64(len(r.data)-r.off) {
+		return nil, fmt.Errorf("unexpected end of data at offset %d", r.off)
+	}
+	b := r.data[r.off : r.off+int(n)]
+	r.off += int(n)
+	return b, nil
+}
+
+// head reads an initial byte and its argument. For additional
+// information 31, an indefinite length or break, arg is zero.
+func (r *cborReader) head() (major byte, info byte, arg uint64, err error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info = b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		n := uint64(1) << (info - 24)
+		p, err := r.next(n)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, c := range p {
+			arg = arg<<8 | uint64(c)
+		}
+		return major, info, arg, nil
+	case info == 31:
+		return major, info, 0, nil
+	}
+	return 0, 0, 0, fmt.Errorf("reserved additional information %d", info)
+}
+
+func (r *cborReader) value(depth int) (any, error) {
+	if depth > maxEncodingDepth {
+		return nil, fmt.Errorf("nesting exceeds %d levels", maxEncodingDepth)
+	}
+	major, info, arg, err := r.head()
+	if err != nil {
+		return nil, err
+	}
+	indefinite := info == 31
+	if indefinite && (major == cborUint || major == cborNegInt || major == cborTag) {
+		return nil, fmt.Errorf("indefinite length for major type %d", major)
+	}
+
+	switch major {
+	case cborUint:
+		return float64(arg), nil
+	case cborNegInt:
+		return -1 - float64(arg), nil
+	case cborBytes, cborText:
+		b, err := r.str(major, arg, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		if major == cborBytes {
+			return b, nil
+		}
+		if !utf8.Valid(b) {
+			return nil, errors.New("invalid UTF-8 in text string")
+		}
+		return string(b), nil
+	case cborArray:
+		return r.array(arg, indefinite, depth)
+	case cborMap:
+		return r.object(arg, indefinite, depth)
+	case cborTag:
+		v, err := r.value(depth + 1)
+		return v, r.noBreak(err)
+	}
+
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		return halfToFloat(uint16(arg)), nil
+	case 26:
+		return float64(math.Float32frombits(uint32(arg))), nil
+	case 27:
+		return math.Float64frombits(arg), nil
+	case 31:
+		return nil, errCBORBreak
+	}
+	return nil, fmt.Errorf("unsupported simple value %d", arg)
+}
+
+// str reads a byte or text string, joining indefinite-length chunks.
+func (r *cborReader) str(major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		b, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	}
+	var out []byte
+	for {
+		if r.off < len(r.data) && r.data[r.off] == cborBreak {
+			r.off++
+			return out, nil
+		}
+		m, info, n, err := r.head()
+		if err != nil {
+			return nil, err
+		}
+		if m != major || info == 31 {
+			return nil, errors.New("invalid chunk in indefinite-length string")
+		}
+		b, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b...)
+	}
+}
+
+func (r *cborReader) array(n uint64, indefinite bool, depth int) ([]any, error) {
+	if indefinite {
+		out := []any{}
+		for {
+			v, err := r.value(depth + 1)
+			if err == errCBORBreak {
+				return out, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+	}
+	// Every item takes at least one byte.
+	if n > uint64(len(r.data)-r.off) {
+		return nil, fmt.Errorf("array length %d exceeds data", n)
+	}
+	out := make([]any, n)
+	for i := range out {
+		v, err := r.value(depth + 1)
+		if err != nil {
+			return nil, r.noBreak(err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func (r *cborReader) object(n uint64, indefinite bool, depth int) (map[string]any, error) {
+	if !indefinite && n > uint64(len(r.data)-r.off)/2 {
+		return nil, fmt.Errorf("map length %d exceeds data", n)
+	}
+	out := make(map[string]any, min(n, 64))
+	for i := uint64(0); indefinite || i < n; i++ {
+		k, err := r.value(depth + 1)
+		if indefinite && err == errCBORBreak {
+			return out, nil
+		}
+		if err != nil {
+			return nil, r.noBreak(err)
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key %v is not a text string", k)
+		}
+		v, err := r.value(depth + 1)
+		if err != nil {
+			return nil, r.noBreak(err)
+		}
+		out[key] = v
+	}
+	return out, nil
+}
+
+// noBreak reports a break outside an indefinite-length item as an error
+// in its own right.
+func (r *cborReader) noBreak(err error) error {
+	if err == errCBORBreak {
+		return fmt.Errorf("%w at offset %d", errCBORBreak, r.off-1)
+	}
+	return err
+}
+
+// halfToFloat converts an IEEE 754 half-precision float.
+func halfToFloat(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(mant+1024, exp-25)
+}
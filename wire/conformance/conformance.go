@@ -0,0 +1,502 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/toolprotocol/wire"
+)
+
+// Kind classifies the message a fixture holds.
+type Kind string
+
+const (
+	// KindRequest is a request a client sends.
+	KindRequest Kind = "request"
+
+	// KindResponse is a successful response.
+	KindResponse Kind = "response"
+
+	// KindError is a protocol error or a tool execution error.
+	KindError Kind = "error"
+
+	// KindNotification is a message without a reply: progress,
+	// cancellation, session updates and streamed events.
+	KindNotification Kind = "notification"
+
+	// KindToolList is a tool, skill or agent list.
+	KindToolList Kind = "tool_list"
+)
+
+// Sentinel errors for conformance checks.
+var (
+	// ErrInvalidFixture is returned when a fixture file is malformed.
+	ErrInvalidFixture = errors.New("conformance: invalid fixture")
+
+	// ErrMismatch is returned when a codec's output differs from a
+	// fixture's expectation.
+	ErrMismatch = errors.New("conformance: mismatch")
+
+	// ErrUnsupported is returned when a fixture needs an optional
+	// interface, such as wire.NotificationCodec, that the codec does not
+	// implement.
+	ErrUnsupported = errors.New("conformance: unsupported by codec")
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Fixture is a golden wire message and the model a codec must decode it
+// to. Exactly one of Request, Response, ToolPage, Progress and
+// Cancellation is set; it selects the Decode method under test.
+//
+// Fixture files live at <protocol>/<version>/<name>.json. Version is the
+// protocol revision that introduced the message shape, so a fixture
+// applies to codecs of that version and later. Tool lists hold the list
+// result object, as EncodeToolList produces, rather than a JSON-RPC
+// envelope.
+type Fixture struct {
+	// Protocol is the wire protocol name, matching wire.Wire.Name.
+	Protocol string `json:"-"`
+
+	// Version is the protocol revision the fixture belongs to.
+	Version string `json:"-"`
+
+	// Name is the file name without its extension.
+	Name string `json:"-"`
+
+	// Kind classifies the message.
+	Kind Kind `json:"kind"`
+
+	// Description says what the message exercises.
+	Description string `json:"description"`
+
+	// Source names the specification section or implementation the
+	// message was taken from.
+	Source string `json:"source"`
+
+	// Exact reports that encoding the expectation with a codec of the
+	// same version reproduces Message, up to key order and whitespace.
+	Exact bool `json:"exact,omitempty"`
+
+	// Lossy reports that the model cannot represent the message, so
+	// re-encoding it changes it; the round-trip check is skipped.
+	Lossy bool `json:"lossy,omitempty"`
+
+	// Message is the raw wire message.
+	Message json.RawMessage `json:"message"`
+
+	// Request is the expected result of DecodeRequest.
+	Request *wire.Request `json:"request,omitempty"`
+
+	// Response is the expected result of DecodeResponse.
+	Response *wire.Response `json:"response,omitempty"`
+
+	// ToolPage is the expected result of DecodeToolPage. Codecs without
+	// wire.PageCodec are checked against its items with DecodeToolList.
+	ToolPage *wire.Page[wire.Tool] `json:"toolPage,omitempty"`
+
+	// Progress is the expected result of DecodeProgress.
+	Progress *wire.Progress `json:"progress,omitempty"`
+
+	// Cancellation is the expected result of DecodeCancellation.
+	Cancellation *wire.Cancellation `json:"cancellation,omitempty"`
+}
+
+// Path returns the fixture's path within its corpus.
+func (f *Fixture) Path() string {
+	return path.Join(f.Protocol, f.Version, f.Name+".json")
+}
+
+// AppliesTo reports whether w should decode the fixture: the protocols
+// match and w's version is at least the fixture's.
+func (f *Fixture) AppliesTo(w wire.Wire) bool {
+	return f.Protocol == w.Name() && compareVersions(f.Version, w.Version()) <= 0
+}
+
+// Fixtures returns the embedded fixtures for protocol ("mcp", "a2a" or
+// "acp"), or every fixture when protocol is empty, sorted by path.
+func Fixtures(protocol string) ([]Fixture, error) {
+	all, err := Load(fixtures, "fixtures")
+	if err != nil {
+		return nil, err
+	}
+	if protocol == "" {
+		return all, nil
+	}
+	return slices.DeleteFunc(all, func(f Fixture) bool { return f.Protocol != protocol }), nil
+}
+
+// Load reads the fixtures under dir in fsys, laid out as
+// <protocol>/<version>/<name>.json, so projects can check codecs against
+// their own captures. Fixtures are sorted by path.
+func Load(fsys fs.FS, dir string) ([]Fixture, error) {
+	var out []Fixture
+	err := fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".json" {
+			return err
+		}
+		rel := strings.TrimPrefix(p, dir+"/")
+		parts := strings.Split(rel, "/")
+		if len(parts) != 3 {
+			return fmt.Errorf("%w: %s: want <protocol>/<version>/<name>.json", ErrInvalidFixture, rel)
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		f := Fixture{
+			Protocol: parts[0],
+			Version:  parts[1],
+			Name:     strings.TrimSuffix(parts[2], ".json"),
+		}
+		if err := json.Unmarshal(data, &f); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidFixture, rel, err)
+		}
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidFixture, rel, err)
+		}
+		out = append(out, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(out, func(a, b Fixture) int { return strings.Compare(a.Path(), b.Path()) })
+	return out, nil
+}
+
+func (f *Fixture) validate() error {
+	switch f.Kind {
+	case KindRequest, KindResponse, KindError, KindNotification, KindToolList:
+	default:
+		return fmt.Errorf("unknown kind %q", f.Kind)
+	}
+	if len(f.Message) == 0 {
+		return errors.New("missing message")
+	}
+	n := 0
+	for _, set := range []bool{f.Request != nil, f.Response != nil, f.ToolPage != nil, f.Progress != nil, f.Cancellation != nil} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("want exactly one expectation, have %d", n)
+	}
+	return nil
+}
+
+// Run checks w against every fixture that applies to it, one subtest per
+// fixture named by its path. Fixtures needing an interface w lacks are
+// skipped.
+func Run(t *testing.T, w wire.Wire, fixtures []Fixture) {
+	t.Helper()
+	ran := 0
+	for _, f := range fixtures {
+		if !f.AppliesTo(w) {
+			continue
+		}
+		ran++
+		t.Run(strings.TrimSuffix(f.Path(), ".json"), func(t *testing.T) {
+			err := Check(t.Context(), w, f)
+			if errors.Is(err, ErrUnsupported) {
+				t.Skip(err)
+			}
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	if ran == 0 {
+		t.Logf("no fixtures apply to %s %s", w.Name(), w.Version())
+	}
+}
+
+// Check checks w against one fixture:
+//
+//   - Decode: the message decodes to the expectation.
+//   - Equivalence: for wire.RawRequestDecoder, the raw path yields the
+//     same request as DecodeRequest.
+//   - Round trip: unless the fixture is Lossy, re-encoding the decoded
+//     value and decoding the result yields the same value.
+//   - Exact: for Exact fixtures of w's version, encoding the expectation
+//     reproduces the message.
+//   - Schema: if w exports message schemas ([wire.ExportSchemas]),
+//     encoding the expectation yields a message valid against the schema
+//     for the fixture's kind. Error fixtures carrying a protocol error
+//     are also checked against the error schema.
+//
+// Values are compared as JSON, so nil and empty collections are equal.
+// Failures are joined and wrap ErrMismatch; errors from w are wrapped
+// with the step that failed.
+func Check(ctx context.Context, w wire.Wire, f Fixture) error {
+	c := checker{ctx: ctx, w: w, f: f}
+	if err := c.loadSchemas(); err != nil {
+		return err
+	}
+	switch {
+	case f.Request != nil:
+		c.request()
+	case f.Response != nil:
+		c.response()
+	case f.ToolPage != nil:
+		c.toolPage()
+	case f.Progress != nil:
+		c.progress()
+	case f.Cancellation != nil:
+		c.cancellation()
+	}
+	return errors.Join(c.errs...)
+}
+
+type checker struct {
+	ctx     context.Context
+	w       wire.Wire
+	f       Fixture
+	schemas []*wire.Schema
+	errs    []error
+}
+
+// loadSchemas compiles the schemas the fixture's encoding must satisfy.
+// Wires without schemas skip the schema check.
+func (c *checker) loadSchemas() error {
+	exported, err := wire.ExportSchemas(c.w)
+	if errors.Is(err, wire.ErrUnsupportedFeature) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("export schemas: %w", err)
+	}
+	var kinds []map[string]any
+	switch {
+	case c.f.Request != nil:
+		kinds = append(kinds, exported.Request)
+	case c.f.Response != nil:
+		kinds = append(kinds, exported.Response)
+		if c.f.Response.IsError && c.f.Response.Error != nil {
+			kinds = append(kinds, exported.Error)
+		}
+	case c.f.ToolPage != nil:
+		kinds = append(kinds, exported.ToolList)
+	}
+	for _, k := range kinds {
+		s, err := wire.CompileSchema(k)
+		if err != nil {
+			return fmt.Errorf("export schemas: %w", err)
+		}
+		c.schemas = append(c.schemas, s)
+	}
+	return nil
+}
+
+func (c *checker) fail(step string, err error) {
+	c.errs = append(c.errs, fmt.Errorf("%s: %w", step, err))
+}
+
+// same records a mismatch between got and want.
+func (c *checker) same(step string, got, want any) {
+	g, w := normalize(got), normalize(want)
+	if !reflect.DeepEqual(g, w) {
+		gj, _ := json.Marshal(g)
+		wj, _ := json.Marshal(w)
+		c.fail(step, fmt.Errorf("%w:\n got: %s\nwant: %s", ErrMismatch, gj, wj))
+	}
+}
+
+// verify runs the decode, round-trip and exact checks for one message
+// type and returns the decoded value.
+func verify[T any](c *checker, what string, want T, encode func(context.Context, T) ([]byte, error), decode func(context.Context, []byte) (T, error)) (T, bool) {
+	got, err := decode(c.ctx, c.f.Message)
+	if err != nil {
+		c.fail("decode "+what, err)
+		return got, false
+	}
+	c.same("decode "+what, got, want)
+
+	if !c.f.Lossy {
+		if data, err := encode(c.ctx, got); err != nil {
+			c.fail("round trip encode", err)
+		} else if again, err := decode(c.ctx, data); err != nil {
+			c.fail("round trip decode", err)
+		} else {
+			c.same("round trip", again, got)
+		}
+	}
+
+	if c.f.Exact && compareVersions(c.f.Version, c.w.Version()) == 0 {
+		if data, err := encode(c.ctx, want); err != nil {
+			c.fail("exact encode", err)
+		} else if !jsonEqual(data, c.f.Message) {
+			c.fail("exact encode", fmt.Errorf("%w:\n got: %s\nwant: %s", ErrMismatch, data, compact(c.f.Message)))
+		}
+	}
+
+	if len(c.schemas) > 0 {
+		if data, err := encode(c.ctx, want); err != nil {
+			c.fail("schema encode", err)
+		} else {
+			c.validate(data)
+		}
+	}
+	return got, true
+}
+
+// validate checks an encoded message against the fixture's schemas.
+func (c *checker) validate(data []byte) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		c.fail("schema", err)
+		return
+	}
+	for _, s := range c.schemas {
+		if err := s.Validate(v); err != nil {
+			c.fail("schema", fmt.Errorf("%w: %s: %w", ErrMismatch, data, err))
+		}
+	}
+}
+
+func (c *checker) request() {
+	got, ok := verify(c, "request", c.f.Request, c.w.EncodeRequest, c.w.DecodeRequest)
+	d, raw := c.w.(wire.RawRequestDecoder)
+	if !ok || !raw {
+		return
+	}
+	if r, err := d.DecodeRawRequest(c.ctx, c.f.Message); err != nil {
+		c.fail("decode raw request", err)
+	} else if req, err := r.Request(); err != nil {
+		c.fail("decode raw request", err)
+	} else {
+		c.same("decode raw request", req, got)
+	}
+}
+
+func (c *checker) response() {
+	verify(c, "response", c.f.Response, c.w.EncodeResponse, c.w.DecodeResponse)
+}
+
+func (c *checker) toolPage() {
+	if pc, ok := c.w.(wire.PageCodec); ok {
+		verify(c, "tool page", c.f.ToolPage, pc.EncodeToolPage, pc.DecodeToolPage)
+		return
+	}
+	verify(c, "tool list", c.f.ToolPage.Items, c.w.EncodeToolList, c.w.DecodeToolList)
+}
+
+func (c *checker) notifications() (wire.NotificationCodec, bool) {
+	nc, ok := c.w.(wire.NotificationCodec)
+	if !ok {
+		c.errs = append(c.errs, fmt.Errorf("%w: %s has no notification codec", ErrUnsupported, c.w.Name()))
+	}
+	return nc, ok
+}
+
+func (c *checker) progress() {
+	if nc, ok := c.notifications(); ok {
+		verify(c, "progress", c.f.Progress, nc.EncodeProgress, nc.DecodeProgress)
+	}
+}
+
+func (c *checker) cancellation() {
+	if nc, ok := c.notifications(); ok {
+		verify(c, "cancellation", c.f.Cancellation, nc.EncodeCancellation, nc.DecodeCancellation)
+	}
+}
+
+// jsonEqual reports whether a and b hold the same JSON value.
+func jsonEqual(a, b []byte) bool {
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// normalize converts v to its generic JSON form with nulls and empty
+// arrays and objects removed.
+func normalize(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err.Error()
+	}
+	return prune(out)
+}
+
+func prune(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, e := range x {
+			if e = prune(e); e == nil {
+				delete(x, k)
+				continue
+			}
+			x[k] = e
+		}
+		if len(x) == 0 {
+			return nil
+		}
+	case []any:
+		for i, e := range x {
+			x[i] = prune(e)
+		}
+		if len(x) == 0 {
+			return nil
+		}
+	}
+	return v
+}
+
+func compact(data []byte) []byte {
+	var buf bytes.Buffer
+	if json.Compact(&buf, data) != nil {
+		return data
+	}
+	return buf.Bytes()
+}
+
+// compareVersions orders versions by their numeric components, split on
+// "." and "-", with missing components counting as zero. This orders
+// MCP's dated revisions ("2025-06-18") as well as A2A and ACP's dotted
+// versions, where "0.3" equals "0.3.0".
+func compareVersions(a, b string) int {
+	split := func(s string) []string {
+		return strings.FieldsFunc(s, func(r rune) bool { return r == '.' || r == '-' })
+	}
+	as, bs := split(a), split(b)
+	for i := range max(len(as), len(bs)) {
+		ac, bc := "0", "0"
+		if i < len(as) {
+			ac = as[i]
+		}
+		if i < len(bs) {
+			bc = bs[i]
+		}
+		an, aerr := strconv.Atoi(ac)
+		bn, berr := strconv.Atoi(bc)
+		if aerr != nil || berr != nil {
+			if c := strings.Compare(ac, bc); c != 0 {
+				return c
+			}
+			continue
+		}
+		if an != bn {
+			return an - bn
+		}
+	}
+	return 0
+}
@@ -0,0 +1,254 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jonwraymond/toolprotocol/wire"
+)
+
+// codecs returns every codec with embedded fixtures, including each
+// pinned MCP revision.
+func codecs(t *testing.T) []wire.Wire {
+	t.Helper()
+	out := []wire.Wire{wire.NewA2A(), wire.NewACP()}
+	for _, v := range wire.MCPSupportedVersions() {
+		w, err := wire.NewMCPVersion(v)
+		if err != nil {
+			t.Fatalf("NewMCPVersion(%q) error = %v", v, err)
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+func TestConformance(t *testing.T) {
+	all, err := Fixtures("")
+	if err != nil {
+		t.Fatalf("Fixtures() error = %v", err)
+	}
+	for _, w := range codecs(t) {
+		t.Run(w.Name()+"@"+w.Version(), func(t *testing.T) {
+			Run(t, w, all)
+		})
+	}
+}
+
+func TestConformance_Wrapped(t *testing.T) {
+	all, err := Fixtures("")
+	if err != nil {
+		t.Fatalf("Fixtures() error = %v", err)
+	}
+	// CanonicalWire implements only Wire, so tool lists go through
+	// DecodeToolList and notifications are skipped.
+	for _, w := range []wire.Wire{wire.NewCanonical(wire.NewMCP()), wire.NewCanonical(wire.NewACP())} {
+		t.Run(w.Name(), func(t *testing.T) {
+			Run(t, w, all)
+		})
+	}
+}
+
+func TestFixtures_Coverage(t *testing.T) {
+	for _, protocol := range []string{"mcp", "a2a", "acp"} {
+		fixtures, err := Fixtures(protocol)
+		if err != nil {
+			t.Fatalf("Fixtures(%q) error = %v", protocol, err)
+		}
+		kinds := map[Kind]bool{}
+		for _, f := range fixtures {
+			if f.Protocol != protocol {
+				t.Errorf("Fixtures(%q) returned %s", protocol, f.Path())
+			}
+			if f.Description == "" || f.Source == "" {
+				t.Errorf("%s: missing description or source", f.Path())
+			}
+			kinds[f.Kind] = true
+		}
+		for _, k := range []Kind{KindRequest, KindResponse, KindError, KindNotification, KindToolList} {
+			if !kinds[k] {
+				t.Errorf("%s: no %s fixtures", protocol, k)
+			}
+		}
+	}
+}
+
+func TestFixtures_MCPRevisions(t *testing.T) {
+	fixtures, err := Fixtures("mcp")
+	if err != nil {
+		t.Fatalf("Fixtures() error = %v", err)
+	}
+	versions := map[string]bool{}
+	for _, f := range fixtures {
+		versions[f.Version] = true
+	}
+	for _, v := range wire.MCPSupportedVersions() {
+		if !versions[v] {
+			t.Errorf("no fixtures for MCP %s", v)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	valid := `{"kind":"request","description":"d","source":"s","message":{},"request":{}}`
+	tests := []struct {
+		name string
+		path string
+		data string
+	}{
+		{"layout", "fixtures/mcp/call.json", valid},
+		{"json", "fixtures/mcp/1/call.json", `{`},
+		{"kind", "fixtures/mcp/1/call.json", `{"kind":"event","message":{},"request":{}}`},
+		{"message", "fixtures/mcp/1/call.json", `{"kind":"request","request":{}}`},
+		{"no expectation", "fixtures/mcp/1/call.json", `{"kind":"request","message":{}}`},
+		{"two expectations", "fixtures/mcp/1/call.json", `{"kind":"request","message":{},"request":{},"response":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{tt.path: {Data: []byte(tt.data)}}
+			if _, err := Load(fsys, "fixtures"); !errors.Is(err, ErrInvalidFixture) {
+				t.Errorf("Load() error = %v, want ErrInvalidFixture", err)
+			}
+		})
+	}
+
+	fsys := fstest.MapFS{
+		"fixtures/mcp/2025-06-18/b.json": {Data: []byte(valid)},
+		"fixtures/mcp/2024-11-05/a.json": {Data: []byte(valid)},
+		"fixtures/README.md":             {Data: []byte("not a fixture")},
+	}
+	got, err := Load(fsys, "fixtures")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Path() != "mcp/2024-11-05/a.json" || got[1].Version != "2025-06-18" {
+		t.Errorf("Load() = %+v, want two fixtures sorted by path", got)
+	}
+}
+
+// brokenWire drops tool IDs, as a codec with a decoding bug would.
+type brokenWire struct {
+	wire.Wire
+}
+
+func (b brokenWire) DecodeRequest(ctx context.Context, data []byte) (*wire.Request, error) {
+	req, err := b.Wire.DecodeRequest(ctx, data)
+	if err == nil {
+		req.ToolID = ""
+	}
+	return req, err
+}
+
+func TestCheck_Mismatch(t *testing.T) {
+	fixtures, err := Fixtures("mcp")
+	if err != nil {
+		t.Fatalf("Fixtures() error = %v", err)
+	}
+	i := slices.IndexFunc(fixtures, func(f Fixture) bool { return f.Path() == "mcp/2024-11-05/tools_call.json" })
+	if i < 0 {
+		t.Fatal("fixture mcp/2024-11-05/tools_call.json not found")
+	}
+	err = Check(context.Background(), brokenWire{wire.NewMCP()}, fixtures[i])
+	if !errors.Is(err, ErrMismatch) {
+		t.Fatalf("Check() error = %v, want ErrMismatch", err)
+	}
+	if !strings.Contains(err.Error(), "decode request") || !strings.Contains(err.Error(), "get_weather") {
+		t.Errorf("Check() error = %v, want the failing step and values", err)
+	}
+}
+
+func TestCheck_Unsupported(t *testing.T) {
+	fixtures, err := Fixtures("mcp")
+	if err != nil {
+		t.Fatalf("Fixtures() error = %v", err)
+	}
+	i := slices.IndexFunc(fixtures, func(f Fixture) bool { return f.Progress != nil })
+	err = Check(context.Background(), wire.NewCanonical(wire.NewMCP()), fixtures[i])
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Check() error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestCheck_Exact(t *testing.T) {
+	f := Fixture{
+		Protocol: "mcp",
+		Version:  wire.MCPVersion,
+		Name:     "cancel",
+		Kind:     KindNotification,
+		Exact:    true,
+		// The model has no place for _meta, so it is not re-encoded.
+		Message:      json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"_meta":{"k":1}}}`),
+		Cancellation: &wire.Cancellation{RequestID: wire.IntID(7)},
+	}
+	err := Check(context.Background(), wire.NewMCP(), f)
+	if !errors.Is(err, ErrMismatch) || !strings.Contains(err.Error(), "exact encode") {
+		t.Errorf("Check() error = %v, want exact encode mismatch", err)
+	}
+
+	f.Exact = false
+	if err := Check(context.Background(), wire.NewMCP(), f); err != nil {
+		t.Errorf("Check() error = %v, want nil without Exact", err)
+	}
+}
+
+// extraFieldWire adds a member its exported schemas do not allow.
+type extraFieldWire struct {
+	*wire.MCPWire
+}
+
+func (x extraFieldWire) EncodeRequest(ctx context.Context, req *wire.Request) ([]byte, error) {
+	data, err := x.MCPWire.EncodeRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return append(data[:len(data)-1], `,"extra":true}`...), nil
+}
+
+func TestCheck_Schema(t *testing.T) {
+	fixtures, err := Fixtures("mcp")
+	if err != nil {
+		t.Fatalf("Fixtures() error = %v", err)
+	}
+	i := slices.IndexFunc(fixtures, func(f Fixture) bool { return f.Path() == "mcp/2024-11-05/tools_call.json" })
+	if i < 0 {
+		t.Fatal("fixture mcp/2024-11-05/tools_call.json not found")
+	}
+	f := fixtures[i]
+	f.Exact = false
+	f.Lossy = true
+	err = Check(context.Background(), extraFieldWire{wire.NewMCP()}, f)
+	if !errors.Is(err, ErrMismatch) || !strings.Contains(err.Error(), "schema") || !strings.Contains(err.Error(), "extra") {
+		t.Errorf("Check() error = %v, want schema mismatch naming the extra member", err)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2024-11-05", "2025-03-26", -1},
+		{"2025-11-25", "2025-06-18", 1},
+		{"2025-06-18", "2025-06-18", 0},
+		{"0.2", "0.3.0", -1},
+		{"0.3", "0.3.0", 0},
+		{"1", "1.0.0", 0},
+		{"0.10", "0.9", 1},
+		{"1.0.0-rc", "1.0.0-rc", 0},
+	}
+	for _, tt := range tests {
+		got := compareVersions(tt.a, tt.b)
+		if got < 0 {
+			got = -1
+		} else if got > 0 {
+			got = 1
+		}
+		if got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
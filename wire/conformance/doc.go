@@ -0,0 +1,55 @@
+// Package conformance checks wire codecs against a corpus of golden
+// messages taken from the MCP, A2A and Agent Client Protocol
+// specifications.
+//
+// Each fixture pairs a raw wire message with the wire model it must
+// decode to. The corpus covers requests, responses, protocol and tool
+// errors, notifications and tool lists for every protocol revision the
+// wire package speaks, and is embedded so that any [wire.Wire]
+// implementation, including wrappers and third-party codecs, can be
+// checked from its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//	    fixtures, err := conformance.Fixtures("mcp")
+//	    if err != nil {
+//	        t.Fatal(err)
+//	    }
+//	    conformance.Run(t, mycodec.New(), fixtures)
+//	}
+//
+// # Fixtures
+//
+// Fixtures live at <protocol>/<version>/<name>.json, where version is
+// the revision that introduced the message shape. A fixture applies to
+// codecs of the same protocol whose version is at least the fixture's
+// ([Fixture.AppliesTo]), so a 2025-11-25 MCP codec is also checked
+// against 2024-11-05 messages. [Load] reads a corpus in the same layout
+// from any fs.FS, for projects with captures of their own.
+//
+// A fixture file holds a description, the specification section it was
+// taken from, its [Kind], the message, and one expectation: "request",
+// "response", "toolPage", "progress" or "cancellation", in the JSON form
+// of the matching wire type. The expectation selects the Decode method
+// under test.
+//
+// # Checks
+//
+// [Check] decodes the message and compares the result with the
+// expectation, confirms that the raw request path agrees with
+// DecodeRequest, and round-trips the decoded value through the encoder.
+// Fixtures marked exact must also be reproduced byte-for-byte, up to key
+// order, by encoding the expectation with a codec of the fixture's
+// version; fixtures marked lossy skip the round trip because the model
+// cannot represent them. Codecs that export message schemas
+// ([wire.ExportSchemas]) must encode every expectation to a message that
+// validates against the schema for its kind. Checks needing an optional interface the codec
+// lacks report [ErrUnsupported], which [Run] turns into a skip.
+//
+// # Fuzzing
+//
+// The package tests include fuzz targets that feed hostile input to
+// every Decode method of the MCP, A2A, ACP and binary codecs and to the
+// stream decoder, seeded from the fixtures:
+//
+//	go test -run '^$' -fuzz FuzzMCP ./wire/conformance
+package conformance
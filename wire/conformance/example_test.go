@@ -0,0 +1,31 @@
+package conformance_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jonwraymond/toolprotocol/wire"
+	"github.com/jonwraymond/toolprotocol/wire/conformance"
+)
+
+func ExampleCheck() {
+	fixtures, err := conformance.Fixtures("mcp")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	w, _ := wire.NewMCPVersion(wire.MCPVersion20250618)
+	for _, f := range fixtures {
+		if f.Name != "tools_call_structured" || !f.AppliesTo(w) {
+			continue
+		}
+		fmt.Println(f.Path())
+		fmt.Println(f.Description)
+		fmt.Println("error:", conformance.Check(context.Background(), w, f))
+	}
+	// Output:
+	// mcp/2025-06-18/tools_call_structured.json
+	// tools/call result with structured content
+	// error: <nil>
+}
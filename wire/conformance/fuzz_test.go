@@ -0,0 +1,202 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jonwraymond/toolprotocol/wire"
+)
+
+// The fuzz targets feed hostile input to every Decode method and
+// re-encode whatever decodes, so that neither side panics. They are
+// seeded from the golden fixtures. Run one with, for example:
+//
+//	go test -run '^$' -fuzz FuzzMCP ./wire/conformance
+
+// seed adds the messages of the protocol's fixtures to the corpus.
+func seed(f *testing.F, protocol string) {
+	fixtures, err := Fixtures(protocol)
+	if err != nil {
+		f.Fatalf("Fixtures(%q) error = %v", protocol, err)
+	}
+	for _, fx := range fixtures {
+		f.Add([]byte(fx.Message))
+	}
+}
+
+// seedBinary adds the fixture models, encoded with w, to the corpus.
+func seedBinary(f *testing.F, w *wire.BinaryWire) {
+	ctx := context.Background()
+	fixtures, err := Fixtures("")
+	if err != nil {
+		f.Fatalf("Fixtures() error = %v", err)
+	}
+	for _, fx := range fixtures {
+		var data []byte
+		switch {
+		case fx.Request != nil:
+			data, err = w.EncodeRequest(ctx, fx.Request)
+		case fx.Response != nil:
+			data, err = w.EncodeResponse(ctx, fx.Response)
+		case fx.ToolPage != nil:
+			data, err = w.EncodeToolPage(ctx, fx.ToolPage)
+		default:
+			continue
+		}
+		if err != nil {
+			f.Fatalf("%s: encode error = %v", fx.Path(), err)
+		}
+		f.Add(data)
+	}
+}
+
+// decodeWire runs the Wire and optional-interface decoders on data and
+// re-encodes the results.
+func decodeWire(ctx context.Context, w wire.Wire, data []byte) {
+	if req, err := w.DecodeRequest(ctx, data); err == nil {
+		_, _ = w.EncodeRequest(ctx, req)
+	}
+	if resp, err := w.DecodeResponse(ctx, data); err == nil {
+		_, _ = w.EncodeResponse(ctx, resp)
+	}
+	if tools, err := w.DecodeToolList(ctx, data); err == nil {
+		_, _ = w.EncodeToolList(ctx, tools)
+	}
+	if pc, ok := w.(wire.PageCodec); ok {
+		if page, err := pc.DecodeToolPage(ctx, data); err == nil {
+			_, _ = pc.EncodeToolPage(ctx, page)
+		}
+	}
+	if d, ok := w.(wire.RawRequestDecoder); ok {
+		if raw, err := d.DecodeRawRequest(ctx, data); err == nil {
+			_, _ = raw.Request()
+			_, _ = wire.DecodeRawArguments[map[string]any](raw)
+		}
+	}
+}
+
+func FuzzMCP(f *testing.F) {
+	seed(f, "mcp")
+	f.Add([]byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"c","version":"1"}}}`))
+	f.Add([]byte(`{"jsonrpc":"2.0","id":1,"result":{"resources":[{"uri":"file:///a","name":"a"}],"prompts":[{"name":"p","arguments":[{"name":"x"}]}],"tasks":[{"taskId":"t","status":"working"}],"nextCursor":"c"}}`))
+	w := wire.NewMCP()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ctx := context.Background()
+		decodeWire(ctx, w, data)
+		if req, err := w.DecodeListRequest(ctx, data); err == nil {
+			_, _ = w.EncodeListRequest(ctx, req)
+		}
+		if page, err := w.DecodeResourcePage(ctx, data); err == nil {
+			_, _ = w.EncodeResourcePage(ctx, page)
+		}
+		if page, err := w.DecodePromptPage(ctx, data); err == nil {
+			_, _ = w.EncodePromptPage(ctx, page)
+		}
+		if page, err := w.DecodeTaskPage(ctx, data); err == nil {
+			_, _ = w.EncodeTaskPage(ctx, page)
+		}
+		if id, params, err := w.DecodeInitialize(ctx, data); err == nil {
+			_, _ = w.EncodeInitialize(ctx, id, params)
+		}
+		if id, result, err := w.DecodeInitializeResult(ctx, data); err == nil {
+			_, _ = w.EncodeInitializeResult(ctx, id, result)
+		}
+		if p, err := w.DecodeProgress(ctx, data); err == nil {
+			_, _ = w.EncodeProgress(ctx, p)
+		}
+		if c, err := w.DecodeCancellation(ctx, data); err == nil {
+			_, _ = w.EncodeCancellation(ctx, c)
+		}
+	})
+}
+
+func FuzzA2A(f *testing.F) {
+	seed(f, "a2a")
+	w := wire.NewA2A()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ctx := context.Background()
+		decodeWire(ctx, w, data)
+		if call, err := w.DecodeCall(ctx, data); err == nil {
+			if p, err := call.MessageSendParams(); err == nil {
+				_, _ = w.EncodeMessageSend(ctx, call.ID, p)
+			}
+			if p, err := call.TaskQueryParams(); err == nil {
+				_, _ = w.EncodeTasksGet(ctx, call.ID, p)
+			}
+			if p, err := call.TaskIDParams(); err == nil {
+				_, _ = w.EncodeTasksCancel(ctx, call.ID, p)
+			}
+		}
+		if id, result, err := w.DecodeResult(ctx, data); err == nil {
+			_, _ = w.EncodeResult(ctx, id, result)
+		}
+	})
+}
+
+func FuzzACP(f *testing.F) {
+	seed(f, "acp")
+	w := wire.NewACP()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ctx := context.Background()
+		decodeWire(ctx, w, data)
+		if call, err := w.DecodeCall(ctx, data); err == nil {
+			var params map[string]any
+			if call.DecodeParams(&params) == nil {
+				_, _ = w.EncodeCall(ctx, call.ID, call.Method, params)
+			}
+		}
+		var result any
+		if id, err := w.DecodeResult(ctx, data, &result); err == nil {
+			_, _ = w.EncodeResult(ctx, id, result)
+		}
+	})
+}
+
+func FuzzCBOR(f *testing.F) {
+	w := wire.NewBinary(wire.EncodingCBOR)
+	seedBinary(f, w)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if v, err := wire.EncodingCBOR.Unmarshal(data); err == nil {
+			_, _ = wire.EncodingCBOR.Marshal(v)
+		}
+		decodeWire(context.Background(), w, data)
+	})
+}
+
+func FuzzMsgPack(f *testing.F) {
+	w := wire.NewBinary(wire.EncodingMsgPack)
+	seedBinary(f, w)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if v, err := wire.EncodingMsgPack.Unmarshal(data); err == nil {
+			_, _ = wire.EncodingMsgPack.Marshal(v)
+		}
+		decodeWire(context.Background(), w, data)
+	})
+}
+
+// FuzzFraming reads framed streams through the stream Decoder, which
+// must stop with an error rather than panic or loop on malformed frames.
+func FuzzFraming(f *testing.F) {
+	seed(f, "mcp")
+	f.Add([]byte("Content-Length: 2\r\n\r\n{}"))
+	f.Add([]byte("event: message\ndata: {\"jsonrpc\":\"2.0\"}\n\n"))
+	framings := []wire.Framing{wire.FramingNDJSON, wire.FramingContentLength, wire.FramingSSE}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ctx := context.Background()
+		for _, fr := range framings {
+			d := wire.NewDecoder(bytes.NewReader(data), wire.NewMCP(), fr, wire.WithMaxMessageSize(1<<16))
+			// Every message consumes input, so the stream must end within
+			// len(data)+1 reads.
+			var err error
+			for range len(data) + 1 {
+				if _, err = d.DecodeRequest(ctx); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				t.Fatalf("%T: decoder did not stop", fr)
+			}
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package wire
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/content"
+)
+
+// FromContent converts a content part to wire content.
+//
+// The built-in content types convert field for field, so ToContent
+// returns an equal part: text keeps its MIME type, images their source
+// URI, alt text and dimensions, resources their text or blob, audio its
+// duration, files their path (as Name) and size, and all of them their
+// annotations. MIME types are copied as set, not defaulted. Other
+// Content implementations are converted by their Type through MIMEType,
+// Bytes and String; LazyContent is read into memory.
+//
+// FromContent returns ErrEncodeFailure for a nil part or an unknown type.
+func FromContent(c content.Content) (Content, error) {
+	if isNilContent(c) {
+		return Content{}, fmt.Errorf("%w: nil content", ErrEncodeFailure)
+	}
+	switch c := c.(type) {
+	case *content.TextContent:
+		return Content{Type: ContentTypeText, Text: c.Text, MIMEType: c.Mime, Annotations: c.Annotations}, nil
+	case *content.ImageContent:
+		return Content{Type: ContentTypeImage, Data: c.Data, MIMEType: c.Mime, URI: c.URI, AltText: c.AltText,
+			Width: c.Width, Height: c.Height, Annotations: c.Annotations}, nil
+	case *content.ResourceContent:
+		return Content{Type: ContentTypeResource, URI: c.URI, MIMEType: c.Mime, Text: c.Text, Data: c.Blob, Annotations: c.Annotations}, nil
+	case *content.AudioContent:
+		return Content{Type: ContentTypeAudio, Data: c.Data, MIMEType: c.Mime, Duration: c.Duration, Annotations: c.Annotations}, nil
+	case *content.FileContent:
+		return Content{Type: ContentTypeFile, Data: c.Data, MIMEType: c.Mime, Name: c.Path, Size: c.Size, Annotations: c.Annotations}, nil
+	case *content.LazyContent:
+		// Wire content holds its payload, so the source is read here.
+		data, err := c.Bytes()
+		if err != nil {
+			return Content{}, fmt.Errorf("%w: %s content: %w", ErrEncodeFailure, c.Type(), err)
+		}
+		out := Content{Type: ContentType(c.Type()), Data: data, MIMEType: c.Mime, URI: c.URI, Name: c.Path, Annotations: c.Annotations}
+		if c.Type() == content.TypeFile {
+			out.Size = int64(len(data))
+		}
+		return out, nil
+	}
+
+	out := Content{Type: ContentType(c.Type()), MIMEType: c.MIMEType(), Annotations: content.AnnotationsOf(c)}
+	switch c.Type() {
+	case content.TypeText:
+		out.Text = c.String()
+		return out, nil
+	case content.TypeImage, content.TypeAudio, content.TypeFile, content.TypeResource:
+		data, err := c.Bytes()
+		if err != nil {
+			return Content{}, fmt.Errorf("%w: %s content: %w", ErrEncodeFailure, c.Type(), err)
+		}
+		out.Data = data
+		if c.Type() == content.TypeFile {
+			out.Size = int64(len(data))
+		}
+		return out, nil
+	}
+	return Content{}, fmt.Errorf("%w: unsupported content type %q", ErrEncodeFailure, c.Type())
+}
+
+// FromContents converts content parts to wire content, preserving order.
+func FromContents(parts []content.Content) ([]Content, error) {
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	out := make([]Content, len(parts))
+	for i, p := range parts {
+		c, err := FromContent(p)
+		if err != nil {
+			return nil, fmt.Errorf("content[%d]: %w", i, err)
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+// ToContent converts wire content to a content part. It is the inverse
+// of FromContent: audio and file content come back as AudioContent and
+// FileContent, and resource Data becomes the resource blob. MCP
+// "resource_link" blocks convert to ResourceContent.
+//
+// ToContent returns ErrDecodeFailure for other content types.
+func ToContent(c Content) (content.Content, error) {
+	switch c.Type {
+	case ContentTypeText:
+		return &content.TextContent{Text: c.Text, Mime: c.MIMEType, Annotations: c.Annotations}, nil
+	case ContentTypeImage:
+		return &content.ImageContent{Data: c.Data, Mime: c.MIMEType, URI: c.URI, AltText: c.AltText,
+			Width: c.Width, Height: c.Height, Annotations: c.Annotations}, nil
+	case ContentTypeResource, "resource_link":
+		return &content.ResourceContent{URI: c.URI, Mime: c.MIMEType, Text: c.Text, Blob: c.Data, Annotations: c.Annotations}, nil
+	case ContentTypeAudio:
+		return &content.AudioContent{Data: c.Data, Mime: c.MIMEType, Duration: c.Duration, Annotations: c.Annotations}, nil
+	case ContentTypeFile:
+		return &content.FileContent{Data: c.Data, Mime: c.MIMEType, Path: c.Name, Size: c.Size, Annotations: c.Annotations}, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported content type %q", ErrDecodeFailure, c.Type)
+}
+
+// ToContents converts wire content to content parts, preserving order.
+func ToContents(cs []Content) ([]content.Content, error) {
+	if len(cs) == 0 {
+		return nil, nil
+	}
+	out := make([]content.Content, len(cs))
+	for i, c := range cs {
+		p, err := ToContent(c)
+		if err != nil {
+			return nil, fmt.Errorf("content[%d]: %w", i, err)
+		}
+		out[i] = p
+	}
+	return out, nil
+}
+
+// NewResponse creates a response carrying content parts, so they can be
+// handed to any codec's EncodeResponse without converting them by hand.
+func NewResponse(id string, parts ...content.Content) (*Response, error) {
+	cs, err := FromContents(parts)
+	if err != nil {
+		return nil, fmt.Errorf("new response: %w", err)
+	}
+	return &Response{ID: id, Content: cs}, nil
+}
+
+// Parts returns the response content as content parts.
+func (r *Response) Parts() ([]content.Content, error) {
+	return ToContents(r.Content)
+}
+
+// isNilContent reports whether c is nil or a typed nil pointer.
+func isNilContent(c content.Content) bool {
+	if c == nil {
+		return true
+	}
+	v := reflect.ValueOf(c)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// annotationsMap returns the JSON object form of annotations, or nil.
+func annotationsMap(a *content.Annotations) map[string]any {
+	if a == nil {
+		return nil
+	}
+	m := map[string]any{}
+	if len(a.Audience) > 0 {
+		audience := make([]any, len(a.Audience))
+		for i, r := range a.Audience {
+			audience[i] = string(r)
+		}
+		m["audience"] = audience
+	}
+	if a.Priority != nil {
+		m["priority"] = *a.Priority
+	}
+	if !a.LastModified.IsZero() {
+		m["lastModified"] = a.LastModified.Format(time.RFC3339Nano)
+	}
+	return m
+}
+
+// annotationsFromMap is the inverse of annotationsMap. Members of the
+// wrong type are ignored, as peers may send newer or malformed hints.
+func annotationsFromMap(m map[string]any) *content.Annotations {
+	if m == nil {
+		return nil
+	}
+	a := &content.Annotations{}
+	if audience, ok := m["audience"].([]any); ok {
+		for _, r := range audience {
+			if s, ok := r.(string); ok {
+				a.Audience = append(a.Audience, content.Role(s))
+			}
+		}
+	}
+	if p, ok := m["priority"].(float64); ok {
+		a.Priority = content.Priority(p)
+	}
+	if s, ok := m["lastModified"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			a.LastModified = t
+		}
+	}
+	return a
+}
@@ -0,0 +1,218 @@
+package wire
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/content"
+)
+
+func allContentParts() []content.Content {
+	return []content.Content{
+		&content.TextContent{Text: "<b>hi</b>", Mime: "text/html"},
+		&content.TextContent{Text: "no mime", Annotations: &content.Annotations{Audience: []content.Role{content.RoleAssistant}}},
+		&content.ImageContent{Data: []byte{0x89, 'P', 'N', 'G'}, Mime: "image/png", URI: "https://example.com/a.png", AltText: "A chart",
+			Width: 640, Height: 480},
+		&content.ResourceContent{URI: "file:///a.txt", Mime: "text/plain", Text: "hello"},
+		&content.ResourceContent{URI: "file:///a.bin", Mime: "application/octet-stream", Blob: []byte{1, 2, 3}},
+		&content.ResourceContent{URI: "file:///link", Annotations: &content.Annotations{
+			Priority:     content.Priority(0.25),
+			LastModified: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		}},
+		&content.AudioContent{Data: []byte("RIFF"), Mime: "audio/wav", Duration: 30 * time.Second},
+		&content.FileContent{Data: []byte("a,b"), Mime: "text/csv", Path: "/reports/q1.csv", Size: 3},
+		&content.FileContent{Path: "/big.iso", Size: 1 << 32},
+	}
+}
+
+func TestFromContent_RoundTrip(t *testing.T) {
+	for _, part := range allContentParts() {
+		c, err := FromContent(part)
+		if err != nil {
+			t.Fatalf("FromContent(%+v) error = %v", part, err)
+		}
+		if string(c.Type) != string(part.Type()) {
+			t.Errorf("Type = %q, want %q", c.Type, part.Type())
+		}
+		got, err := ToContent(c)
+		if err != nil {
+			t.Fatalf("ToContent(%+v) error = %v", c, err)
+		}
+		if !reflect.DeepEqual(got, part) {
+			t.Errorf("round trip = %+v, want %+v", got, part)
+		}
+	}
+}
+
+func TestFromContent_Fields(t *testing.T) {
+	tests := []struct {
+		part content.Content
+		want Content
+	}{
+		{
+			part: &content.ImageContent{Data: []byte{1}, Mime: "image/png", AltText: "alt"},
+			want: Content{Type: ContentTypeImage, Data: []byte{1}, MIMEType: "image/png", AltText: "alt"},
+		},
+		{
+			part: &content.ResourceContent{URI: "file:///b", Blob: []byte{2}},
+			want: Content{Type: ContentTypeResource, URI: "file:///b", Data: []byte{2}},
+		},
+		{
+			part: &content.FileContent{Path: "/x.pdf", Mime: "application/pdf", Size: 10},
+			want: Content{Type: ContentTypeFile, Name: "/x.pdf", MIMEType: "application/pdf", Size: 10},
+		},
+	}
+	for _, tt := range tests {
+		got, err := FromContent(tt.part)
+		if err != nil {
+			t.Fatalf("FromContent error = %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FromContent(%+v) = %+v, want %+v", tt.part, got, tt.want)
+		}
+	}
+}
+
+// customContent is a Content implementation outside the content package.
+type customContent struct {
+	typ  content.Type
+	data []byte
+}
+
+func (c customContent) Type() content.Type     { return c.typ }
+func (c customContent) MIMEType() string       { return "application/x-custom" }
+func (c customContent) Bytes() ([]byte, error) { return c.data, nil }
+func (c customContent) String() string         { return string(c.data) }
+
+func TestFromContent_CustomImplementation(t *testing.T) {
+	got, err := FromContent(customContent{typ: content.TypeText, data: []byte("hi")})
+	if err != nil {
+		t.Fatalf("FromContent error = %v", err)
+	}
+	want := Content{Type: ContentTypeText, Text: "hi", MIMEType: "application/x-custom"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromContent = %+v, want %+v", got, want)
+	}
+
+	got, err = FromContent(customContent{typ: content.TypeFile, data: []byte("abc")})
+	if err != nil {
+		t.Fatalf("FromContent error = %v", err)
+	}
+	want = Content{Type: ContentTypeFile, Data: []byte("abc"), MIMEType: "application/x-custom", Size: 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromContent = %+v, want %+v", got, want)
+	}
+}
+
+func TestFromContent_Errors(t *testing.T) {
+	var nilText *content.TextContent
+	for _, part := range []content.Content{nil, nilText, customContent{typ: "video"}} {
+		if _, err := FromContent(part); !errors.Is(err, ErrEncodeFailure) {
+			t.Errorf("FromContent(%#v) error = %v, want ErrEncodeFailure", part, err)
+		}
+	}
+}
+
+func TestToContent_Unsupported(t *testing.T) {
+	if _, err := ToContent(Content{Type: "video"}); !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("ToContent error = %v, want ErrDecodeFailure", err)
+	}
+}
+
+func TestToContent_ResourceLink(t *testing.T) {
+	got, err := ToContent(Content{Type: "resource_link", URI: "file:///a", MIMEType: "text/plain"})
+	if err != nil {
+		t.Fatalf("ToContent error = %v", err)
+	}
+	want := &content.ResourceContent{URI: "file:///a", Mime: "text/plain"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToContent = %+v, want %+v", got, want)
+	}
+}
+
+func TestFromContents_IndexInError(t *testing.T) {
+	_, err := FromContents([]content.Content{content.NewText("ok"), nil})
+	if !errors.Is(err, ErrEncodeFailure) || err.Error() != "content[1]: wire: encode failed: nil content" {
+		t.Errorf("FromContents error = %v", err)
+	}
+	if cs, err := FromContents(nil); cs != nil || err != nil {
+		t.Errorf("FromContents(nil) = %v, %v, want nil, nil", cs, err)
+	}
+}
+
+func TestNewResponse_Codecs(t *testing.T) {
+	ctx := context.Background()
+	parts := allContentParts()
+	resp, err := NewResponse("1", parts...)
+	if err != nil {
+		t.Fatalf("NewResponse error = %v", err)
+	}
+	for _, w := range []Wire{NewBinary(EncodingCBOR), NewBinary(EncodingMsgPack)} {
+		data, err := w.EncodeResponse(ctx, resp)
+		if err != nil {
+			t.Fatalf("%s: EncodeResponse error = %v", w.Name(), err)
+		}
+		decoded, err := w.DecodeResponse(ctx, data)
+		if err != nil {
+			t.Fatalf("%s: DecodeResponse error = %v", w.Name(), err)
+		}
+		got, err := decoded.Parts()
+		if err != nil {
+			t.Fatalf("%s: Parts error = %v", w.Name(), err)
+		}
+		if !reflect.DeepEqual(got, parts) {
+			t.Errorf("%s: Parts = %+v, want %+v", w.Name(), got, parts)
+		}
+	}
+
+	// The JSON protocols keep what their content blocks can carry.
+	for _, w := range []Wire{NewMCP(), NewA2A(), NewACP()} {
+		data, err := w.EncodeResponse(ctx, resp)
+		if err != nil {
+			t.Fatalf("%s: EncodeResponse error = %v", w.Name(), err)
+		}
+		decoded, err := w.DecodeResponse(ctx, data)
+		if err != nil {
+			t.Fatalf("%s: DecodeResponse error = %v", w.Name(), err)
+		}
+		if len(decoded.Content) != len(parts) {
+			t.Errorf("%s: decoded %d parts, want %d", w.Name(), len(decoded.Content), len(parts))
+		}
+		if _, err := decoded.Parts(); err != nil {
+			t.Errorf("%s: Parts error = %v", w.Name(), err)
+		}
+	}
+}
+
+func TestNewResponse_Error(t *testing.T) {
+	if _, err := NewResponse("1", nil); !errors.Is(err, ErrEncodeFailure) {
+		t.Errorf("NewResponse error = %v, want ErrEncodeFailure", err)
+	}
+}
+
+func TestFromContent_Lazy(t *testing.T) {
+	payload := []byte("a,b,c")
+	lazy := &content.LazyContent{
+		Kind:   content.TypeFile,
+		Mime:   "text/csv",
+		Path:   "/report.csv",
+		Source: content.ReaderAtSource(bytes.NewReader(payload), int64(len(payload))),
+	}
+	got, err := FromContent(lazy)
+	if err != nil {
+		t.Fatalf("FromContent error = %v", err)
+	}
+	want := Content{Type: ContentTypeFile, Data: payload, MIMEType: "text/csv", Name: "/report.csv", Size: 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromContent = %+v, want %+v", got, want)
+	}
+
+	missing := content.NewLazyFile(t.TempDir()+"/missing", "")
+	if _, err := FromContent(missing); !errors.Is(err, ErrEncodeFailure) {
+		t.Errorf("FromContent error = %v, want ErrEncodeFailure", err)
+	}
+}
@@ -0,0 +1,144 @@
+package wire
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// cursorMACSize is the length of the truncated HMAC-SHA256 tag.
+const cursorMACSize = 16
+
+// DefaultPageSize is the page size used when a Pager does not set one.
+const DefaultPageSize = 100
+
+// CursorCodec issues and verifies opaque pagination cursors.
+//
+// A cursor records the scope it was issued for and the key of the last
+// item returned, authenticated with HMAC-SHA256. Clients cannot forge or
+// alter cursors, and a cursor from one list is rejected by another.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use.
+//   - Errors: Decode returns ErrInvalidCursor for any cursor it did not issue.
+type CursorCodec struct {
+	key []byte
+}
+
+// NewCursorCodec creates a cursor codec that signs with key. Servers
+// that share cursors across instances or restarts must share the key.
+// A nil or empty key selects a random one, so cursors only remain valid
+// for the life of the codec.
+func NewCursorCodec(key []byte) *CursorCodec {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+	}
+	return &CursorCodec{key: slices.Clone(key)}
+}
+
+// cursorPayload is the signed content of a cursor.
+type cursorPayload struct {
+	Scope string `json:"s,omitempty"`
+	After string `json:"a"`
+}
+
+// Encode returns a cursor that resumes scope after the item with key after.
+func (c *CursorCodec) Encode(scope, after string) string {
+	payload, _ := json.Marshal(cursorPayload{Scope: scope, After: after})
+	return base64.RawURLEncoding.EncodeToString(append(payload, c.mac(payload)...))
+}
+
+// Decode verifies a cursor issued for scope and returns the key of the
+// last item already returned.
+func (c *CursorCodec) Decode(scope, cursor string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) <= cursorMACSize {
+		return "", fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+	payload, tag := raw[:len(raw)-cursorMACSize], raw[len(raw)-cursorMACSize:]
+	if !hmac.Equal(tag, c.mac(payload)) {
+		return "", fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+	}
+	var p cursorPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if p.Scope != scope {
+		return "", fmt.Errorf("%w: issued for %q", ErrInvalidCursor, p.Scope)
+	}
+	return p.After, nil
+}
+
+func (c *CursorCodec) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write(payload)
+	return h.Sum(nil)[:cursorMACSize]
+}
+
+// Page is one page of a list operation.
+type Page[T any] struct {
+	// Items are the entries on this page.
+	Items []T
+
+	// NextCursor resumes the listing after this page; empty on the last page.
+	NextCursor string
+}
+
+// Pager pages through slice-backed lists such as registries.
+type Pager struct {
+	// Codec signs the cursors. Required.
+	Codec *CursorCodec
+
+	// Size is the maximum number of items per page.
+	// Zero means DefaultPageSize.
+	Size int
+
+	// Scope names the list, such as "tools/list", so that cursors cannot
+	// be replayed against a different list.
+	Scope string
+}
+
+// Paginate returns the page of items that follows cursor; an empty cursor
+// starts at the beginning.
+//
+// Items are ordered by key, which must be unique per item, so pages are
+// deterministic however the registry orders its slice. Cursors record
+// the last key returned rather than an offset: items added or removed
+// between requests never cause others to be skipped or repeated.
+//
+// Cursors the pager did not issue return an error wrapping
+// ErrInvalidCursor.
+func Paginate[T any](p Pager, items []T, key func(T) string, cursor string) (Page[T], error) {
+	size := p.Size
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	start := 0
+	sorted := slices.SortedStableFunc(slices.Values(items), func(a, b T) int {
+		return strings.Compare(key(a), key(b))
+	})
+	if cursor != "" {
+		after, err := p.Codec.Decode(p.Scope, cursor)
+		if err != nil {
+			return Page[T]{}, err
+		}
+		start, _ = slices.BinarySearchFunc(sorted, after, func(item T, k string) int {
+			if key(item) <= k {
+				return -1
+			}
+			return 1
+		})
+	}
+	end := min(start+size, len(sorted))
+	page := Page[T]{Items: sorted[start:end]}
+	if end < len(sorted) {
+		page.NextCursor = p.Codec.Encode(p.Scope, key(sorted[end-1]))
+	}
+	return page, nil
+}
@@ -0,0 +1,135 @@
+package wire
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCursorCodec(t *testing.T) {
+	c := NewCursorCodec([]byte("secret"))
+	cursor := c.Encode("tools/list", "search")
+	if strings.Contains(cursor, "=") || strings.Contains(cursor, "+") {
+		t.Errorf("cursor %q is not URL-safe", cursor)
+	}
+	after, err := c.Decode("tools/list", cursor)
+	if err != nil || after != "search" {
+		t.Fatalf("Decode = %q, %v, want search", after, err)
+	}
+
+	// Tampering with any byte invalidates the cursor.
+	raw := []byte(cursor)
+	raw[2] ^= 1
+	tests := map[string]struct {
+		codec  *CursorCodec
+		scope  string
+		cursor string
+	}{
+		"tampered":    {c, "tools/list", string(raw)},
+		"other scope": {c, "resources/list", cursor},
+		"other key":   {NewCursorCodec([]byte("other")), "tools/list", cursor},
+		"not base64":  {c, "tools/list", "!!"},
+		"too short":   {c, "tools/list", "YWJj"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := tt.codec.Decode(tt.scope, tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Decode error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestNewCursorCodec_RandomKey(t *testing.T) {
+	a, b := NewCursorCodec(nil), NewCursorCodec(nil)
+	cursor := a.Encode("", "x")
+	if _, err := a.Decode("", cursor); err != nil {
+		t.Errorf("Decode with issuing codec error: %v", err)
+	}
+	if _, err := b.Decode("", cursor); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Decode with other random codec error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func names(n int) []Tool {
+	tools := make([]Tool, n)
+	for i := range tools {
+		tools[i] = Tool{Name: fmt.Sprintf("tool-%03d", i)}
+	}
+	return tools
+}
+
+func toolKey(t Tool) string { return t.Name }
+
+func TestPaginate(t *testing.T) {
+	tools := names(25)
+	// Registries return items in arbitrary order.
+	shuffled := slices.Clone(tools)
+	slices.Reverse(shuffled)
+	p := Pager{Codec: NewCursorCodec([]byte("k")), Size: 10, Scope: "tools/list"}
+
+	var got []Tool
+	var pages int
+	cursor := ""
+	for {
+		page, err := Paginate(p, shuffled, toolKey, cursor)
+		if err != nil {
+			t.Fatalf("Paginate error: %v", err)
+		}
+		pages++
+		got = append(got, page.Items...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if pages != 3 || !reflect.DeepEqual(got, tools) {
+		t.Errorf("pages = %d, items = %v", pages, got)
+	}
+	if shuffled[0].Name != "tool-024" {
+		t.Error("Paginate reordered the caller's slice")
+	}
+}
+
+func TestPaginate_StableUnderChanges(t *testing.T) {
+	p := Pager{Codec: NewCursorCodec([]byte("k")), Size: 2}
+	tools := []Tool{{Name: "a"}, {Name: "c"}, {Name: "e"}, {Name: "g"}}
+
+	first, _ := Paginate(p, tools, toolKey, "")
+	// Remove a returned item and insert one before the cursor and one after.
+	changed := []Tool{{Name: "c"}, {Name: "b"}, {Name: "d"}, {Name: "e"}, {Name: "g"}}
+	second, err := Paginate(p, changed, toolKey, first.NextCursor)
+	if err != nil {
+		t.Fatalf("Paginate error: %v", err)
+	}
+	if want := []Tool{{Name: "d"}, {Name: "e"}}; !reflect.DeepEqual(second.Items, want) {
+		t.Errorf("second page = %v, want %v", second.Items, want)
+	}
+}
+
+func TestPaginate_Edges(t *testing.T) {
+	p := Pager{Codec: NewCursorCodec([]byte("k"))}
+
+	page, err := Paginate(p, names(DefaultPageSize), toolKey, "")
+	if err != nil || len(page.Items) != DefaultPageSize || page.NextCursor != "" {
+		t.Errorf("full single page = %d items, cursor %q, err %v", len(page.Items), page.NextCursor, err)
+	}
+	page, err = Paginate(p, nil, toolKey, "")
+	if err != nil || len(page.Items) != 0 || page.NextCursor != "" {
+		t.Errorf("empty list = %+v, %v", page, err)
+	}
+	if _, err := Paginate(p, names(3), toolKey, "bogus"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("bogus cursor error = %v, want ErrInvalidCursor", err)
+	}
+	scoped := Pager{Codec: p.Codec, Scope: "prompts/list"}
+	cursor := p.Codec.Encode("tools/list", "tool-001")
+	if _, err := Paginate(scoped, names(3), toolKey, cursor); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("cross-scope cursor error = %v, want ErrInvalidCursor", err)
+	}
+	if code := FromError(ErrInvalidCursor).Code; code != CodeInvalidParams {
+		t.Errorf("FromError(ErrInvalidCursor).Code = %d, want %d", code, CodeInvalidParams)
+	}
+}
@@ -0,0 +1,318 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MCPProtocolVersionHeader is the HTTP header MCP clients use to announce
+// the negotiated protocol version.
+const MCPProtocolVersionHeader = "MCP-Protocol-Version"
+
+// Detection describes a candidate protocol for a payload.
+type Detection struct {
+	// Name is the registry name of the detected format (e.g., "mcp").
+	Name string
+
+	// Wire is the codec registered under Name.
+	Wire Wire
+
+	// Version is the protocol version announced by the payload or headers.
+	// Empty when the payload does not carry version information.
+	Version string
+
+	// Confidence is a score in (0, 1]; higher means a stronger match.
+	Confidence float64
+
+	// Signals lists the evidence that contributed to the score.
+	Signals []string
+}
+
+// Detector identifies the wire format of incoming payloads.
+//
+// Detection combines independent signals (method names, parameter and
+// result shapes, transport headers) per protocol. Each signal carries a
+// weight in (0, 1) and weights are combined as 1 - Π(1 - w), so several
+// weak signals add up without ever exceeding 1.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use.
+//   - Registry: Only formats registered in the registry are candidates.
+//   - Errors: Detect returns ErrUnsupportedFormat when nothing matches.
+type Detector struct {
+	registry *Registry
+}
+
+// NewDetector creates a detector over the given registry.
+// A nil registry uses DefaultRegistry.
+func NewDetector(reg *Registry) *Detector {
+	if reg == nil {
+		reg = DefaultRegistry()
+	}
+	return &Detector{registry: reg}
+}
+
+// Detect returns the best matching codec for the payload.
+// The header may be nil when the payload did not arrive over HTTP.
+func (d *Detector) Detect(data []byte, header http.Header) (*Detection, error) {
+	candidates := d.Candidates(data, header)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: no protocol matched payload", ErrUnsupportedFormat)
+	}
+	return &candidates[0], nil
+}
+
+// Candidates returns every matching codec ordered by descending confidence.
+// Ties are broken by format name for deterministic results.
+func (d *Detector) Candidates(data []byte, header http.Header) []Detection {
+	scores := map[string]*Detection{}
+	add := func(name string, weight float64, signal string) {
+		det, ok := scores[name]
+		if !ok {
+			det = &Detection{Name: name}
+			scores[name] = det
+		}
+		det.Confidence = 1 - (1-det.Confidence)*(1-weight)
+		det.Signals = append(det.Signals, signal)
+	}
+
+	if header != nil {
+		if v := header.Get(MCPProtocolVersionHeader); v != "" {
+			add("mcp", 0.9, "header:"+MCPProtocolVersionHeader)
+			scores["mcp"].Version = v
+		}
+		if header.Get("Mcp-Session-Id") != "" {
+			add("mcp", 0.6, "header:Mcp-Session-Id")
+		}
+		if header.Get("X-A2A-Extensions") != "" {
+			add("a2a", 0.6, "header:X-A2A-Extensions")
+		}
+	}
+
+	if msg := firstMessage(data); msg != nil {
+		detectMessage(msg, add)
+		if det, ok := scores["mcp"]; ok && det.Version == "" {
+			det.Version = initializeVersion(msg, "mcp")
+		}
+		if det, ok := scores["acp"]; ok && det.Version == "" {
+			det.Version = initializeVersion(msg, "acp")
+		}
+	}
+
+	out := make([]Detection, 0, len(scores))
+	for name, det := range scores {
+		w := d.registry.Get(name)
+		if w == nil {
+			continue
+		}
+		det.Wire = w
+		if _, ok := w.(*MCPWire); ok && det.Version != "" {
+			// Prefer a codec pinned to the announced revision.
+			if versioned, err := NewMCPVersion(det.Version); err == nil {
+				det.Wire = versioned
+			}
+		}
+		out = append(out, *det)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Confidence != out[j].Confidence {
+			return out[i].Confidence > out[j].Confidence
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// Detect identifies the payload's wire format using DefaultRegistry.
+func Detect(data []byte, header http.Header) (*Detection, error) {
+	return NewDetector(nil).Detect(data, header)
+}
+
+// mcpMethodPrefixes are method namespaces defined only by MCP.
+var mcpMethodPrefixes = []string{
+	"tools/", "resources/", "prompts/", "completion/", "logging/",
+	"sampling/", "roots/", "elicitation/",
+}
+
+// a2aMethods are method names defined only by A2A (including legacy 0.1/0.2 names).
+var a2aMethods = map[string]bool{
+	"message/send":                        true,
+	"message/stream":                      true,
+	"tasks/resubscribe":                   true,
+	"tasks/send":                          true,
+	"tasks/sendSubscribe":                 true,
+	"tasks/pushNotificationConfig/set":    true,
+	"tasks/pushNotificationConfig/get":    true,
+	"tasks/pushNotificationConfig/list":   true,
+	"tasks/pushNotificationConfig/delete": true,
+	"agent/getAuthenticatedExtendedCard":  true,
+}
+
+// acpMethodPrefixes are method namespaces defined by the Agent Client Protocol.
+var acpMethodPrefixes = []string{"session/", "fs/", "terminal/"}
+
+func detectMessage(msg map[string]any, add func(name string, weight float64, signal string)) {
+	method, _ := msg["method"].(string)
+	params, _ := msg["params"].(map[string]any)
+
+	switch {
+	case method == "":
+	case a2aMethods[method]:
+		add("a2a", 0.8, "method:"+method)
+	case hasPrefix(method, acpMethodPrefixes):
+		add("acp", 0.8, "method:"+method)
+	case method == "tools/call":
+		// ACP invocations reuse the method name with agentId + input
+		// params; only MCP's params shape makes the method evidence.
+		if _, ok := params["name"].(string); ok {
+			add("mcp", 0.8, "method:"+method)
+		}
+	case hasPrefix(method, mcpMethodPrefixes):
+		add("mcp", 0.8, "method:"+method)
+	case strings.HasPrefix(method, "notifications/"), method == "ping":
+		add("mcp", 0.6, "method:"+method)
+	case method == "tasks/get", method == "tasks/cancel":
+		// Shared by A2A and MCP tasks; the params shape disambiguates.
+		if _, ok := params["taskId"]; ok {
+			add("mcp", 0.6, "method:"+method)
+		} else {
+			add("a2a", 0.6, "method:"+method)
+		}
+	case method == "tasks/list", method == "tasks/result":
+		add("mcp", 0.6, "method:"+method)
+	case method == "initialize":
+		if _, ok := params["protocolVersion"].(string); ok {
+			add("mcp", 0.8, "param:protocolVersion(string)")
+		} else if _, ok := params["protocolVersion"].(float64); ok {
+			add("acp", 0.8, "param:protocolVersion(number)")
+		}
+	}
+
+	if params != nil {
+		if _, ok := params["arguments"]; ok {
+			if _, ok := params["name"].(string); ok {
+				add("mcp", 0.5, "param:name+arguments")
+			}
+		}
+		if m, ok := params["message"].(map[string]any); ok {
+			if _, ok := m["parts"]; ok {
+				add("a2a", 0.6, "param:message.parts")
+			}
+		}
+		if _, ok := params["skillId"]; ok {
+			add("a2a", 0.4, "param:skillId")
+		}
+		if _, ok := params["agentId"]; ok {
+			add("acp", 0.6, "param:agentId")
+		}
+		if _, ok := params["sessionId"]; ok {
+			add("acp", 0.4, "param:sessionId")
+		}
+		if _, ok := params["clientCapabilities"]; ok {
+			add("acp", 0.5, "param:clientCapabilities")
+		}
+		if _, ok := params["clientInfo"]; ok {
+			add("mcp", 0.3, "param:clientInfo")
+		}
+	}
+
+	if result, ok := msg["result"].(map[string]any); ok {
+		detectResult(result, add)
+	}
+
+	// Bare list documents carry no JSON-RPC envelope.
+	if _, ok := msg["tools"].([]any); ok {
+		add("mcp", 0.5, "field:tools")
+	}
+	if _, ok := msg["skills"].([]any); ok {
+		add("a2a", 0.5, "field:skills")
+	}
+	if _, ok := msg["agents"].([]any); ok {
+		add("acp", 0.5, "field:agents")
+	}
+}
+
+func detectResult(result map[string]any, add func(name string, weight float64, signal string)) {
+	if _, ok := result["content"].([]any); ok {
+		add("mcp", 0.5, "result:content")
+	}
+	if _, ok := result["structuredContent"]; ok {
+		add("mcp", 0.5, "result:structuredContent")
+	}
+	if _, ok := result["tools"].([]any); ok {
+		add("mcp", 0.5, "result:tools")
+	}
+	if _, ok := result["artifacts"]; ok {
+		add("a2a", 0.6, "result:artifacts")
+	}
+	if _, ok := result["status"].(map[string]any); ok {
+		add("a2a", 0.4, "result:status")
+	}
+	if kind, ok := result["kind"].(string); ok && (kind == "task" || kind == "message") {
+		add("a2a", 0.6, "result:kind")
+	}
+	if _, ok := result["output"]; ok {
+		add("acp", 0.6, "result:output")
+	}
+	if _, ok := result["stopReason"]; ok {
+		add("acp", 0.6, "result:stopReason")
+	}
+	if _, ok := result["agentCapabilities"]; ok {
+		add("acp", 0.6, "result:agentCapabilities")
+	}
+	if _, ok := result["serverInfo"]; ok {
+		add("mcp", 0.6, "result:serverInfo")
+	}
+}
+
+// initializeVersion extracts the protocol version from an initialize
+// request or result for the given protocol.
+func initializeVersion(msg map[string]any, name string) string {
+	for _, key := range []string{"params", "result"} {
+		body, ok := msg[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		switch v := body["protocolVersion"].(type) {
+		case string:
+			if name == "mcp" {
+				return v
+			}
+		case float64:
+			if name == "acp" {
+				return fmt.Sprintf("%.0f", v)
+			}
+		}
+	}
+	return ""
+}
+
+// firstMessage parses a JSON object, or the first element of a batch array.
+func firstMessage(data []byte) map[string]any {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var batch []map[string]any
+		if err := json.Unmarshal(data, &batch); err != nil || len(batch) == 0 {
+			return nil
+		}
+		return batch[0]
+	}
+	var msg map[string]any
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil
+	}
+	return msg
+}
+
+func hasPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDetector_Fixtures checks every payload under testdata/detect.
+// Fixtures are laid out as <protocol>/<version>/<message>.json.
+func TestDetector_Fixtures(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "detect", "*", "*", "*.json"))
+	if err != nil {
+		t.Fatalf("Glob error = %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no detection fixtures found")
+	}
+
+	d := NewDetector(nil)
+	for _, file := range files {
+		rel, _ := filepath.Rel(filepath.Join("testdata", "detect"), file)
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		wantName, wantVersion := parts[0], parts[1]
+
+		t.Run(filepath.ToSlash(rel), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("ReadFile error = %v", err)
+			}
+			det, err := d.Detect(data, nil)
+			if err != nil {
+				t.Fatalf("Detect error = %v", err)
+			}
+			if det.Name != wantName {
+				t.Errorf("Name = %q, want %q (signals: %v)", det.Name, wantName, det.Signals)
+			}
+			if det.Wire == nil || det.Wire.Name() != wantName {
+				t.Errorf("Wire = %v, want %s codec", det.Wire, wantName)
+			}
+			if det.Confidence <= 0 || det.Confidence > 1 {
+				t.Errorf("Confidence = %v, want in (0, 1]", det.Confidence)
+			}
+			if strings.HasPrefix(parts[2], "initialize") && det.Version != wantVersion {
+				t.Errorf("Version = %q, want %q", det.Version, wantVersion)
+			}
+		})
+	}
+}
+
+func TestDetector_MCPHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set(MCPProtocolVersionHeader, "2025-06-18")
+
+	// A bare ping is weak evidence on its own; the header settles it.
+	det, err := Detect([]byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}`), header)
+	if err != nil {
+		t.Fatalf("Detect error = %v", err)
+	}
+	if det.Name != "mcp" {
+		t.Errorf("Name = %q, want %q", det.Name, "mcp")
+	}
+	if det.Version != "2025-06-18" {
+		t.Errorf("Version = %q, want %q", det.Version, "2025-06-18")
+	}
+	if det.Confidence < 0.9 {
+		t.Errorf("Confidence = %v, want >= 0.9", det.Confidence)
+	}
+}
+
+func TestDetector_SignalsAccumulate(t *testing.T) {
+	d := NewDetector(nil)
+	weak, err := d.Detect([]byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}`), nil)
+	if err != nil {
+		t.Fatalf("Detect error = %v", err)
+	}
+	header := http.Header{}
+	header.Set("Mcp-Session-Id", "abc")
+	strong, err := d.Detect([]byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}`), header)
+	if err != nil {
+		t.Fatalf("Detect error = %v", err)
+	}
+	if strong.Confidence <= weak.Confidence {
+		t.Errorf("Confidence with header = %v, want > %v", strong.Confidence, weak.Confidence)
+	}
+}
+
+func TestDetector_Batch(t *testing.T) {
+	data := []byte(`[{"jsonrpc":"2.0","id":1,"method":"session/cancel","params":{"sessionId":"s1"}}]`)
+	det, err := Detect(data, nil)
+	if err != nil {
+		t.Fatalf("Detect error = %v", err)
+	}
+	if det.Name != "acp" {
+		t.Errorf("Name = %q, want %q", det.Name, "acp")
+	}
+}
+
+func TestDetector_Candidates_Ordered(t *testing.T) {
+	// skillId alongside MCP-style name/arguments: both protocols match.
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"x","arguments":{},"skillId":"x"}}`)
+	got := NewDetector(nil).Candidates(data, nil)
+	if len(got) != 2 {
+		t.Fatalf("len(Candidates) = %d, want 2", len(got))
+	}
+	if got[0].Name != "mcp" || got[1].Name != "a2a" {
+		t.Errorf("Candidates = [%s %s], want [mcp a2a]", got[0].Name, got[1].Name)
+	}
+	if got[0].Confidence < got[1].Confidence {
+		t.Errorf("Candidates not sorted by confidence: %v < %v", got[0].Confidence, got[1].Confidence)
+	}
+}
+
+func TestDetector_RegistryFilters(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register("a2a", NewA2A())
+
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"x","arguments":{}}}`)
+	_, err := NewDetector(reg).Detect(data, nil)
+	if !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Detect error = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+func TestDetector_NoMatch(t *testing.T) {
+	for _, data := range []string{`{invalid`, `{"hello":"world"}`, `[]`, ``} {
+		if _, err := Detect([]byte(data), nil); !errors.Is(err, ErrUnsupportedFormat) {
+			t.Errorf("Detect(%q) error = %v, want ErrUnsupportedFormat", data, err)
+		}
+	}
+}
+
+// TestDetector_CodecOutput runs each registered codec's own encodings
+// through Detect.
+func TestDetector_CodecOutput(t *testing.T) {
+	ctx := context.Background()
+	requests := []*Request{
+		{ID: "1", Method: "tools/call", ToolID: "search", Arguments: map[string]any{"q": "go"}},
+		{ID: "2", ToolID: "ping"},
+		{ID: "3", Method: "tools/call", ToolID: "search", Arguments: map[string]any{"q": "go"}, Meta: map[string]any{"trace": "t"}},
+	}
+	responses := []*Response{
+		{ID: "1", Content: []Content{{Type: ContentTypeText, Text: "hi"}}},
+		{ID: "2", Content: []Content{{Type: ContentTypeText, Text: "failed"}}, IsError: true},
+		{ID: "3", StructuredContent: map[string]any{"n": 1.0}},
+	}
+	reg := DefaultRegistry()
+	d := NewDetector(reg)
+	for _, name := range reg.List() {
+		w := reg.Get(name)
+		check := func(kind string, data []byte, err error) {
+			t.Helper()
+			if err != nil {
+				t.Fatalf("%s: encode %s: %v", name, kind, err)
+			}
+			det, err := d.Detect(data, nil)
+			if err != nil {
+				t.Fatalf("%s: Detect(%s) error = %v", name, data, err)
+			}
+			if det.Name != name {
+				t.Errorf("%s: Detect(%s) = %q (signals: %v)", name, data, det.Name, det.Signals)
+			}
+		}
+		for _, req := range requests {
+			data, err := w.EncodeRequest(ctx, req)
+			check("request", data, err)
+		}
+		for _, resp := range responses {
+			data, err := w.EncodeResponse(ctx, resp)
+			check("response", data, err)
+		}
+	}
+}
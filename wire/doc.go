@@ -1,7 +1,7 @@
 // Package wire provides multi-protocol wire format adapters for tool communication.
 //
 // It enables encoding and decoding of tool requests and responses across
-// different protocols including MCP (Anthropic), A2A (Google), and ACP (IBM).
+// different protocols including MCP (Anthropic), A2A (Google), and ACP (Agent Client Protocol).
 //
 // # Ecosystem Position
 //
@@ -37,9 +37,38 @@
 //   - [Wire]: Interface for protocol-specific encoding/decoding
 //   - [MCPWire]: Model Context Protocol (Anthropic) - JSON-RPC 2.0 based
 //   - [A2AWire]: Agent-to-Agent Protocol (Google) - JSON-RPC with artifacts
-//   - [ACPWire]: Agent Communication Protocol (IBM) - JSON-RPC with agents
+//   - [ACPWire]: Agent Client Protocol - JSON-RPC sessions between editors and agents
 //   - [Registry]: Thread-safe registry of wire format handlers
 //   - [DefaultRegistry]: Pre-configured registry with all standard formats
+//   - [Translator]: Converts raw messages between protocols, reporting dropped fields
+//   - [Detector]: Identifies the protocol of an incoming payload with a confidence score
+//   - [MCPHandshake]: Negotiates the MCP revision during initialize
+//   - [Encoder], [Decoder]: Stream framed messages using a pluggable [Framing]
+//     (NDJSON, Content-Length headers, SSE)
+//   - [CanonicalWire]: Wraps any [Wire] to emit RFC 8785 canonical JSON ([Canonicalize])
+//   - [Error]: Protocol error with JSON-RPC, MCP and A2A codes; [FromError] maps Go errors
+//   - [Schema]: JSON Schema 2020-12 subset validator; [ValidateArguments] checks
+//     [Request] arguments against a [Tool] input schema
+//   - [SchemaFor], [NewTool]: Derive tool schemas from Go structs; [DecodeArguments]
+//     decodes [Request] arguments into the same struct
+//   - [RawRequest]: Requests with arguments kept as raw JSON ([RawRequestDecoder]),
+//     decoded straight into caller types by [DecodeRawArguments]
+//   - [Progress], [Cancellation]: Notifications bridged to [stream.Event]
+//     ([ProgressEvent]) and request contexts ([Cancellations]); tokens and
+//     request IDs are [ID]s that keep their JSON string or number type
+//   - [Paginate], [CursorCodec]: Page slice-backed registries with signed,
+//     opaque cursors; [PageCodec] encodes tool, resource, prompt and task
+//     [Page] envelopes with nextCursor
+//   - [BinaryWire]: Serializes the model with an [Encoding] ([EncodingCBOR],
+//     [EncodingMsgPack]) chosen by content type ([NegotiateEncoding], [WireFor])
+//   - [Intercept]: Wraps a [Wire] with an [Interceptor] chain; built-ins
+//     [Redact], [InjectMeta], [LimitSize] and [Logging] (log/slog)
+//   - [ExportSchemas]: JSON Schemas of a codec's messages ([SchemaExporter]);
+//     [OpenRPC] combines them with a tool list into an OpenRPC document
+//   - [TraceContext]: W3C traceparent, tracestate and baggage carried in Meta
+//     ([InjectTraceMeta], [TraceFromMeta]) and contexts ([ExtractTrace], [PropagateTrace])
+//   - [FromContent], [ToContent]: Convert between content.Content parts and [Content];
+//     [NewResponse] builds a [Response] from parts and [Response.Parts] reads them back
 //
 // # Quick Start
 //
@@ -59,34 +88,63 @@
 //	// Decode a response
 //	resp, err := w.DecodeResponse(ctx, responseData)
 //
+// Servers that know their argument types can skip the map[string]any
+// form of the arguments entirely:
+//
+//	raw, err := wire.NewMCP().DecodeRawRequest(ctx, data)
+//	args, err := wire.DecodeRawArguments[SearchArgs](raw)
+//
 // # Available Formats
 //
 // MCP (Model Context Protocol):
 //   - Version: 2025-11-25
+//   - Also supports: 2024-11-05, 2025-03-26, 2025-06-18 via [NewMCPVersion]
 //   - Streaming: Yes
-//   - Batch requests: No
-//   - Progress notifications: Yes
-//   - Cancellation: Yes
+//   - Batch requests: No (Yes for 2025-03-26)
+//   - Progress notifications: Yes ([NotificationCodec]; messages from 2025-03-26)
+//   - Cancellation: Yes (notifications/cancelled)
+//   - Lists: tools, resources, prompts; tasks from 2025-11-25
 //
 // A2A (Agent-to-Agent Protocol):
-//   - Version: 0.2.1
+//   - Version: 0.3.0
+//   - Methods: message/send, message/stream, tasks/get, tasks/cancel,
+//     tasks/resubscribe via [A2AWire.EncodeCall] and [A2AWire.EncodeResult]
 //   - Streaming: Yes
 //   - Batch requests: No
-//   - Progress notifications: Yes
-//   - Cancellation: Yes
-//
-// ACP (Agent Communication Protocol):
-//   - Version: 1.0.0
-//   - Streaming: No
+//   - Progress notifications: Yes ([NotificationCodec]; status-update events)
+//   - Cancellation: Yes (tasks/cancel)
+//   - Lists: skills, tasks; agents list no resources or prompts
+//
+// ACP (Agent Client Protocol):
+//   - Version: 1.0.0 (protocol version [ACPProtocolVersion])
+//   - Methods: initialize, session/new, session/load, session/prompt,
+//     session/cancel, session/update, session/request_permission
+//   - Streaming: No (session/update notifications via [ACPWire.EncodeSessionUpdate])
 //   - Batch requests: Yes
-//   - Progress notifications: No
-//   - Cancellation: Yes
+//   - Progress notifications: No (tool call updates and plans instead)
+//   - Cancellation: Yes ([NotificationCodec]; session/cancel)
+//   - Lists: agents; resources travel in prompts and work in sessions
+//
+// Binary (CBOR, MessagePack):
+//   - Version: [BinaryVersion] ([NewBinary])
+//   - Media types: application/cbor, application/vnd.msgpack
+//   - Layout: The Request/Response/Tool model as a map; content data as raw bytes
+//   - Streaming, batches, notifications: No (carried by the protocol codecs)
+//   - Lists: tools, resources, prompts, tasks
+//
+// # Conformance
+//
+// Package conformance embeds golden messages from each protocol's
+// specification and checks any [Wire] against them: decoding, round
+// trips, exact encodings where the codec reproduces them and, for codecs
+// that export schemas, validity against those schemas. Its fuzz targets
+// cover every Decode method.
 //
 // # Thread Safety
 //
 // All exported types are safe for concurrent use:
 //
-//   - [MCPWire], [A2AWire], [ACPWire]: Stateless, concurrent-safe
+//   - [MCPWire], [A2AWire], [ACPWire], [BinaryWire]: Stateless, concurrent-safe
 //   - [Registry]: sync.RWMutex protects all operations
 //   - [DefaultRegistry]: Returns shared instance, safe to use concurrently
 //
@@ -97,6 +155,16 @@
 //   - [ErrUnsupportedFormat]: Unknown wire format requested
 //   - [ErrEncodeFailure]: Encoding to wire format failed
 //   - [ErrDecodeFailure]: Decoding from wire format failed
+//   - [ErrTranslateFailure]: Translating between wire formats failed
+//   - [ErrMessageTooLarge]: A framed message exceeded the maximum size
+//   - [ErrUnsupportedVersion]: A protocol version is unknown or not negotiable
+//   - [ErrUnsupportedFeature]: A message needs a feature the version lacks
+//   - [ErrInvalidSchema]: A JSON Schema is malformed or uses a remote reference
+//   - [ErrInvalidArguments]: Arguments fail a tool's input schema ([ValidationError])
+//   - [ErrRequestCancelled]: Context cause for a request cancelled by notification
+//   - [ErrInvalidCursor]: A pagination cursor is malformed, forged or from another list
+//   - [ErrInvalidPath]: A [Redactor] path is malformed
+//   - [ErrInvalidTraceContext]: A traceparent or baggage value is malformed
 //
 // Encode/Decode methods wrap underlying errors with context:
 //
@@ -105,6 +173,19 @@
 //	    // err contains: "decode response: <underlying error>"
 //	}
 //
+// Protocol errors carried in responses are [*Error] values. Each code in
+// the catalogue has a matching value ([ErrParse], [ErrMethodNotFound],
+// [ErrInvalidParams], [ErrResourceNotFound], [ErrTaskNotFound],
+// [ErrTaskNotCancelable], ...) and errors.Is compares codes, so a decoded
+// error matches regardless of message. Server-defined codes are scoped
+// by [Error.Protocol]: MCP's resource-not-found and A2A's
+// task-not-cancelable share -32002 but do not match. [FromError] maps
+// task, resource, prompt and elicit sentinels to codes while keeping the
+// original error in the chain, and [FromErrorFor] keeps another
+// protocol's codes off the wire:
+//
+//	resp.Error = wire.FromErrorFor("a2a", err) // task.ErrTaskNotFound -> -32001
+//
 // # Integration with ApertureStack
 //
 // wire integrates with other ApertureStack packages:
@@ -112,5 +193,5 @@
 //   - transport: Uses wire for protocol-specific message encoding
 //   - stream: Streaming responses use wire for event encoding
 //   - discover: Tool lists encoded via EncodeToolList/DecodeToolList
-//   - content: Response content types map to wire.Content
+//   - content: Response content types map to wire.Content ([FromContent], [ToContent])
 package wire
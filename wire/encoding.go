@@ -0,0 +1,280 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Media types for wire message encodings.
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeCBOR    = "application/cbor"
+	MediaTypeMsgPack = "application/vnd.msgpack"
+)
+
+// maxEncodingDepth bounds nesting when encoding or decoding binary
+// values, so cyclic maps and hostile payloads fail instead of
+// exhausting the stack.
+const maxEncodingDepth = 512
+
+// Encoding serializes generic message values, the types produced by
+// decoding JSON into an any, to bytes.
+//
+// Marshal accepts nil, bool, strings, []byte, Go numeric types,
+// json.Number, []any and map[string]any; other values are first
+// normalized through encoding/json. Unmarshal returns the same types
+// encoding/json does, so numbers are float64, plus []byte for binary
+// strings in encodings that have them.
+//
+// Contract:
+//   - Concurrency: Implementations are stateless and safe for concurrent use.
+//   - Determinism: Map keys are written in sorted order, so equal values
+//     encode to identical bytes.
+//   - Errors: Marshal wraps ErrEncodeFailure and Unmarshal wraps
+//     ErrDecodeFailure.
+type Encoding interface {
+	// Name returns the encoding identifier (e.g., "cbor").
+	Name() string
+
+	// ContentType returns the media type of encoded messages.
+	ContentType() string
+
+	// Marshal encodes v.
+	Marshal(v any) ([]byte, error)
+
+	// Unmarshal decodes one value that spans all of data.
+	Unmarshal(data []byte) (any, error)
+}
+
+var (
+	// EncodingJSON encodes values as JSON. Byte strings become base64.
+	EncodingJSON Encoding = jsonEncoding{}
+
+	// EncodingCBOR encodes values as CBOR (RFC 8949).
+	EncodingCBOR Encoding = cborEncoding{}
+
+	// EncodingMsgPack encodes values as MessagePack.
+	EncodingMsgPack Encoding = msgpackEncoding{}
+)
+
+// encodingsByMediaType maps media types, including common aliases, to
+// encodings.
+var encodingsByMediaType = map[string]Encoding{
+	MediaTypeJSON:           EncodingJSON,
+	MediaTypeCBOR:           EncodingCBOR,
+	MediaTypeMsgPack:        EncodingMsgPack,
+	"application/msgpack":   EncodingMsgPack,
+	"application/x-msgpack": EncodingMsgPack,
+}
+
+// EncodingFor returns the encoding for a Content-Type header value.
+// Parameters are ignored, and structured syntax suffixes ("+json",
+// "+cbor") select their base encoding. Unknown media types return
+// ErrUnsupportedFormat.
+func EncodingFor(contentType string) (Encoding, error) {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: content type %q: %w", ErrUnsupportedFormat, contentType, err)
+	}
+	if enc := encodingsByMediaType[mt]; enc != nil {
+		return enc, nil
+	}
+	switch {
+	case strings.HasSuffix(mt, "+json"):
+		return EncodingJSON, nil
+	case strings.HasSuffix(mt, "+cbor"):
+		return EncodingCBOR, nil
+	}
+	return nil, fmt.Errorf("%w: content type %q", ErrUnsupportedFormat, mt)
+}
+
+// NegotiateEncoding selects the encoding for a response from an Accept
+// header value. The supported media type with the highest quality wins,
+// earlier entries breaking ties; wildcards select JSON. An empty header,
+// or one that accepts nothing supported, selects JSON.
+func NegotiateEncoding(accept string) Encoding {
+	best, bestQ := EncodingJSON, -1.0
+	for entry := range strings.SplitSeq(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(entry))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		if q <= 0 || q <= bestQ {
+			continue
+		}
+		var enc Encoding
+		switch mt {
+		case "*/*", "application/*":
+			enc = EncodingJSON
+		default:
+			enc, _ = EncodingFor(mt)
+		}
+		if enc != nil {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// WireFor returns the Wire for messages of the given content type: jsonWire
+// for JSON, and a BinaryWire for binary encodings.
+func WireFor(contentType string, jsonWire Wire) (Wire, error) {
+	enc, err := EncodingFor(contentType)
+	if err != nil {
+		return nil, err
+	}
+	if enc == EncodingJSON {
+		return jsonWire, nil
+	}
+	return NewBinary(enc), nil
+}
+
+type jsonEncoding struct{}
+
+func (jsonEncoding) Name() string        { return "json" }
+func (jsonEncoding) ContentType() string { return MediaTypeJSON }
+
+func (jsonEncoding) Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEncodeFailure, err)
+	}
+	return data, nil
+}
+
+func (jsonEncoding) Unmarshal(data []byte) (any, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecodeFailure, err)
+	}
+	return v, nil
+}
+
+// valueWriter appends the primitives of a binary encoding.
+type valueWriter interface {
+	writeNil()
+	writeBool(b bool)
+	writeInt(n int64)
+	writeUint(n uint64)
+	writeFloat(f float64)
+	writeString(s string)
+	writeBytes(b []byte)
+	writeArrayHeader(n int)
+	writeMapHeader(n int)
+}
+
+// writeValue writes v with w, normalizing types as Encoding documents.
+func writeValue(w valueWriter, v any, depth int) error {
+	if depth > maxEncodingDepth {
+		return fmt.Errorf("nesting exceeds %d levels", maxEncodingDepth)
+	}
+	switch x := v.(type) {
+	case nil:
+		w.writeNil()
+	case bool:
+		w.writeBool(x)
+	case string:
+		w.writeString(x)
+	case []byte:
+		w.writeBytes(x)
+	case int:
+		w.writeInt(int64(x))
+	case int8:
+		w.writeInt(int64(x))
+	case int16:
+		w.writeInt(int64(x))
+	case int32:
+		w.writeInt(int64(x))
+	case int64:
+		w.writeInt(x)
+	case uint:
+		w.writeUint(uint64(x))
+	case uint8:
+		w.writeUint(uint64(x))
+	case uint16:
+		w.writeUint(uint64(x))
+	case uint32:
+		w.writeUint(uint64(x))
+	case uint64:
+		w.writeUint(x)
+	case float32:
+		writeNumber(w, float64(x))
+	case float64:
+		writeNumber(w, x)
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			w.writeInt(n)
+			return nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return fmt.Errorf("number %s: %w", x, err)
+		}
+		writeNumber(w, f)
+	case []any:
+		w.writeArrayHeader(len(x))
+		for _, item := range x {
+			if err := writeValue(w, item, depth+1); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		// Shorter keys first, then bytewise: the RFC 8949 deterministic
+		// order for text keys, used for MessagePack too.
+		slices.SortFunc(keys, func(a, b string) int {
+			if len(a) != len(b) {
+				return len(a) - len(b)
+			}
+			return strings.Compare(a, b)
+		})
+		w.writeMapHeader(len(keys))
+		for _, k := range keys {
+			w.writeString(k)
+			if err := writeValue(w, x[k], depth+1); err != nil {
+				return err
+			}
+		}
+	default:
+		normalized, err := jsonValue(v)
+		if err != nil {
+			return err
+		}
+		return writeValue(w, normalized, depth)
+	}
+	return nil
+}
+
+// writeNumber writes integral values as integers, the smallest
+// encoding, and others as floats.
+func writeNumber(w valueWriter, f float64) {
+	negZero := f == 0 && math.Signbit(f)
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !negZero {
+		w.writeInt(int64(f))
+		return
+	}
+	w.writeFloat(f)
+}
+
+// malformedError marks an error decoding a payload that is not valid
+// CBOR or MessagePack, which FromError reports as a parse error.
+type malformedError struct {
+	err error
+}
+
+func (e malformedError) Error() string { return e.err.Error() }
+func (e malformedError) Unwrap() error { return e.err }
@@ -0,0 +1,289 @@
+package wire
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCBOR_Vectors(t *testing.T) {
+	// Encodings from RFC 8949 Appendix A.
+	tests := []struct {
+		value any
+		hex   string
+	}{
+		{0, "00"},
+		{23, "17"},
+		{24, "1818"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{uint64(18446744073709551615), "1bffffffffffffffff"},
+		{-1, "20"},
+		{-1000, "3903e7"},
+		{1.5, "fa3fc00000"}, // float32; half precision is not emitted
+		{1.1, "fb3ff199999999999a"},
+		{100000.0, "1a000186a0"},
+		{false, "f4"},
+		{true, "f5"},
+		{nil, "f6"},
+		{"", "60"},
+		{"IETF", "6449455446"},
+		{"ü", "62c3bc"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{[]any{}, "80"},
+		{[]any{1, []any{2, 3}}, "8201820203"},
+		{map[string]any{"a": 1, "b": []any{2, 3}}, "a26161016162820203"},
+		{map[string]any{"aa": 1, "b": 2}, "a261620262616101"},
+	}
+	for _, tt := range tests {
+		got, err := EncodingCBOR.Marshal(tt.value)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error: %v", tt.value, err)
+		}
+		if hex.EncodeToString(got) != tt.hex {
+			t.Errorf("Marshal(%v) = %x, want %s", tt.value, got, tt.hex)
+		}
+	}
+}
+
+func TestCBOR_DecodeVectors(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want any
+	}{
+		{"3bffffffffffffffff", -18446744073709551616.0},
+		{"f90000", 0.0},
+		{"f93c00", 1.0},
+		{"f97bff", 65504.0},
+		{"f90001", 5.960464477539063e-8},
+		{"f9c400", -4.0},
+		{"f97c00", math.Inf(1)},
+		{"f7", nil},
+		{"c074323031332d30332d32315432303a30343a30305a", "2013-03-21T20:04:00Z"},
+		{"5f42010243030405ff", []byte{1, 2, 3, 4, 5}},
+		{"7f657374726561646d696e67ff", "streaming"},
+		{"9f018202039f0405ffff", []any{1.0, []any{2.0, 3.0}, []any{4.0, 5.0}}},
+		{"bf61610161629f0203ffff", map[string]any{"a": 1.0, "b": []any{2.0, 3.0}}},
+	}
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.hex)
+		got, err := EncodingCBOR.Unmarshal(data)
+		if err != nil {
+			t.Fatalf("Unmarshal(%s) error: %v", tt.hex, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Unmarshal(%s) = %#v, want %#v", tt.hex, got, tt.want)
+		}
+	}
+}
+
+func TestMsgPack_Vectors(t *testing.T) {
+	tests := []struct {
+		value any
+		hex   string
+	}{
+		{0, "00"},
+		{127, "7f"},
+		{128, "cc80"},
+		{65536, "ce00010000"},
+		{-1, "ff"},
+		{-32, "e0"},
+		{-33, "d0df"},
+		{-129, "d1ff7f"},
+		{1.5, "ca3fc00000"},
+		{1.1, "cb3ff199999999999a"},
+		{nil, "c0"},
+		{true, "c3"},
+		{"abc", "a3616263"},
+		{strings.Repeat("x", 32), "d920" + strings.Repeat("78", 32)},
+		{[]byte{1, 2}, "c4020102"},
+		{[]any{1, "a"}, "9201a161"},
+		{map[string]any{"b": 2, "a": 1}, "82a16101a16202"},
+	}
+	for _, tt := range tests {
+		got, err := EncodingMsgPack.Marshal(tt.value)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error: %v", tt.value, err)
+		}
+		if hex.EncodeToString(got) != tt.hex {
+			t.Errorf("Marshal(%v) = %x, want %s", tt.value, got, tt.hex)
+		}
+	}
+}
+
+func TestEncoding_RoundTrip(t *testing.T) {
+	value := map[string]any{
+		"text":   "héllo",
+		"int":    42.0,
+		"neg":    -70000.0,
+		"big":    float64(1 << 40),
+		"float":  3.14159,
+		"bool":   true,
+		"null":   nil,
+		"bytes":  []byte{0, 1, 2, 255},
+		"list":   []any{1.0, "two", []any{}, map[string]any{}},
+		"nested": map[string]any{"deep": map[string]any{"x": -0.5}},
+		"long":   strings.Repeat("long string ", 30),
+		"many":   make([]any, 70000),
+	}
+	for _, enc := range []Encoding{EncodingCBOR, EncodingMsgPack} {
+		t.Run(enc.Name(), func(t *testing.T) {
+			data, err := enc.Marshal(value)
+			if err != nil {
+				t.Fatalf("Marshal error: %v", err)
+			}
+			got, err := enc.Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(got, value) {
+				t.Error("round trip changed the value")
+			}
+			again, _ := enc.Marshal(got)
+			if string(again) != string(data) {
+				t.Error("re-encoding is not byte-identical")
+			}
+		})
+	}
+}
+
+func TestEncoding_Normalizes(t *testing.T) {
+	type point struct {
+		X int `json:"x"`
+	}
+	value := map[string]any{
+		"number": json.Number("12"),
+		"uint8":  uint8(7),
+		"float":  float32(0.5),
+		"struct": point{X: 3},
+		"list":   []string{"a"},
+	}
+	want := map[string]any{
+		"number": 12.0,
+		"uint8":  7.0,
+		"float":  0.5,
+		"struct": map[string]any{"x": 3.0},
+		"list":   []any{"a"},
+	}
+	for _, enc := range []Encoding{EncodingJSON, EncodingCBOR, EncodingMsgPack} {
+		data, err := enc.Marshal(value)
+		if err != nil {
+			t.Fatalf("%s Marshal error: %v", enc.Name(), err)
+		}
+		got, _ := enc.Unmarshal(data)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s round trip = %#v, want %#v", enc.Name(), got, want)
+		}
+	}
+}
+
+func TestEncoding_Errors(t *testing.T) {
+	cyclic := map[string]any{}
+	cyclic["self"] = cyclic
+
+	tests := []struct {
+		enc  Encoding
+		hex  string
+		name string
+	}{
+		{EncodingCBOR, "", "empty"},
+		{EncodingCBOR, "1a0001", "truncated argument"},
+		{EncodingCBOR, "6449", "truncated string"},
+		{EncodingCBOR, "0000", "trailing data"},
+		{EncodingCBOR, "9bffffffffffffffff", "huge array"},
+		{EncodingCBOR, "a10102", "integer key"},
+		{EncodingCBOR, "62c328", "invalid UTF-8"},
+		{EncodingCBOR, "ff", "stray break"},
+		{EncodingCBOR, "8201ff", "break in definite array"},
+		{EncodingCBOR, "1c", "reserved info"},
+		{EncodingCBOR, "5f6161ff", "mixed chunk"},
+		{EncodingCBOR, strings.Repeat("81", maxEncodingDepth+2) + "00", "too deep"},
+		{EncodingMsgPack, "", "empty"},
+		{EncodingMsgPack, "cd00", "truncated integer"},
+		{EncodingMsgPack, "a3ab", "truncated string"},
+		{EncodingMsgPack, "dfffffffff", "huge map"},
+		{EncodingMsgPack, "810102", "integer key"},
+		{EncodingMsgPack, "d40100", "extension"},
+		{EncodingMsgPack, "c1", "never used"},
+		{EncodingMsgPack, strings.Repeat("91", maxEncodingDepth+2) + "00", "too deep"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.enc.Name()+"/"+tt.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.hex)
+			if _, err := tt.enc.Unmarshal(data); !errors.Is(err, ErrDecodeFailure) {
+				t.Errorf("Unmarshal(%s) error = %v, want ErrDecodeFailure", tt.hex, err)
+			}
+		})
+	}
+	for _, enc := range []Encoding{EncodingJSON, EncodingCBOR, EncodingMsgPack} {
+		if _, err := enc.Marshal(cyclic); !errors.Is(err, ErrEncodeFailure) {
+			t.Errorf("%s Marshal(cyclic) error = %v, want ErrEncodeFailure", enc.Name(), err)
+		}
+		if _, err := enc.Marshal(make(chan int)); !errors.Is(err, ErrEncodeFailure) {
+			t.Errorf("%s Marshal(chan) error = %v, want ErrEncodeFailure", enc.Name(), err)
+		}
+	}
+}
+
+func TestEncodingFor(t *testing.T) {
+	tests := map[string]Encoding{
+		"application/json":                EncodingJSON,
+		"application/json; charset=utf-8": EncodingJSON,
+		"application/vnd.api+json":        EncodingJSON,
+		"application/cbor":                EncodingCBOR,
+		"application/cose+cbor":           EncodingCBOR,
+		"application/vnd.msgpack":         EncodingMsgPack,
+		"Application/MsgPack":             EncodingMsgPack,
+		"application/x-msgpack":           EncodingMsgPack,
+	}
+	for ct, want := range tests {
+		got, err := EncodingFor(ct)
+		if err != nil || got != want {
+			t.Errorf("EncodingFor(%q) = %v, %v, want %s", ct, got, err, want.Name())
+		}
+	}
+	for _, ct := range []string{"text/plain", "application/xml", "", ";;"} {
+		if _, err := EncodingFor(ct); !errors.Is(err, ErrUnsupportedFormat) {
+			t.Errorf("EncodingFor(%q) error = %v, want ErrUnsupportedFormat", ct, err)
+		}
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := map[string]Encoding{
+		"":                                   EncodingJSON,
+		"application/cbor":                   EncodingCBOR,
+		"application/cbor, application/json": EncodingCBOR,
+		"application/json;q=0.5, application/cbor":      EncodingCBOR,
+		"application/cbor;q=0.5, application/json":      EncodingJSON,
+		"application/msgpack;q=0.9, */*;q=0.1":          EncodingMsgPack,
+		"text/html, */*":                                EncodingJSON,
+		"text/html":                                     EncodingJSON,
+		"application/cbor;q=0":                          EncodingJSON,
+		"application/cbor;q=x, application/vnd.msgpack": EncodingMsgPack,
+	}
+	for accept, want := range tests {
+		if got := NegotiateEncoding(accept); got != want {
+			t.Errorf("NegotiateEncoding(%q) = %s, want %s", accept, got.Name(), want.Name())
+		}
+	}
+}
+
+func TestWireFor(t *testing.T) {
+	mcp := NewMCP()
+	w, err := WireFor("application/json", mcp)
+	if err != nil || w != Wire(mcp) {
+		t.Errorf("WireFor(json) = %v, %v, want the JSON wire", w, err)
+	}
+	w, err = WireFor("application/cbor", mcp)
+	if err != nil || w.Name() != "cbor" {
+		t.Errorf("WireFor(cbor) = %v, %v, want cbor", w, err)
+	}
+	if _, err := WireFor("text/plain", mcp); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("WireFor(text/plain) error = %v, want ErrUnsupportedFormat", err)
+	}
+}
@@ -0,0 +1,222 @@
+package wire
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/jonwraymond/toolprotocol/elicit"
+	"github.com/jonwraymond/toolprotocol/prompt"
+	"github.com/jonwraymond/toolprotocol/resource"
+	"github.com/jonwraymond/toolprotocol/task"
+)
+
+// JSON-RPC 2.0 standard error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// CodeRequestCancelled reports a request its peer cancelled, as in the
+// Language Server Protocol.
+const CodeRequestCancelled = -32800
+
+// MCP error codes. CodeRequestTimeout is the code the MCP SDKs use for
+// requests that time out.
+const (
+	CodeRequestTimeout         = -32001
+	CodeResourceNotFound       = -32002
+	CodeURLElicitationRequired = -32042
+)
+
+// A2A error codes.
+//
+// Codes in the server-defined range are protocol-scoped: A2A's
+// CodeTaskNotCancelable and MCP's CodeResourceNotFound share -32002, so
+// their errors carry a Protocol and do not match each other.
+const (
+	CodeTaskNotFound                 = -32001
+	CodeTaskNotCancelable            = -32002
+	CodePushNotificationNotSupported = -32003
+	CodeUnsupportedOperation         = -32004
+	CodeContentTypeNotSupported      = -32005
+)
+
+// Protocol errors for use with errors.Is. An *Error matches any of these
+// when the codes are equal, whatever its message or data, and for
+// server-defined codes when its Protocol is that of the error.
+//
+// These values are shared and must not be modified; use NewError or
+// NewProtocolError to build an error to send.
+var (
+	// ErrParse reports invalid JSON.
+	ErrParse = &Error{Code: CodeParseError, Message: "Parse error"}
+
+	// ErrInvalidRequest reports a message that is not a valid request.
+	ErrInvalidRequest = &Error{Code: CodeInvalidRequest, Message: "Invalid Request"}
+
+	// ErrMethodNotFound reports an unknown or unavailable method.
+	ErrMethodNotFound = &Error{Code: CodeMethodNotFound, Message: "Method not found"}
+
+	// ErrInvalidParams reports invalid method parameters.
+	ErrInvalidParams = &Error{Code: CodeInvalidParams, Message: "Invalid params"}
+
+	// ErrInternal reports an internal server error.
+	ErrInternal = &Error{Code: CodeInternalError, Message: "Internal error"}
+
+	// ErrRequestTimeout reports an MCP request that timed out.
+	ErrRequestTimeout = &Error{Code: CodeRequestTimeout, Message: "Request timed out", Protocol: "mcp"}
+
+	// ErrResourceNotFound reports an unknown MCP resource.
+	ErrResourceNotFound = &Error{Code: CodeResourceNotFound, Message: "Resource not found", Protocol: "mcp"}
+
+	// ErrURLElicitationRequired reports that the MCP request needs a URL
+	// mode elicitation to complete first.
+	ErrURLElicitationRequired = &Error{Code: CodeURLElicitationRequired, Message: "URL elicitation required", Protocol: "mcp"}
+
+	// ErrTaskNotFound reports an unknown or expired A2A task.
+	ErrTaskNotFound = &Error{Code: CodeTaskNotFound, Message: "Task not found", Protocol: "a2a"}
+
+	// ErrTaskNotCancelable reports an A2A task that can no longer be canceled.
+	ErrTaskNotCancelable = &Error{Code: CodeTaskNotCancelable, Message: "Task cannot be canceled", Protocol: "a2a"}
+
+	// ErrPushNotificationNotSupported reports that the A2A agent does not
+	// support push notifications.
+	ErrPushNotificationNotSupported = &Error{Code: CodePushNotificationNotSupported, Message: "Push Notification is not supported", Protocol: "a2a"}
+
+	// ErrUnsupportedOperation reports an A2A operation the agent does not support.
+	ErrUnsupportedOperation = &Error{Code: CodeUnsupportedOperation, Message: "This operation is not supported", Protocol: "a2a"}
+
+	// ErrContentTypeNotSupported reports incompatible A2A content types.
+	ErrContentTypeNotSupported = &Error{Code: CodeContentTypeNotSupported, Message: "Incompatible content types", Protocol: "a2a"}
+)
+
+// NewError returns a protocol error with the given code and message. The
+// error is not scoped to a protocol.
+func NewError(code int, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// NewProtocolError returns a protocol error with the given code and
+// message, scoped to protocol. Use it for the server-defined codes a
+// protocol assigns, such as A2A's CodeTaskNotCancelable.
+func NewProtocolError(protocol string, code int, message string) *Error {
+	return &Error{Code: code, Message: message, Protocol: protocol}
+}
+
+// FromError maps a Go error to a protocol error.
+//
+// An *Error anywhere in err's chain is returned as is. Sentinel errors
+// from the task, resource, prompt, elicit and wire packages map to the
+// matching code with err's text as the message, and err remains
+// reachable through errors.Is and errors.As. Codes a single protocol
+// defines, such as MCP's resource-not-found, are scoped to it. Malformed
+// JSON, CBOR or MessagePack is a parse error, while other decode
+// failures are invalid requests. A *ValidationError becomes an
+// invalid-params error whose data lists the violations. Anything else is
+// an internal error. FromError returns nil for a nil error.
+func FromError(err error) *Error {
+	return fromError("", err)
+}
+
+// fromError is FromError for errors sent over protocol, or over any
+// protocol when it is empty.
+func fromError(protocol string, err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	code, scope := errorCode(protocol, err)
+	e = &Error{Code: code, Message: err.Error(), Protocol: scope, cause: err}
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		e.Data = map[string]any{"violations": verr.Violations}
+	}
+	return e
+}
+
+// FromErrorFor maps a Go error to a protocol error to send over the
+// named protocol. It is FromError, except that errors the protocol
+// reports with a standard code map to it, as unknown tasks map to
+// invalid params for MCP, and an error scoped to another protocol
+// becomes an internal error, since its code would mean something else
+// to the peer; the original stays in the chain.
+func FromErrorFor(protocol string, err error) *Error {
+	e := fromError(protocol, err)
+	if e == nil || e.Protocol == "" || e.Protocol == protocol {
+		return e
+	}
+	return &Error{Code: CodeInternalError, Message: e.Message, Data: e.Data, cause: err}
+}
+
+// decodedError builds an error decoded from a protocol's response.
+// Codes in the JSON-RPC server-defined range, -32099 to -32000, are
+// scoped to the protocol.
+func decodedError(protocol string, code int, message string, data any) *Error {
+	e := &Error{Code: code, Message: message, Data: data}
+	if serverDefined(code) {
+		e.Protocol = protocol
+	}
+	return e
+}
+
+// serverDefined reports whether code is in the JSON-RPC server-defined
+// range, whose codes each protocol assigns independently.
+func serverDefined(code int) bool {
+	return code >= -32099 && code <= -32000
+}
+
+// errorCode returns the protocol error code for a Go error sent over
+// protocol, or over any protocol when it is empty, and the protocol
+// that defines the code if it is scoped.
+func errorCode(protocol string, err error) (int, string) {
+	var (
+		syntaxErr    *json.SyntaxError
+		malformedErr malformedError
+	)
+	switch {
+	case errors.Is(err, task.ErrTaskNotFound):
+		if protocol == "mcp" {
+			// MCP reports unknown task IDs as invalid params.
+			return CodeInvalidParams, ""
+		}
+		return CodeTaskNotFound, "a2a"
+	case errors.Is(err, resource.ErrResourceNotFound),
+		errors.Is(err, resource.ErrProviderNotFound):
+		return CodeResourceNotFound, "mcp"
+	case errors.Is(err, elicit.ErrTimeout):
+		return CodeRequestTimeout, "mcp"
+	case errors.Is(err, elicit.ErrCancelled),
+		errors.Is(err, ErrRequestCancelled):
+		return CodeRequestCancelled, ""
+	case errors.As(err, &syntaxErr), errors.As(err, &malformedErr):
+		return CodeParseError, ""
+	case errors.Is(err, ErrDecodeFailure):
+		return CodeInvalidRequest, ""
+	case errors.Is(err, task.ErrEmptyID),
+		errors.Is(err, resource.ErrInvalidURI),
+		errors.Is(err, prompt.ErrPromptNotFound),
+		errors.Is(err, prompt.ErrMissingArgument),
+		errors.Is(err, prompt.ErrInvalidPrompt),
+		errors.Is(err, ErrInvalidArguments),
+		errors.Is(err, ErrInvalidCursor):
+		return CodeInvalidParams, ""
+	case errors.Is(err, task.ErrTaskExists),
+		errors.Is(err, task.ErrInvalidState),
+		errors.Is(err, task.ErrInvalidTransition),
+		errors.Is(err, resource.ErrNotSubscribed),
+		errors.Is(err, elicit.ErrInvalidRequest),
+		errors.Is(err, ErrUnsupportedVersion),
+		errors.Is(err, ErrUnsupportedFeature):
+		return CodeInvalidRequest, ""
+	case errors.Is(err, elicit.ErrNoHandler):
+		return CodeMethodNotFound, ""
+	default:
+		return CodeInternalError, ""
+	}
+}
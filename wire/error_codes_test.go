@@ -0,0 +1,172 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jonwraymond/toolprotocol/elicit"
+	"github.com/jonwraymond/toolprotocol/prompt"
+	"github.com/jonwraymond/toolprotocol/resource"
+	"github.com/jonwraymond/toolprotocol/task"
+)
+
+func TestError_Is(t *testing.T) {
+	err := fmt.Errorf("lookup: %w", NewProtocolError("a2a", CodeTaskNotFound, "no such task"))
+	if !errors.Is(err, ErrTaskNotFound) {
+		t.Error("errors.Is(err, ErrTaskNotFound) = false, want true")
+	}
+	if errors.Is(err, ErrInvalidParams) {
+		t.Error("errors.Is(err, ErrInvalidParams) = true, want false")
+	}
+	if errors.Is(err, errors.New("Task not found")) {
+		t.Error("errors.Is matched a non-*Error target")
+	}
+}
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"task not found", &task.TaskError{TaskID: "t-1", Op: "get", Err: task.ErrTaskNotFound}, CodeTaskNotFound},
+		{"task empty id", task.ErrEmptyID, CodeInvalidParams},
+		{"task transition", task.ErrInvalidTransition, CodeInvalidRequest},
+		{"resource not found", resource.ErrResourceNotFound, CodeResourceNotFound},
+		{"resource provider", resource.ErrProviderNotFound, CodeResourceNotFound},
+		{"resource uri", resource.ErrInvalidURI, CodeInvalidParams},
+		{"prompt not found", prompt.ErrPromptNotFound, CodeInvalidParams},
+		{"prompt argument", fmt.Errorf("%w: topic", prompt.ErrMissingArgument), CodeInvalidParams},
+		{"prompt handler", prompt.ErrHandlerFailed, CodeInternalError},
+		{"elicit request", elicit.ErrInvalidRequest, CodeInvalidRequest},
+		{"elicit handler", elicit.ErrNoHandler, CodeMethodNotFound},
+		{"elicit timeout", elicit.ErrTimeout, CodeRequestTimeout},
+		{"elicit cancelled", elicit.ErrCancelled, CodeRequestCancelled},
+		{"request cancelled", ErrRequestCancelled, CodeRequestCancelled},
+		{"decode", fmt.Errorf("%w: missing params", ErrDecodeFailure), CodeInvalidRequest},
+		{"json syntax", jsonSyntaxError(), CodeParseError},
+		{"cbor syntax", cborSyntaxError(), CodeParseError},
+		{"feature", ErrUnsupportedFeature, CodeInvalidRequest},
+		{"context", context.Canceled, CodeInternalError},
+		{"other", errors.New("boom"), CodeInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromError(tt.err)
+			if got.Code != tt.want {
+				t.Errorf("Code = %d, want %d", got.Code, tt.want)
+			}
+			if got.Message != tt.err.Error() {
+				t.Errorf("Message = %q, want %q", got.Message, tt.err.Error())
+			}
+			if !errors.Is(got, tt.err) {
+				t.Error("mapped error does not wrap the original")
+			}
+		})
+	}
+}
+
+func jsonSyntaxError() error {
+	_, err := NewMCP().DecodeRequest(context.Background(), []byte(`{"jsonrpc":`))
+	return err
+}
+
+func cborSyntaxError() error {
+	_, err := NewBinary(EncodingCBOR).DecodeRequest(context.Background(), []byte{0xff})
+	return err
+}
+
+func TestError_IsProtocolScoped(t *testing.T) {
+	if errors.Is(ErrResourceNotFound, ErrTaskNotCancelable) || errors.Is(ErrTaskNotCancelable, ErrResourceNotFound) {
+		t.Error("ErrResourceNotFound and ErrTaskNotCancelable match")
+	}
+	if errors.Is(FromError(resource.ErrResourceNotFound), ErrTaskNotCancelable) {
+		t.Error("mapped resource error matches ErrTaskNotCancelable")
+	}
+	if !errors.Is(FromError(resource.ErrResourceNotFound), ErrResourceNotFound) {
+		t.Error("mapped resource error does not match ErrResourceNotFound")
+	}
+	// An unscoped server-defined code, such as one from NewError, is
+	// neither protocol's error.
+	unscoped := NewError(CodeTaskNotCancelable, "busy")
+	if errors.Is(unscoped, ErrTaskNotCancelable) || errors.Is(unscoped, ErrResourceNotFound) {
+		t.Error("unscoped -32002 matches a scoped sentinel")
+	}
+	if !errors.Is(unscoped, NewError(CodeTaskNotCancelable, "")) {
+		t.Error("unscoped -32002 does not match another unscoped -32002")
+	}
+	if !errors.Is(NewProtocolError("a2a", CodeTaskNotCancelable, "busy"), ErrTaskNotCancelable) {
+		t.Error("NewProtocolError(a2a) does not match ErrTaskNotCancelable")
+	}
+	// Standard codes mean the same in every protocol.
+	if !errors.Is(&Error{Code: CodeInvalidParams, Protocol: "mcp"}, ErrInvalidParams) {
+		t.Error("scoped invalid params does not match ErrInvalidParams")
+	}
+}
+
+func TestFromErrorFor(t *testing.T) {
+	if got := FromErrorFor("a2a", resource.ErrResourceNotFound); got.Code != CodeInternalError || got.Protocol != "" {
+		t.Errorf("FromErrorFor(a2a, resource) = %+v, want unscoped internal error", got)
+	} else if !errors.Is(got, resource.ErrResourceNotFound) {
+		t.Error("FromErrorFor dropped the original error")
+	}
+	if got := FromErrorFor("mcp", resource.ErrResourceNotFound); !errors.Is(got, ErrResourceNotFound) {
+		t.Errorf("FromErrorFor(mcp, resource) = %v, want ErrResourceNotFound", got)
+	}
+	if got := FromErrorFor("mcp", prompt.ErrPromptNotFound); got.Code != CodeInvalidParams {
+		t.Errorf("FromErrorFor(mcp, prompt) = %v, want invalid params", got)
+	}
+	if got := FromErrorFor("mcp", task.ErrTaskNotFound); got.Code != CodeInvalidParams || got.Protocol != "" {
+		t.Errorf("FromErrorFor(mcp, task) = %+v, want unscoped invalid params", got)
+	} else if !errors.Is(got, task.ErrTaskNotFound) {
+		t.Error("FromErrorFor(mcp, task) dropped the original error")
+	}
+	if got := FromErrorFor("a2a", task.ErrTaskNotFound); !errors.Is(got, ErrTaskNotFound) {
+		t.Errorf("FromErrorFor(a2a, task) = %+v, want ErrTaskNotFound", got)
+	}
+	if FromErrorFor("a2a", nil) != nil {
+		t.Error("FromErrorFor(a2a, nil) != nil")
+	}
+}
+
+func TestFromError_Passthrough(t *testing.T) {
+	if FromError(nil) != nil {
+		t.Error("FromError(nil) != nil")
+	}
+	orig := NewError(CodeContentTypeNotSupported, "text/csv")
+	if got := FromError(fmt.Errorf("send: %w", orig)); got != orig {
+		t.Errorf("FromError = %v, want the wrapped *Error", got)
+	}
+}
+
+func TestError_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		w    Wire
+		err  error
+		want *Error
+	}{
+		{NewMCP(), resource.ErrResourceNotFound, ErrResourceNotFound},
+		{NewA2A(), task.ErrTaskNotFound, ErrTaskNotFound},
+		{NewACP(), prompt.ErrPromptNotFound, ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		w := tt.w
+		t.Run(w.Name(), func(t *testing.T) {
+			resp := &Response{ID: "1", IsError: true, Error: FromErrorFor(w.Name(), tt.err)}
+			data, err := w.EncodeResponse(ctx, resp)
+			if err != nil {
+				t.Fatalf("EncodeResponse error: %v", err)
+			}
+			got, err := w.DecodeResponse(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeResponse error: %v", err)
+			}
+			if !errors.Is(got.Error, tt.want) || got.Error.Protocol != tt.want.Protocol {
+				t.Errorf("decoded error = %+v, want %+v", got.Error, tt.want)
+			}
+		})
+	}
+}
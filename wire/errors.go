@@ -13,4 +13,43 @@ var (
 
 	// ErrDecodeFailure is returned when decoding fails.
 	ErrDecodeFailure = errors.New("wire: decode failed")
+
+	// ErrTranslateFailure is returned when a message cannot be translated
+	// between wire formats.
+	ErrTranslateFailure = errors.New("wire: translate failed")
+
+	// ErrMessageTooLarge is returned when a framed message exceeds the
+	// configured maximum size.
+	ErrMessageTooLarge = errors.New("wire: message too large")
+
+	// ErrUnsupportedVersion is returned when a protocol version is unknown
+	// or cannot be negotiated.
+	ErrUnsupportedVersion = errors.New("wire: unsupported version")
+
+	// ErrUnsupportedFeature is returned when a message uses a feature the
+	// codec's protocol version does not support.
+	ErrUnsupportedFeature = errors.New("wire: unsupported feature")
+
+	// ErrInvalidSchema is returned when a JSON Schema is malformed or uses
+	// an unsupported reference.
+	ErrInvalidSchema = errors.New("wire: invalid schema")
+
+	// ErrInvalidArguments is returned when request arguments do not satisfy
+	// a tool's input schema.
+	ErrInvalidArguments = errors.New("wire: invalid arguments")
+
+	// ErrRequestCancelled is the context cause for requests cancelled by a
+	// cancellation notification.
+	ErrRequestCancelled = errors.New("wire: request cancelled")
+
+	// ErrInvalidCursor is returned when a pagination cursor is malformed,
+	// forged, or was issued for a different list.
+	ErrInvalidCursor = errors.New("wire: invalid cursor")
+
+	// ErrInvalidPath is returned when a redaction path is malformed.
+	ErrInvalidPath = errors.New("wire: invalid path")
+
+	// ErrInvalidTraceContext is returned when a traceparent or baggage
+	// value is malformed.
+	ErrInvalidTraceContext = errors.New("wire: invalid trace context")
 )
@@ -2,8 +2,12 @@ package wire_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/jonwraymond/toolprotocol/content"
+	"github.com/jonwraymond/toolprotocol/task"
 	"github.com/jonwraymond/toolprotocol/wire"
 )
 
@@ -338,6 +342,55 @@ func ExampleA2AWire_Capabilities() {
 	// Cancellation: true
 }
 
+func ExampleA2AWire_EncodeMessageSend() {
+	w := wire.NewA2A()
+	ctx := context.Background()
+
+	data, _ := w.EncodeMessageSend(ctx, "1", &wire.A2AMessageSendParams{
+		Message: wire.A2AMessage{
+			MessageID: "msg-1",
+			Role:      wire.A2ARoleUser,
+			Parts:     []wire.A2APart{{Kind: wire.A2APartText, Text: "hello"}},
+		},
+	})
+	fmt.Println(string(data))
+	// Output:
+	// {"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"kind":"message","messageId":"msg-1","role":"user","parts":[{"kind":"text","text":"hello"}]}}}
+}
+
+func ExampleA2AWire_DecodeResult() {
+	w := wire.NewA2A()
+	ctx := context.Background()
+
+	data := []byte(`{"jsonrpc":"2.0","id":"1","result":{"kind":"status-update","taskId":"t1","contextId":"c1","status":{"state":"completed"},"final":true}}`)
+	_, result, _ := w.DecodeResult(ctx, data)
+
+	if ev, ok := result.(*wire.A2ATaskStatusUpdateEvent); ok {
+		fmt.Println("Task:", ev.TaskID)
+		fmt.Println("State:", ev.Status.State)
+		fmt.Println("Terminal:", ev.Status.State.IsTerminal())
+	}
+	// Output:
+	// Task: t1
+	// State: completed
+	// Terminal: true
+}
+
+func ExampleACPWire_EncodeSessionUpdate() {
+	w := wire.NewACP()
+	ctx := context.Background()
+
+	data, _ := w.EncodeSessionUpdate(ctx, &wire.ACPSessionNotification{
+		SessionID: "sess-1",
+		Update: &wire.ACPMessageChunk{
+			Content: wire.ACPContentBlock{Type: wire.ACPContentText, Text: "Looking at the code..."},
+		},
+	})
+	fmt.Println(string(data))
+	// Output:
+	// {"jsonrpc":"2.0","method":"session/update","params":{"sessionId":"sess-1","update":{"sessionUpdate":"agent_message_chunk","content":{"type":"text","text":"Looking at the code..."}}}}
+}
+
 func ExampleACPWire_Capabilities() {
 	w := wire.NewACP()
 	caps := w.Capabilities()
@@ -375,3 +428,329 @@ func ExampleError_Error_withData() {
 	// Output:
 	// Invalid params (code: -32602, data: missing required field: query)
 }
+
+func ExampleFromError() {
+	err := fmt.Errorf("get: %w", task.ErrTaskNotFound)
+
+	rpcErr := wire.FromError(err)
+	fmt.Println(rpcErr.Code)
+	fmt.Println(errors.Is(rpcErr, wire.ErrTaskNotFound))
+	fmt.Println(errors.Is(rpcErr, task.ErrTaskNotFound))
+	// Output:
+	// -32001
+	// true
+	// true
+}
+
+func ExampleValidateArguments() {
+	tool := wire.Tool{
+		Name: "search",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string"},
+				"limit": map[string]any{"type": "integer", "maximum": 100},
+			},
+			"required": []string{"query"},
+		},
+	}
+
+	err := wire.ValidateArguments(tool, map[string]any{"limit": 500})
+	fmt.Println(err)
+	fmt.Println(wire.FromError(err).Code)
+	// Output:
+	// wire: invalid arguments: (root): missing required property "query"; /limit: value 500 is greater than 100
+	// -32602
+}
+
+func ExampleDecodeArguments() {
+	type SearchArgs struct {
+		Query string `json:"query" jsonschema:"required,minLength=1"`
+		Limit int    `json:"limit,omitempty" jsonschema:"minimum=1,maximum=100"`
+	}
+
+	tool, _ := wire.NewTool[SearchArgs, struct{}]("search", "Search the index")
+	schema, _ := json.Marshal(tool.InputSchema)
+	fmt.Println(string(schema))
+
+	req := &wire.Request{ToolID: "search", Arguments: map[string]any{"query": "golang", "limit": 5}}
+	if err := wire.ValidateArguments(tool, req.Arguments); err != nil {
+		fmt.Println(err)
+		return
+	}
+	args, _ := wire.DecodeArguments[SearchArgs](req.Arguments)
+	fmt.Printf("%+v\n", args)
+	// Output:
+	// {"properties":{"limit":{"maximum":100,"minimum":1,"type":"integer"},"query":{"minLength":1,"type":"string"}},"required":["query"],"type":"object"}
+	// {Query:golang Limit:5}
+}
+
+func ExampleMCPWire_EncodeProgress() {
+	ctx := context.Background()
+	w := wire.NewMCP()
+
+	req := &wire.Request{
+		ID:     "1",
+		Method: "tools/call",
+		ToolID: "index",
+		Meta:   map[string]any{"progressToken": "idx-1"},
+	}
+	token, _ := wire.ProgressToken(req.Meta)
+
+	data, _ := w.EncodeProgress(ctx, &wire.Progress{Token: token, Progress: 50, Total: 200, Message: "scanning"})
+	fmt.Println(string(data))
+	// Output:
+	// {"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":"idx-1","progress":50,"total":200,"message":"scanning"}}
+}
+
+func ExampleCancellations() {
+	ctx := context.Background()
+	w := wire.NewMCP()
+	inflight := wire.NewCancellations()
+
+	reqCtx, release := inflight.Track(ctx, "42")
+	defer release()
+
+	note, _ := w.DecodeCancellation(ctx, []byte(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":42,"reason":"user aborted"}}`))
+	inflight.Cancel(note)
+
+	<-reqCtx.Done()
+	fmt.Println(context.Cause(reqCtx))
+	// Output:
+	// wire: request cancelled: user aborted
+}
+
+func ExampleTranslator_TranslateRequest() {
+	ctx := context.Background()
+	tr := wire.NewTranslator(wire.NewMCP(), wire.NewACP())
+
+	mcpReq := []byte(`{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"name":"search","arguments":{"query":"golang"}}}`)
+	out, err := tr.TranslateRequest(ctx, mcpReq)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println(string(out.Data))
+	fmt.Println("Lossless:", out.Lossless())
+	// Output:
+	// {"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"agentId":"search","input":{"query":"golang"}}}
+	// Lossless: true
+}
+
+func ExampleDetect() {
+	payload := []byte(`{"jsonrpc":"2.0","id":1,"method":"message/send","params":{"message":{"role":"user","parts":[{"kind":"text","text":"hi"}]}}}`)
+
+	det, err := wire.Detect(payload, nil)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println("Protocol:", det.Name)
+	fmt.Printf("Confidence: %.2f\n", det.Confidence)
+	// Output:
+	// Protocol: a2a
+	// Confidence: 0.92
+}
+
+func ExampleMCPHandshake() {
+	server := &wire.MCPHandshake{Supported: []string{wire.MCPVersion20250326, wire.MCPVersion20250618}}
+
+	// A client speaking a newer revision than the server.
+	params := &wire.MCPInitializeParams{ProtocolVersion: wire.MCPVersion20251125}
+
+	result, codec, err := server.Respond(params, wire.MCPImplementation{Name: "server", Version: "1.0"}, nil)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println("Negotiated:", result.ProtocolVersion)
+	fmt.Println("Structured output:", codec.Features().StructuredOutput)
+	// Output:
+	// Negotiated: 2025-06-18
+	// Structured output: true
+}
+
+func ExampleNewCanonical() {
+	w := wire.NewCanonical(wire.NewMCP())
+	ctx := context.Background()
+
+	data, _ := w.EncodeRequest(ctx, &wire.Request{
+		ID:        "1",
+		Method:    "tools/call",
+		ToolID:    "search",
+		Arguments: map[string]any{"query": "<golang>", "limit": 10.0},
+	})
+	fmt.Println(string(data))
+	// Output:
+	// {"id":"1","jsonrpc":"2.0","method":"tools/call","params":{"arguments":{"limit":10,"query":"<golang>"},"name":"search"}}
+}
+
+func ExamplePaginate() {
+	tools := []wire.Tool{{Name: "search"}, {Name: "fetch"}, {Name: "summarize"}}
+	pager := wire.Pager{Codec: wire.NewCursorCodec([]byte("server-secret")), Size: 2, Scope: "tools/list"}
+	name := func(t wire.Tool) string { return t.Name }
+
+	cursor := ""
+	for {
+		page, err := wire.Paginate(pager, tools, name, cursor)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+		for _, t := range page.Items {
+			fmt.Println(t.Name)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	// Output:
+	// fetch
+	// search
+	// summarize
+}
+
+func ExampleNegotiateEncoding() {
+	ctx := context.Background()
+
+	// A peer that accepts CBOR gets the binary codec.
+	enc := wire.NegotiateEncoding("application/cbor, application/json;q=0.5")
+	w, _ := wire.WireFor(enc.ContentType(), wire.NewMCP())
+
+	data, _ := w.EncodeResponse(ctx, &wire.Response{
+		ID:      "1",
+		Content: []wire.Content{{Type: wire.ContentTypeImage, MIMEType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}}},
+	})
+	resp, _ := w.DecodeResponse(ctx, data)
+
+	fmt.Println(w.Name(), enc.ContentType())
+	fmt.Printf("%d bytes, data %q\n", len(data), resp.Content[0].Data)
+	// Output:
+	// cbor application/cbor
+	// 56 bytes, data "\x89PNG"
+}
+
+func ExampleDecodeRawArguments() {
+	type searchArgs struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit"`
+	}
+	data := []byte(`{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"name":"search","arguments":{"query":"golang","limit":5}}}`)
+
+	raw, err := wire.NewMCP().DecodeRawRequest(context.Background(), data)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	args, err := wire.DecodeRawArguments[searchArgs](raw)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("%s %+v\n", raw.ToolID, args)
+	// Output:
+	// search {Query:golang Limit:5}
+}
+
+func ExampleIntercept() {
+	redactor, err := wire.NewRedactor("$.arguments.apiKey")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	w := wire.Intercept(wire.NewMCP(),
+		wire.LimitSize(1<<20),
+		wire.InjectMeta(map[string]any{"tenant": "acme"}),
+		wire.Redact(redactor),
+	)
+
+	data, _ := w.EncodeRequest(context.Background(), &wire.Request{
+		ID:        "1",
+		Method:    "tools/call",
+		ToolID:    "search",
+		Arguments: map[string]any{"apiKey": "sk-123"},
+	})
+	fmt.Println(string(data))
+	// Output:
+	// {"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"_meta":{"tenant":"acme"},"arguments":{"apiKey":"[REDACTED]"},"name":"search"}}
+}
+
+func ExampleExportSchemas() {
+	w := wire.NewMCP()
+	schemas, err := wire.ExportSchemas(w)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	request, err := wire.CompileSchema(schemas.Request)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	data, _ := w.EncodeRequest(context.Background(), &wire.Request{
+		ID:        "1",
+		Method:    "tools/call",
+		ToolID:    "search",
+		Arguments: map[string]any{"query": "golang"},
+	})
+	var msg any
+	_ = json.Unmarshal(data, &msg)
+	fmt.Println("Valid:", request.Validate(msg) == nil)
+
+	doc, _ := wire.OpenRPC(w, []wire.Tool{{Name: "search"}}, wire.OpenRPCInfo{Title: "Search"})
+	for _, m := range doc.Methods {
+		fmt.Println("Method:", m.Name)
+	}
+	// Output:
+	// Valid: true
+	// Method: tools/call
+	// Method: tools/list
+}
+
+func ExamplePropagateTrace() {
+	tc, err := wire.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	client := wire.Intercept(wire.NewMCP(), wire.PropagateTrace())
+	data, _ := client.EncodeRequest(wire.ContextWithTrace(context.Background(), tc), &wire.Request{
+		ID:     "1",
+		Method: "tools/call",
+		ToolID: "search",
+	})
+
+	// The server hands the caller's span to its handler.
+	req, _ := wire.NewMCP().DecodeRequest(context.Background(), data)
+	ctx := wire.ExtractTrace(context.Background(), req.Meta)
+	parent, _ := wire.TraceFromContext(ctx)
+	fmt.Println(parent.TraceID, parent.ParentID, parent.Flags.Sampled())
+	// Output:
+	// 4bf92f3577b34da6a3ce929d0e0e4736 00f067aa0ba902b7 true
+}
+
+func ExampleNewResponse() {
+	builder := content.NewBuilder()
+	resp, err := wire.NewResponse("1",
+		builder.Text("Here is the chart"),
+		builder.ImageWithAlt([]byte{0x89, 'P', 'N', 'G'}, "image/png", "Monthly sales"),
+	)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	w := wire.NewBinary(wire.EncodingCBOR)
+	data, _ := w.EncodeResponse(context.Background(), resp)
+
+	decoded, _ := w.DecodeResponse(context.Background(), data)
+	parts, _ := decoded.Parts()
+	img := parts[1].(*content.ImageContent)
+	fmt.Println(parts[0], "/", img.AltText)
+	// Output:
+	// Here is the chart / Monthly sales
+}
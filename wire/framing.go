@@ -0,0 +1,262 @@
+package wire
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Framing delimits messages on a byte stream.
+//
+// Contract:
+//   - Concurrency: Implementations are stateless and safe for concurrent use.
+//   - Buffers: AppendFrame and ReadFrame append to dst and return the
+//     extended slice so callers can reuse buffers across messages.
+//   - Limits: ReadFrame returns ErrMessageTooLarge when a payload exceeds
+//     max bytes. A max of zero or less disables the limit.
+//   - EOF: ReadFrame returns io.EOF only when no partial frame was read.
+type Framing interface {
+	// Name returns the framing identifier (e.g., "ndjson").
+	Name() string
+
+	// AppendFrame appends payload, framed, to dst.
+	AppendFrame(dst, payload []byte) []byte
+
+	// ReadFrame reads the next payload from r and appends it to dst.
+	ReadFrame(r *bufio.Reader, dst []byte, max int) ([]byte, error)
+}
+
+var (
+	// FramingNDJSON delimits messages with a trailing newline.
+	FramingNDJSON Framing = ndjsonFraming{}
+
+	// FramingContentLength prefixes messages with LSP-style headers
+	// ("Content-Length: N\r\n\r\n").
+	FramingContentLength Framing = contentLengthFraming{}
+
+	// FramingSSE wraps messages in Server-Sent Events "data:" lines.
+	FramingSSE Framing = sseFraming{}
+)
+
+type ndjsonFraming struct{}
+
+func (ndjsonFraming) Name() string { return "ndjson" }
+
+func (ndjsonFraming) AppendFrame(dst, payload []byte) []byte {
+	dst = append(dst, payload...)
+	return append(dst, '\n')
+}
+
+func (ndjsonFraming) ReadFrame(r *bufio.Reader, dst []byte, max int) ([]byte, error) {
+	start := len(dst)
+	for {
+		line, err := readLine(r, dst[:start], max)
+		if err != nil {
+			return line, err
+		}
+		if len(bytes.TrimSpace(line[start:])) > 0 {
+			return line, nil
+		}
+		dst = line
+	}
+}
+
+type contentLengthFraming struct{}
+
+func (contentLengthFraming) Name() string { return "content-length" }
+
+func (contentLengthFraming) AppendFrame(dst, payload []byte) []byte {
+	dst = append(dst, "Content-Length: "...)
+	dst = strconv.AppendInt(dst, int64(len(payload)), 10)
+	dst = append(dst, "\r\n\r\n"...)
+	return append(dst, payload...)
+}
+
+func (contentLengthFraming) ReadFrame(r *bufio.Reader, dst []byte, max int) ([]byte, error) {
+	start := len(dst)
+	length := -1
+	sawHeader := false
+	for {
+		line, err := readLine(r, dst[:start], maxHeaderLine)
+		if err != nil {
+			if err == io.EOF && sawHeader {
+				err = io.ErrUnexpectedEOF
+			}
+			return dst[:start], err
+		}
+		header := line[start:]
+		if len(header) == 0 {
+			if !sawHeader {
+				continue
+			}
+			break
+		}
+		sawHeader = true
+		name, value, ok := bytes.Cut(header, []byte{':'})
+		if !ok {
+			return dst[:start], fmt.Errorf("%w: malformed header %q", ErrDecodeFailure, header)
+		}
+		if bytes.EqualFold(bytes.TrimSpace(name), []byte("Content-Length")) {
+			n, err := strconv.Atoi(string(bytes.TrimSpace(value)))
+			if err != nil || n < 0 {
+				return dst[:start], fmt.Errorf("%w: invalid Content-Length %q", ErrDecodeFailure, value)
+			}
+			length = n
+		}
+	}
+	if length < 0 {
+		return dst[:start], fmt.Errorf("%w: missing Content-Length header", ErrDecodeFailure)
+	}
+	if max > 0 && length > max {
+		if _, err := r.Discard(length); err != nil {
+			return dst[:start], err
+		}
+		return dst[:start], fmt.Errorf("%w: %d > %d bytes", ErrMessageTooLarge, length, max)
+	}
+	dst = grow(dst[:start], length)
+	if _, err := io.ReadFull(r, dst[start:start+length]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return dst[:start], err
+	}
+	return dst[:start+length], nil
+}
+
+type sseFraming struct{}
+
+func (sseFraming) Name() string { return "sse" }
+
+func (sseFraming) AppendFrame(dst, payload []byte) []byte {
+	for {
+		line, rest, found := bytes.Cut(payload, []byte{'\n'})
+		dst = append(dst, "data: "...)
+		dst = append(dst, bytes.TrimSuffix(line, []byte{'\r'})...)
+		dst = append(dst, '\n')
+		if !found {
+			break
+		}
+		payload = rest
+	}
+	return append(dst, '\n')
+}
+
+func (sseFraming) ReadFrame(r *bufio.Reader, dst []byte, max int) ([]byte, error) {
+	start := len(dst)
+	end := start
+	hasData := false
+	lineMax := max
+	if max > 0 {
+		lineMax += len("data: ")
+	}
+	for {
+		// Lines are read past the accumulated data; only data fields are kept.
+		line, err := readLine(r, dst[:end], lineMax)
+		if err != nil {
+			if err == io.EOF && hasData {
+				return dst[:end], nil
+			}
+			if errors.Is(err, ErrMessageTooLarge) {
+				discardEvent(r)
+			}
+			return dst[:start], err
+		}
+		field := line[end:]
+		dst = line
+		switch {
+		case len(field) == 0:
+			if hasData {
+				return dst[:end], nil
+			}
+		case field[0] == ':':
+			// Comment line (often used as a heartbeat).
+		case bytes.HasPrefix(field, []byte("data:")):
+			value := field[len("data:"):]
+			if len(value) > 0 && value[0] == ' ' {
+				value = value[1:]
+			}
+			if hasData {
+				dst[end] = '\n'
+				end++
+			}
+			end += copy(dst[end:], value)
+			hasData = true
+			if max > 0 && end-start > max {
+				discardEvent(r)
+				return dst[:start], fmt.Errorf("%w: exceeds %d bytes", ErrMessageTooLarge, max)
+			}
+		default:
+			// event, id, retry and unknown fields carry no payload.
+		}
+	}
+}
+
+// maxHeaderLine bounds a single Content-Length header line.
+const maxHeaderLine = 1024
+
+// readLine appends the next line, without its line terminator, to dst.
+// It returns ErrMessageTooLarge when the line exceeds max bytes.
+func readLine(r *bufio.Reader, dst []byte, max int) ([]byte, error) {
+	start := len(dst)
+	for {
+		chunk, err := r.ReadSlice('\n')
+		dst = append(dst, chunk...)
+		switch err {
+		case bufio.ErrBufferFull:
+			// A trailing '\r' may yet be completed by '\n'.
+			if max > 0 && len(dst)-start > max+1 {
+				discardLine(r)
+				return dst[:start], fmt.Errorf("%w: exceeds %d bytes", ErrMessageTooLarge, max)
+			}
+			continue
+		case nil, io.EOF:
+			if err == io.EOF && len(dst) == start {
+				return dst, io.EOF
+			}
+			dst = bytes.TrimSuffix(dst, []byte{'\n'})
+			dst = bytes.TrimSuffix(dst, []byte{'\r'})
+			if max > 0 && len(dst)-start > max {
+				return dst[:start], fmt.Errorf("%w: exceeds %d bytes", ErrMessageTooLarge, max)
+			}
+			return dst, nil
+		default:
+			return dst[:start], err
+		}
+	}
+}
+
+// discardLine skips the remainder of the current line.
+func discardLine(r *bufio.Reader) {
+	for {
+		_, err := r.ReadSlice('\n')
+		if err != bufio.ErrBufferFull {
+			return
+		}
+	}
+}
+
+// discardEvent skips the remainder of the current SSE event.
+func discardEvent(r *bufio.Reader) {
+	for {
+		line, err := r.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil || len(bytes.TrimRight(line, "\r\n")) == 0 {
+			return
+		}
+	}
+}
+
+// grow extends b by n bytes, reusing its capacity when possible.
+func grow(b []byte, n int) []byte {
+	if cap(b)-len(b) >= n {
+		return b[:len(b)+n]
+	}
+	nb := make([]byte, len(b)+n, 2*cap(b)+n)
+	copy(nb, b)
+	return nb
+}
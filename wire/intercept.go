@@ -0,0 +1,405 @@
+package wire
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+)
+
+// Op identifies the Wire method a Message passes through.
+type Op string
+
+const (
+	// OpEncodeRequest is Wire.EncodeRequest.
+	OpEncodeRequest Op = "encode_request"
+
+	// OpDecodeRequest is Wire.DecodeRequest.
+	OpDecodeRequest Op = "decode_request"
+
+	// OpEncodeResponse is Wire.EncodeResponse.
+	OpEncodeResponse Op = "encode_response"
+
+	// OpDecodeResponse is Wire.DecodeResponse.
+	OpDecodeResponse Op = "decode_response"
+
+	// OpEncodeToolList is Wire.EncodeToolList.
+	OpEncodeToolList Op = "encode_tool_list"
+
+	// OpDecodeToolList is Wire.DecodeToolList.
+	OpDecodeToolList Op = "decode_tool_list"
+)
+
+// Encode reports whether the operation produces wire data from a model.
+func (o Op) Encode() bool {
+	return o == OpEncodeRequest || o == OpEncodeResponse || o == OpEncodeToolList
+}
+
+// Message is the state of one Wire call as it passes through the
+// interceptor chain.
+//
+// For encode operations the model (Request, Response or Tools) is set
+// on entry and Data is set once the call returns from next. For decode
+// operations Data is set on entry and the model once next returns.
+type Message struct {
+	// Op is the Wire method being called.
+	Op Op
+
+	// Wire is the codec the call is delegated to.
+	Wire Wire
+
+	// Request is the request being encoded or decoded.
+	Request *Request
+
+	// Response is the response being encoded or decoded.
+	Response *Response
+
+	// Tools is the tool list being encoded or decoded.
+	Tools []Tool
+
+	// Data is the wire form of the message.
+	Data []byte
+}
+
+// Handler runs the rest of an interceptor chain for a message.
+type Handler func(ctx context.Context, m *Message) error
+
+// Interceptor wraps a Wire call. It may inspect or replace the fields of
+// m before and after calling next, return early with an error, or
+// return without calling next to short-circuit the codec.
+//
+// Interceptors replace models with modified copies rather than mutating
+// them: the Request, Response and Tools on entry to an encode belong to
+// the caller.
+type Interceptor func(ctx context.Context, m *Message, next Handler) error
+
+// InterceptedWire wraps a Wire with a chain of interceptors around every
+// Encode and Decode call.
+//
+// Only the Wire methods are intercepted. Optional interfaces of the
+// wrapped codec, such as PageCodec or NotificationCodec, are reached
+// through Unwrap.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use if the wrapped Wire and the
+//     interceptors are.
+//   - Ordering: The first interceptor is outermost; it sees the caller's
+//     model before any other on encode and the final model last on decode.
+//   - Errors: Interceptor errors are returned as they are; when a call
+//     fails no data or model is returned.
+type InterceptedWire struct {
+	w       Wire
+	handler Handler
+}
+
+// Intercept wraps w with interceptors, the first outermost.
+func Intercept(w Wire, interceptors ...Interceptor) *InterceptedWire {
+	iw := &InterceptedWire{w: w}
+	h := iw.call
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		ic, next := interceptors[i], h
+		h = func(ctx context.Context, m *Message) error {
+			return ic(ctx, m, next)
+		}
+	}
+	iw.handler = h
+	return iw
+}
+
+// Unwrap returns the wrapped Wire.
+func (iw *InterceptedWire) Unwrap() Wire {
+	return iw.w
+}
+
+// Name returns the wrapped protocol name.
+func (iw *InterceptedWire) Name() string {
+	return iw.w.Name()
+}
+
+// Version returns the wrapped protocol version.
+func (iw *InterceptedWire) Version() string {
+	return iw.w.Version()
+}
+
+// Capabilities returns the wrapped protocol capabilities.
+func (iw *InterceptedWire) Capabilities() *Capabilities {
+	return iw.w.Capabilities()
+}
+
+// EncodeRequest encodes a request through the interceptor chain.
+func (iw *InterceptedWire) EncodeRequest(ctx context.Context, req *Request) ([]byte, error) {
+	m := &Message{Op: OpEncodeRequest, Wire: iw.w, Request: req}
+	if err := iw.handler(ctx, m); err != nil {
+		return nil, err
+	}
+	return m.Data, nil
+}
+
+// DecodeRequest decodes a request through the interceptor chain.
+func (iw *InterceptedWire) DecodeRequest(ctx context.Context, data []byte) (*Request, error) {
+	m := &Message{Op: OpDecodeRequest, Wire: iw.w, Data: data}
+	if err := iw.handler(ctx, m); err != nil {
+		return nil, err
+	}
+	return m.Request, nil
+}
+
+// EncodeResponse encodes a response through the interceptor chain.
+func (iw *InterceptedWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, error) {
+	m := &Message{Op: OpEncodeResponse, Wire: iw.w, Response: resp}
+	if err := iw.handler(ctx, m); err != nil {
+		return nil, err
+	}
+	return m.Data, nil
+}
+
+// DecodeResponse decodes a response through the interceptor chain.
+func (iw *InterceptedWire) DecodeResponse(ctx context.Context, data []byte) (*Response, error) {
+	m := &Message{Op: OpDecodeResponse, Wire: iw.w, Data: data}
+	if err := iw.handler(ctx, m); err != nil {
+		return nil, err
+	}
+	return m.Response, nil
+}
+
+// EncodeToolList encodes a tool list through the interceptor chain.
+func (iw *InterceptedWire) EncodeToolList(ctx context.Context, tools []Tool) ([]byte, error) {
+	m := &Message{Op: OpEncodeToolList, Wire: iw.w, Tools: tools}
+	if err := iw.handler(ctx, m); err != nil {
+		return nil, err
+	}
+	return m.Data, nil
+}
+
+// DecodeToolList decodes a tool list through the interceptor chain.
+func (iw *InterceptedWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+	m := &Message{Op: OpDecodeToolList, Wire: iw.w, Data: data}
+	if err := iw.handler(ctx, m); err != nil {
+		return nil, err
+	}
+	return m.Tools, nil
+}
+
+// call is the end of the chain: it runs the wrapped codec.
+func (iw *InterceptedWire) call(ctx context.Context, m *Message) error {
+	var err error
+	switch m.Op {
+	case OpEncodeRequest:
+		m.Data, err = iw.w.EncodeRequest(ctx, m.Request)
+	case OpDecodeRequest:
+		m.Request, err = iw.w.DecodeRequest(ctx, m.Data)
+	case OpEncodeResponse:
+		m.Data, err = iw.w.EncodeResponse(ctx, m.Response)
+	case OpDecodeResponse:
+		m.Response, err = iw.w.DecodeResponse(ctx, m.Data)
+	case OpEncodeToolList:
+		m.Data, err = iw.w.EncodeToolList(ctx, m.Tools)
+	case OpDecodeToolList:
+		m.Tools, err = iw.w.DecodeToolList(ctx, m.Data)
+	default:
+		err = fmt.Errorf("%w: unknown op %q", ErrUnsupportedFeature, m.Op)
+	}
+	return err
+}
+
+// Redact returns an interceptor that applies r to requests and responses:
+// before they are encoded, and after they are decoded.
+func Redact(r *Redactor) Interceptor {
+	return func(ctx context.Context, m *Message, next Handler) error {
+		if m.Op.Encode() {
+			if err := redactMessage(r, m); err != nil {
+				return err
+			}
+			return next(ctx, m)
+		}
+		if err := next(ctx, m); err != nil {
+			return err
+		}
+		return redactMessage(r, m)
+	}
+}
+
+// redactMessage replaces the request or response in m with its redacted
+// copy.
+func redactMessage(r *Redactor, m *Message) error {
+	var err error
+	if m.Request != nil {
+		m.Request, err = r.RedactRequest(m.Request)
+	}
+	if err == nil && m.Response != nil {
+		m.Response, err = r.RedactResponse(m.Response)
+	}
+	return err
+}
+
+// InjectMeta returns an interceptor that adds meta to the Meta of every
+// request and response it encodes. Injected keys replace existing ones;
+// the codec carries them as the protocol's metadata (MCP "_meta").
+func InjectMeta(meta map[string]any) Interceptor {
+	meta = maps.Clone(meta)
+	return InjectMetaFunc(func(context.Context) map[string]any {
+		return meta
+	})
+}
+
+// InjectMetaFunc is like InjectMeta with the metadata computed for each
+// call, typically from values carried by ctx. A nil or empty result
+// leaves the message unchanged.
+func InjectMetaFunc(fn func(ctx context.Context) map[string]any) Interceptor {
+	return func(ctx context.Context, m *Message, next Handler) error {
+		if m.Op != OpEncodeRequest && m.Op != OpEncodeResponse {
+			return next(ctx, m)
+		}
+		extra := fn(ctx)
+		if len(extra) == 0 {
+			return next(ctx, m)
+		}
+		if m.Request != nil {
+			req := *m.Request
+			req.Meta = mergeMeta(req.Meta, extra)
+			m.Request = &req
+		}
+		if m.Response != nil {
+			resp := *m.Response
+			resp.Meta = mergeMeta(resp.Meta, extra)
+			m.Response = &resp
+		}
+		return next(ctx, m)
+	}
+}
+
+// mergeMeta returns a copy of meta with extra's keys set.
+func mergeMeta(meta, extra map[string]any) map[string]any {
+	out := make(map[string]any, len(meta)+len(extra))
+	maps.Copy(out, meta)
+	maps.Copy(out, extra)
+	return out
+}
+
+// LimitSize returns an interceptor that rejects wire data longer than n
+// bytes with ErrMessageTooLarge: input before it is decoded, and output
+// after it is encoded. A value of zero or less disables the limit.
+func LimitSize(n int) Interceptor {
+	return func(ctx context.Context, m *Message, next Handler) error {
+		if n <= 0 {
+			return next(ctx, m)
+		}
+		if !m.Op.Encode() && len(m.Data) > n {
+			return fmt.Errorf("%w: %s: %d bytes exceeds %d", ErrMessageTooLarge, m.Op, len(m.Data), n)
+		}
+		if err := next(ctx, m); err != nil {
+			return err
+		}
+		if m.Op.Encode() && len(m.Data) > n {
+			size := len(m.Data)
+			m.Data = nil
+			return fmt.Errorf("%w: %s: %d bytes exceeds %d", ErrMessageTooLarge, m.Op, size, n)
+		}
+		return nil
+	}
+}
+
+// LogOption configures a Logging interceptor.
+type LogOption func(*logConfig)
+
+type logConfig struct {
+	level    slog.Level
+	payload  bool
+	redactor *Redactor
+}
+
+// WithLogLevel sets the level successful calls are logged at. The
+// default is slog.LevelDebug; failed calls are logged at slog.LevelWarn.
+func WithLogLevel(level slog.Level) LogOption {
+	return func(c *logConfig) {
+		c.level = level
+	}
+}
+
+// WithLogPayload adds the request or response to each record, in the
+// layout Redactor paths address, after applying r. A nil Redactor logs
+// payloads unredacted.
+func WithLogPayload(r *Redactor) LogOption {
+	return func(c *logConfig) {
+		c.payload = true
+		c.redactor = r
+	}
+}
+
+// Logging returns an interceptor that logs every call to logger with its
+// protocol, operation, method, id, tool, size in bytes, duration and
+// error. A nil logger uses slog.Default.
+func Logging(logger *slog.Logger, opts ...LogOption) Interceptor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	cfg := logConfig{level: slog.LevelDebug}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(ctx context.Context, m *Message, next Handler) error {
+		if !logger.Enabled(ctx, min(cfg.level, slog.LevelWarn)) {
+			return next(ctx, m)
+		}
+		start := time.Now()
+		err := next(ctx, m)
+		level := cfg.level
+		if err != nil {
+			level = slog.LevelWarn
+		}
+		if !logger.Enabled(ctx, level) {
+			return err
+		}
+		logger.LogAttrs(ctx, level, "wire "+string(m.Op), cfg.attrs(m, time.Since(start), err)...)
+		return err
+	}
+}
+
+// attrs returns the record attributes for a completed call.
+func (c *logConfig) attrs(m *Message, d time.Duration, err error) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("protocol", m.Wire.Name()),
+		slog.String("op", string(m.Op)),
+	}
+	switch {
+	case m.Request != nil:
+		attrs = append(attrs,
+			slog.String("method", m.Request.Method),
+			slog.String("id", m.Request.ID),
+			slog.String("tool", m.Request.ToolID))
+	case m.Response != nil:
+		attrs = append(attrs,
+			slog.String("id", m.Response.ID),
+			slog.Bool("is_error", m.Response.IsError))
+	case m.Tools != nil:
+		attrs = append(attrs, slog.Int("tools", len(m.Tools)))
+	}
+	attrs = append(attrs,
+		slog.Int("bytes", len(m.Data)),
+		slog.Duration("duration", d))
+	if c.payload {
+		attrs = append(attrs, c.payloadAttr(m))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	return attrs
+}
+
+// payloadAttr returns the redacted model of m.
+func (c *logConfig) payloadAttr(m *Message) slog.Attr {
+	var doc map[string]any
+	switch {
+	case m.Request != nil:
+		doc = requestMap(m.Request)
+	case m.Response != nil:
+		doc = responseMap(m.Response)
+	default:
+		return slog.Any("payload", nil)
+	}
+	if c.redactor != nil {
+		doc, _ = c.redactor.apply(doc)
+	}
+	return slog.Any("payload", doc)
+}
@@ -0,0 +1,278 @@
+package wire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIntercept_Order(t *testing.T) {
+	var trace []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, m *Message, next Handler) error {
+			trace = append(trace, name+">")
+			err := next(ctx, m)
+			trace = append(trace, "<"+name)
+			return err
+		}
+	}
+	w := Intercept(NewMCP(), record("a"), record("b"))
+	if _, err := w.EncodeRequest(context.Background(), &Request{ID: "1", Method: "tools/call", ToolID: "t"}); err != nil {
+		t.Fatalf("EncodeRequest() error = %v", err)
+	}
+	if want := []string{"a>", "b>", "<b", "<a"}; !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %v, want %v", trace, want)
+	}
+}
+
+func TestIntercept_Delegates(t *testing.T) {
+	ctx := context.Background()
+	var ops []Op
+	w := Intercept(NewMCP(), func(ctx context.Context, m *Message, next Handler) error {
+		ops = append(ops, m.Op)
+		if m.Wire.Name() != "mcp" {
+			t.Errorf("%s: Message.Wire = %s, want mcp", m.Op, m.Wire.Name())
+		}
+		return next(ctx, m)
+	})
+	if w.Name() != "mcp" || w.Version() != MCPVersion || w.Unwrap().Name() != "mcp" || !w.Capabilities().Streaming {
+		t.Errorf("InterceptedWire = %s %s, want delegated mcp", w.Name(), w.Version())
+	}
+	var _ Wire = w
+
+	req := &Request{ID: "1", Method: "tools/call", ToolID: "t", Arguments: map[string]any{"a": "b"}}
+	data, err := w.EncodeRequest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := w.DecodeRequest(ctx, data); err != nil || got.ToolID != "t" {
+		t.Errorf("DecodeRequest() = %+v, %v", got, err)
+	}
+	resp := &Response{ID: "1", Content: []Content{{Type: ContentTypeText, Text: "ok"}}}
+	if data, err = w.EncodeResponse(ctx, resp); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := w.DecodeResponse(ctx, data); err != nil || got.Content[0].Text != "ok" {
+		t.Errorf("DecodeResponse() = %+v, %v", got, err)
+	}
+	if data, err = w.EncodeToolList(ctx, []Tool{{Name: "t"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := w.DecodeToolList(ctx, data); err != nil || len(got) != 1 {
+		t.Errorf("DecodeToolList() = %+v, %v", got, err)
+	}
+	want := []Op{OpEncodeRequest, OpDecodeRequest, OpEncodeResponse, OpDecodeResponse, OpEncodeToolList, OpDecodeToolList}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("ops = %v, want %v", ops, want)
+	}
+}
+
+func TestIntercept_ShortCircuit(t *testing.T) {
+	errDenied := errors.New("denied")
+	w := Intercept(NewMCP(), func(ctx context.Context, m *Message, next Handler) error {
+		if m.Op == OpDecodeRequest {
+			return errDenied
+		}
+		return next(ctx, m)
+	})
+	data, err := w.EncodeRequest(context.Background(), &Request{ID: "1", Method: "tools/call", ToolID: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req, err := w.DecodeRequest(context.Background(), data); !errors.Is(err, errDenied) || req != nil {
+		t.Errorf("DecodeRequest() = %v, %v, want nil, errDenied", req, err)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewRedactor("$.arguments.password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := Intercept(NewMCP(), Redact(r))
+	req := &Request{ID: "1", Method: "tools/call", ToolID: "login", Arguments: map[string]any{"password": "hunter2"}}
+	data, err := w.EncodeRequest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("hunter2")) || !bytes.Contains(data, []byte(RedactedValue)) {
+		t.Errorf("EncodeRequest() = %s, want password redacted", data)
+	}
+	if req.Arguments["password"] != "hunter2" {
+		t.Error("Redact modified the caller's request")
+	}
+
+	raw, err := NewMCP().EncodeRequest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := w.DecodeRequest(ctx, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Arguments["password"] != RedactedValue {
+		t.Errorf("DecodeRequest() password = %v, want redacted", got.Arguments["password"])
+	}
+}
+
+func TestInjectMeta(t *testing.T) {
+	ctx := context.Background()
+	meta := map[string]any{"tenant": "acme", "trace": "new"}
+	w := Intercept(NewMCP(), InjectMeta(meta))
+	meta["tenant"] = "changed"
+
+	req := &Request{ID: "1", Method: "tools/call", ToolID: "t", Meta: map[string]any{"trace": "old", "keep": true}}
+	data, err := w.EncodeRequest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewMCP().DecodeRequest(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"tenant": "acme", "trace": "new", "keep": true}
+	if !reflect.DeepEqual(got.Meta, want) {
+		t.Errorf("Meta = %v, want %v", got.Meta, want)
+	}
+	if req.Meta["trace"] != "old" || len(req.Meta) != 2 {
+		t.Errorf("InjectMeta modified the caller's request: %v", req.Meta)
+	}
+
+	data, err = w.EncodeResponse(ctx, &Response{ID: "1", Content: []Content{{Type: ContentTypeText, Text: "ok"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"_meta"`)) || !bytes.Contains(data, []byte(`"acme"`)) {
+		t.Errorf("EncodeResponse() = %s, want _meta injected", data)
+	}
+}
+
+type tenantKey struct{}
+
+func TestInjectMetaFunc(t *testing.T) {
+	w := Intercept(NewMCP(), InjectMetaFunc(func(ctx context.Context) map[string]any {
+		if v, ok := ctx.Value(tenantKey{}).(string); ok {
+			return map[string]any{"tenant": v}
+		}
+		return nil
+	}))
+	req := &Request{ID: "1", Method: "tools/call", ToolID: "t"}
+	data, err := w.EncodeRequest(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(`"_meta"`)) {
+		t.Errorf("EncodeRequest() = %s, want no _meta without a tenant", data)
+	}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	if data, err = w.EncodeRequest(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"_meta":{"tenant":"acme"}`)) {
+		t.Errorf("EncodeRequest() = %s, want tenant meta", data)
+	}
+}
+
+func TestLimitSize(t *testing.T) {
+	ctx := context.Background()
+	small := &Request{ID: "1", Method: "tools/call", ToolID: "t"}
+	large := &Request{ID: "1", Method: "tools/call", ToolID: "t", Arguments: map[string]any{"blob": strings.Repeat("x", 256)}}
+	w := Intercept(NewMCP(), LimitSize(128))
+
+	if _, err := w.EncodeRequest(ctx, small); err != nil {
+		t.Errorf("EncodeRequest(small) error = %v", err)
+	}
+	if data, err := w.EncodeRequest(ctx, large); !errors.Is(err, ErrMessageTooLarge) || data != nil {
+		t.Errorf("EncodeRequest(large) = %d bytes, %v, want ErrMessageTooLarge", len(data), err)
+	}
+	data, err := NewMCP().EncodeRequest(ctx, large)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.DecodeRequest(ctx, data); !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("DecodeRequest(large) error = %v, want ErrMessageTooLarge", err)
+	}
+	if _, err := Intercept(NewMCP(), LimitSize(0)).DecodeRequest(ctx, data); err != nil {
+		t.Errorf("LimitSize(0) DecodeRequest() error = %v", err)
+	}
+}
+
+func TestLogging(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	r, err := NewRedactor("$..password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := Intercept(NewMCP(), Logging(logger, WithLogLevel(slog.LevelInfo), WithLogPayload(r)))
+
+	req := &Request{ID: "7", Method: "tools/call", ToolID: "login", Arguments: map[string]any{"password": "hunter2"}}
+	if _, err := w.EncodeRequest(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.DecodeRequest(ctx, []byte("{")); err == nil {
+		t.Fatal("DecodeRequest() error = nil, want decode failure")
+	}
+
+	var records []map[string]any
+	for line := range bytes.Lines(buf.Bytes()) {
+		var rec map[string]any
+		if err := json.Unmarshal(line, &rec); err != nil {
+			t.Fatalf("log line %s: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2:\n%s", len(records), buf.String())
+	}
+
+	enc := records[0]
+	for k, want := range map[string]any{
+		"level": "INFO", "msg": "wire encode_request", "protocol": "mcp", "op": "encode_request",
+		"method": "tools/call", "id": "7", "tool": "login",
+	} {
+		if enc[k] != want {
+			t.Errorf("encode record %s = %v, want %v", k, enc[k], want)
+		}
+	}
+	if enc["bytes"].(float64) <= 0 {
+		t.Errorf("encode record bytes = %v, want > 0", enc["bytes"])
+	}
+	if _, ok := enc["duration"]; !ok {
+		t.Error("encode record has no duration")
+	}
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("log contains the unredacted password:\n%s", buf.String())
+	}
+	payload, _ := enc["payload"].(map[string]any)
+	if args, _ := payload["arguments"].(map[string]any); args["password"] != RedactedValue {
+		t.Errorf("encode record payload = %v, want redacted arguments", enc["payload"])
+	}
+
+	dec := records[1]
+	if dec["level"] != "WARN" || dec["op"] != "decode_request" || dec["error"] == nil {
+		t.Errorf("decode record = %v, want WARN with error", dec)
+	}
+}
+
+func TestLogging_Disabled(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError}))
+	w := Intercept(NewMCP(), Logging(logger))
+	if _, err := w.EncodeRequest(context.Background(), &Request{ID: "1", Method: "tools/call", ToolID: "t"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.DecodeRequest(context.Background(), []byte("{")); err == nil {
+		t.Fatal("DecodeRequest() error = nil")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("logged below the handler level:\n%s", buf.String())
+	}
+}
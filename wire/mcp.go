@@ -2,21 +2,38 @@ package wire
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 )
 
-// MCPVersion is the MCP specification version.
-const MCPVersion = "2025-11-25"
+// MCPVersion is the latest MCP specification version.
+const MCPVersion = MCPVersion20251125
 
 // MCPWire implements Wire for the Model Context Protocol.
-type MCPWire struct{}
+//
+// The zero value and NewMCP speak the latest revision ([MCPVersion]).
+// Use NewMCPVersion for a codec pinned to an older revision; such codecs
+// never emit fields the revision cannot parse.
+type MCPWire struct {
+	version string
+}
 
-// NewMCP creates a new MCP wire format handler.
+// NewMCP creates a new MCP wire format handler for the latest revision.
 func NewMCP() *MCPWire {
 	return &MCPWire{}
 }
 
+// NewMCPVersion creates an MCP wire format handler for a specific
+// specification revision. It returns ErrUnsupportedVersion for unknown
+// revisions.
+func NewMCPVersion(version string) (*MCPWire, error) {
+	if _, ok := mcpFeatures[version]; !ok {
+		return nil, fmt.Errorf("%w: mcp %q", ErrUnsupportedVersion, version)
+	}
+	return &MCPWire{version: version}, nil
+}
+
 // Name returns "mcp".
 func (w *MCPWire) Name() string {
 	return "mcp"
@@ -24,7 +41,10 @@ func (w *MCPWire) Name() string {
 
 // Version returns the MCP spec version.
 func (w *MCPWire) Version() string {
-	return MCPVersion
+	if w.version == "" {
+		return MCPVersion
+	}
+	return w.version
 }
 
 // jsonrpcRequest is the JSON-RPC 2.0 request format.
@@ -51,7 +71,12 @@ type jsonrpcError struct {
 }
 
 // EncodeRequest encodes a Request to MCP JSON-RPC format.
+// Methods introduced after the codec's revision are rejected with
+// ErrUnsupportedFeature.
 func (w *MCPWire) EncodeRequest(ctx context.Context, req *Request) ([]byte, error) {
+	if err := w.checkMethod(req.Method); err != nil {
+		return nil, err
+	}
 	rpc := jsonrpcRequest{
 		JSONRPC: "2.0",
 		ID:      req.ID,
@@ -69,35 +94,38 @@ func (w *MCPWire) EncodeRequest(ctx context.Context, req *Request) ([]byte, erro
 	return json.Marshal(rpc)
 }
 
+// mcpRequestIn is the decoding form of an MCP request. Arguments and
+// metadata stay raw until the caller asks for them.
+type mcpRequestIn struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params *struct {
+		Name      looseString     `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+		Meta      json.RawMessage `json:"_meta"`
+	} `json:"params"`
+}
+
 // DecodeRequest decodes MCP JSON-RPC format to a Request.
 func (w *MCPWire) DecodeRequest(ctx context.Context, data []byte) (*Request, error) {
-	var rpc jsonrpcRequest
+	return decodeRequest(ctx, w, data)
+}
+
+// DecodeRawRequest decodes MCP JSON-RPC format to a RawRequest.
+func (w *MCPWire) DecodeRawRequest(ctx context.Context, data []byte) (*RawRequest, error) {
+	var rpc mcpRequestIn
 	if err := json.Unmarshal(data, &rpc); err != nil {
 		return nil, fmt.Errorf("decode request: %w", err)
 	}
 
-	req := &Request{
+	req := &RawRequest{
+		ID:     rawID(rpc.ID),
 		Method: rpc.Method,
 	}
-
-	// Handle ID which can be string or number
-	switch id := rpc.ID.(type) {
-	case string:
-		req.ID = id
-	case float64:
-		req.ID = fmt.Sprintf("%.0f", id)
-	}
-
-	if rpc.Params != nil {
-		if name, ok := rpc.Params["name"].(string); ok {
-			req.ToolID = name
-		}
-		if args, ok := rpc.Params["arguments"].(map[string]any); ok {
-			req.Arguments = args
-		}
-		if meta, ok := rpc.Params["_meta"].(map[string]any); ok {
-			req.Meta = meta
-		}
+	if p := rpc.Params; p != nil {
+		req.ToolID = string(p.Name)
+		req.Arguments = objectOrNil(p.Arguments)
+		req.Meta = objectOrNil(p.Meta)
 	}
 
 	return req, nil
@@ -117,8 +145,16 @@ func (w *MCPWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, e
 			Data:    resp.Error.Data,
 		}
 	} else {
+		f := w.Features()
 		content := make([]map[string]any, 0, len(resp.Content))
 		for _, c := range resp.Content {
+			switch {
+			case c.Type == ContentTypeAudio && !f.AudioContent:
+				return nil, fmt.Errorf("encode response: %w: audio content not available in mcp %s", ErrUnsupportedFeature, w.Version())
+			case c.Type == "resource_link" && !f.ResourceLinks:
+				// A link is a resource block without contents.
+				c = Content{Type: ContentTypeResource, URI: c.URI, MIMEType: c.MIMEType, Annotations: c.Annotations}
+			}
 			item := map[string]any{"type": string(c.Type)}
 			switch c.Type {
 			case ContentTypeText:
@@ -127,14 +163,60 @@ func (w *MCPWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, e
 				item["data"] = c.Data
 				item["mimeType"] = c.MIMEType
 			case ContentTypeResource:
-				item["uri"] = c.URI
-				if c.MIMEType != "" {
-					item["mimeType"] = c.MIMEType
+				if c.Text == "" && len(c.Data) == 0 {
+					item["uri"] = c.URI
+					if c.MIMEType != "" {
+						item["mimeType"] = c.MIMEType
+					}
+					break
+				}
+				// Resource contents travel as an embedded resource.
+				res := map[string]any{"uri": c.URI}
+				putString(res, "mimeType", c.MIMEType)
+				if len(c.Data) > 0 {
+					res["blob"] = c.Data
+				} else {
+					res["text"] = c.Text
+				}
+				item["resource"] = res
+			default:
+				// Other block types (audio, resource_link, ...) keep the
+				// fields they set.
+				putString(item, "text", c.Text)
+				putString(item, "uri", c.URI)
+				putString(item, "mimeType", c.MIMEType)
+				if len(c.Data) > 0 {
+					item["data"] = c.Data
 				}
 			}
+			annotations := annotationsMap(c.Annotations)
+			if _, ok := annotations["lastModified"]; ok && !f.LastModified {
+				delete(annotations, "lastModified")
+				if len(annotations) == 0 {
+					annotations = nil
+				}
+			}
+			putMap(item, "annotations", annotations)
 			content = append(content, item)
 		}
 		rpc.Result = map[string]any{"content": content}
+		if resp.IsError {
+			// A tool execution error without protocol error details.
+			rpc.Result["isError"] = true
+		}
+		if resp.StructuredContent != nil {
+			if w.Features().StructuredOutput {
+				rpc.Result["structuredContent"] = resp.StructuredContent
+			} else if len(content) == 0 {
+				// Older peers only understand content blocks; fall back to
+				// serialized JSON as the spec recommends.
+				text, err := json.Marshal(resp.StructuredContent)
+				if err != nil {
+					return nil, fmt.Errorf("encode response: %w", err)
+				}
+				rpc.Result["content"] = []map[string]any{{"type": "text", "text": string(text)}}
+			}
+		}
 		if len(resp.Meta) > 0 {
 			rpc.Result["_meta"] = resp.Meta
 		}
@@ -143,85 +225,147 @@ func (w *MCPWire) EncodeResponse(ctx context.Context, resp *Response) ([]byte, e
 	return json.Marshal(rpc)
 }
 
+// mcpResponseIn is the decoding form of an MCP response.
+type mcpResponseIn struct {
+	ID     json.RawMessage `json:"id"`
+	Result *struct {
+		Content           []mcpContentIn `json:"content"`
+		StructuredContent looseObject    `json:"structuredContent"`
+		IsError           bool           `json:"isError"`
+		Meta              looseObject    `json:"_meta"`
+	} `json:"result"`
+	Error *jsonrpcError `json:"error"`
+}
+
+// mcpContentIn is the decoding form of an MCP content block.
+type mcpContentIn struct {
+	Type     looseString `json:"type"`
+	Text     looseString `json:"text"`
+	URI      looseString `json:"uri"`
+	MIMEType looseString `json:"mimeType"`
+	Data     looseBytes  `json:"data"`
+
+	// Annotations are the block's audience, priority and lastModified.
+	Annotations looseObject `json:"annotations"`
+
+	// Resource is the resource of an embedded resource block, whose URI
+	// and MIME type are nested rather than top-level.
+	Resource *struct {
+		URI      looseString `json:"uri"`
+		MIMEType looseString `json:"mimeType"`
+		Text     looseString `json:"text"`
+		Blob     looseBytes  `json:"blob"`
+	} `json:"resource"`
+}
+
 // DecodeResponse decodes MCP JSON-RPC format to a Response.
 func (w *MCPWire) DecodeResponse(ctx context.Context, data []byte) (*Response, error) {
-	var rpc jsonrpcResponse
+	var rpc mcpResponseIn
 	if err := json.Unmarshal(data, &rpc); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	resp := &Response{}
-
-	// Handle ID
-	switch id := rpc.ID.(type) {
-	case string:
-		resp.ID = id
-	case float64:
-		resp.ID = fmt.Sprintf("%.0f", id)
-	}
+	resp := &Response{ID: rawID(rpc.ID)}
 
 	if rpc.Error != nil {
 		resp.IsError = true
-		resp.Error = &Error{
-			Code:    rpc.Error.Code,
-			Message: rpc.Error.Message,
-			Data:    rpc.Error.Data,
-		}
-	} else if rpc.Result != nil {
-		if contentArr, ok := rpc.Result["content"].([]any); ok {
-			for _, item := range contentArr {
-				if cm, ok := item.(map[string]any); ok {
-					c := Content{}
-					if t, ok := cm["type"].(string); ok {
-						c.Type = ContentType(t)
-					}
-					if text, ok := cm["text"].(string); ok {
-						c.Text = text
-					}
-					if uri, ok := cm["uri"].(string); ok {
-						c.URI = uri
-					}
-					if mime, ok := cm["mimeType"].(string); ok {
-						c.MIMEType = mime
-					}
-					resp.Content = append(resp.Content, c)
+		resp.Error = decodedError("mcp", rpc.Error.Code, rpc.Error.Message, rpc.Error.Data)
+	} else if r := rpc.Result; r != nil {
+		if len(r.Content) > 0 {
+			resp.Content = make([]Content, len(r.Content))
+			for i, c := range r.Content {
+				resp.Content[i] = Content{
+					Type:        ContentType(c.Type),
+					Text:        string(c.Text),
+					URI:         string(c.URI),
+					MIMEType:    string(c.MIMEType),
+					Data:        c.Data,
+					Annotations: annotationsFromMap(c.Annotations),
+				}
+				if r := c.Resource; r != nil && c.URI == "" {
+					resp.Content[i].URI = string(r.URI)
+					resp.Content[i].MIMEType = string(r.MIMEType)
+					resp.Content[i].Text = string(r.Text)
+					resp.Content[i].Data = r.Blob
 				}
 			}
 		}
-		if meta, ok := rpc.Result["_meta"].(map[string]any); ok {
-			resp.Meta = meta
-		}
+		resp.StructuredContent = r.StructuredContent
+		resp.IsError = r.IsError
+		resp.Meta = r.Meta
 	}
 
 	return resp, nil
 }
 
+// decodeData decodes base64 binary data as produced by encoding/json for
+// []byte values. Malformed or missing data yields nil.
+func decodeData(v any) []byte {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
 // mcpToolList is the MCP tools/list response format.
 type mcpToolList struct {
-	Tools []mcpTool `json:"tools"`
+	Tools      []mcpTool `json:"tools"`
+	NextCursor string    `json:"nextCursor,omitempty"`
 }
 
 type mcpTool struct {
-	Name        string         `json:"name"`
-	Description string         `json:"description,omitempty"`
-	InputSchema map[string]any `json:"inputSchema,omitempty"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description,omitempty"`
+	InputSchema  map[string]any `json:"inputSchema,omitempty"`
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
+	Annotations  map[string]any `json:"annotations,omitempty"`
 }
 
 // EncodeToolList encodes a tool list to MCP format.
+// Output schemas and tool annotations are omitted for revisions that do
+// not support them.
 func (w *MCPWire) EncodeToolList(ctx context.Context, tools []Tool) ([]byte, error) {
+	return w.EncodeToolPage(ctx, &Page[Tool]{Items: tools})
+}
+
+// DecodeToolList decodes MCP format to a tool list.
+func (w *MCPWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+	page, err := w.DecodeToolPage(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// EncodeToolPage encodes one page of a tools/list result.
+func (w *MCPWire) EncodeToolPage(ctx context.Context, page *Page[Tool]) ([]byte, error) {
 	list := mcpToolList{
-		Tools: make([]mcpTool, 0, len(tools)),
+		Tools:      make([]mcpTool, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
 	}
 
-	for _, t := range tools {
-		list.Tools = append(list.Tools, mcpTool(t))
+	f := w.Features()
+	for _, t := range page.Items {
+		tool := mcpTool(t)
+		if !f.StructuredOutput {
+			tool.OutputSchema = nil
+		}
+		if !f.ToolAnnotations {
+			tool.Annotations = nil
+		}
+		list.Tools = append(list.Tools, tool)
 	}
 
 	return json.Marshal(list)
 }
 
-// DecodeToolList decodes MCP format to a tool list.
-func (w *MCPWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, error) {
+// DecodeToolPage decodes one page of a tools/list result.
+func (w *MCPWire) DecodeToolPage(ctx context.Context, data []byte) (*Page[Tool], error) {
 	var list mcpToolList
 	if err := json.Unmarshal(data, &list); err != nil {
 		return nil, fmt.Errorf("decode tool list: %w", err)
@@ -232,14 +376,14 @@ func (w *MCPWire) DecodeToolList(ctx context.Context, data []byte) ([]Tool, erro
 		tools = append(tools, Tool(t))
 	}
 
-	return tools, nil
+	return &Page[Tool]{Items: tools, NextCursor: list.NextCursor}, nil
 }
 
 // Capabilities returns MCP protocol capabilities.
 func (w *MCPWire) Capabilities() *Capabilities {
 	return &Capabilities{
 		Streaming:     true,
-		BatchRequests: false,
+		BatchRequests: w.Features().Batching,
 		Progress:      true,
 		Cancellation:  true,
 	}
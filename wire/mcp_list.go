@@ -0,0 +1,252 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/prompt"
+	"github.com/jonwraymond/toolprotocol/resource"
+	"github.com/jonwraymond/toolprotocol/task"
+)
+
+// MCP list methods.
+const (
+	MCPMethodToolsList     = "tools/list"
+	MCPMethodResourcesList = "resources/list"
+	MCPMethodPromptsList   = "prompts/list"
+	MCPMethodTasksList     = "tasks/list"
+)
+
+// MCP task statuses (2025-11-25).
+const (
+	MCPTaskWorking       = "working"
+	MCPTaskInputRequired = "input_required"
+	MCPTaskCompleted     = "completed"
+	MCPTaskFailed        = "failed"
+	MCPTaskCancelled     = "cancelled"
+)
+
+// EncodeListRequest encodes a list request. The cursor is omitted for
+// the first page. Methods introduced after the codec's revision are
+// rejected with ErrUnsupportedFeature.
+func (w *MCPWire) EncodeListRequest(ctx context.Context, req *ListRequest) ([]byte, error) {
+	if !strings.HasSuffix(req.Method, "/list") {
+		return nil, fmt.Errorf("%w: %q is not a list method", ErrEncodeFailure, req.Method)
+	}
+	if err := w.checkMethod(req.Method); err != nil {
+		return nil, err
+	}
+	type params struct {
+		Cursor string `json:"cursor"`
+	}
+	rpc := struct {
+		JSONRPC string  `json:"jsonrpc"`
+		ID      string  `json:"id"`
+		Method  string  `json:"method"`
+		Params  *params `json:"params,omitempty"`
+	}{JSONRPC: "2.0", ID: req.ID, Method: req.Method}
+	if req.Cursor != "" {
+		rpc.Params = &params{Cursor: req.Cursor}
+	}
+	return json.Marshal(rpc)
+}
+
+// DecodeListRequest decodes a list request.
+func (w *MCPWire) DecodeListRequest(ctx context.Context, data []byte) (*ListRequest, error) {
+	var rpc struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+		Params *struct {
+			Cursor string `json:"cursor"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return nil, fmt.Errorf("decode list request: %w", err)
+	}
+	if !strings.HasSuffix(rpc.Method, "/list") {
+		return nil, fmt.Errorf("decode list request: %w: not a list request", ErrDecodeFailure)
+	}
+	req := &ListRequest{ID: rpcID(rpc.ID), Method: rpc.Method}
+	if rpc.Params != nil {
+		req.Cursor = rpc.Params.Cursor
+	}
+	return req, nil
+}
+
+// mcpResourceList is the MCP resources/list result format.
+type mcpResourceList struct {
+	Resources  []mcpResource `json:"resources"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+type mcpResource struct {
+	URI         string         `json:"uri"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	MIMEType    string         `json:"mimeType,omitempty"`
+	Annotations map[string]any `json:"annotations,omitempty"`
+}
+
+// EncodeResourcePage encodes one page of a resources/list result.
+func (w *MCPWire) EncodeResourcePage(ctx context.Context, page *Page[resource.Resource]) ([]byte, error) {
+	list := mcpResourceList{
+		Resources:  make([]mcpResource, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, r := range page.Items {
+		list.Resources = append(list.Resources, mcpResource(r))
+	}
+	return json.Marshal(list)
+}
+
+// DecodeResourcePage decodes one page of a resources/list result.
+func (w *MCPWire) DecodeResourcePage(ctx context.Context, data []byte) (*Page[resource.Resource], error) {
+	var list mcpResourceList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decode resource list: %w", err)
+	}
+	resources := make([]resource.Resource, 0, len(list.Resources))
+	for _, r := range list.Resources {
+		resources = append(resources, resource.Resource(r))
+	}
+	return &Page[resource.Resource]{Items: resources, NextCursor: list.NextCursor}, nil
+}
+
+// mcpPromptList is the MCP prompts/list result format.
+type mcpPromptList struct {
+	Prompts    []mcpPrompt `json:"prompts"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+type mcpPrompt struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Arguments   []mcpPromptArgument `json:"arguments,omitempty"`
+}
+
+type mcpPromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// EncodePromptPage encodes one page of a prompts/list result.
+func (w *MCPWire) EncodePromptPage(ctx context.Context, page *Page[prompt.Prompt]) ([]byte, error) {
+	list := mcpPromptList{
+		Prompts:    make([]mcpPrompt, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, p := range page.Items {
+		mp := mcpPrompt{Name: p.Name, Description: p.Description}
+		for _, a := range p.Arguments {
+			mp.Arguments = append(mp.Arguments, mcpPromptArgument(a))
+		}
+		list.Prompts = append(list.Prompts, mp)
+	}
+	return json.Marshal(list)
+}
+
+// DecodePromptPage decodes one page of a prompts/list result.
+func (w *MCPWire) DecodePromptPage(ctx context.Context, data []byte) (*Page[prompt.Prompt], error) {
+	var list mcpPromptList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decode prompt list: %w", err)
+	}
+	prompts := make([]prompt.Prompt, 0, len(list.Prompts))
+	for _, mp := range list.Prompts {
+		p := prompt.Prompt{Name: mp.Name, Description: mp.Description}
+		for _, a := range mp.Arguments {
+			p.Arguments = append(p.Arguments, prompt.Argument(a))
+		}
+		prompts = append(prompts, p)
+	}
+	return &Page[prompt.Prompt]{Items: prompts, NextCursor: list.NextCursor}, nil
+}
+
+// mcpTaskList is the MCP tasks/list result format.
+type mcpTaskList struct {
+	Tasks      []mcpTask `json:"tasks"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+}
+
+type mcpTask struct {
+	TaskID        string    `json:"taskId"`
+	Status        string    `json:"status"`
+	StatusMessage string    `json:"statusMessage,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	LastUpdatedAt time.Time `json:"lastUpdatedAt"`
+	TTL           *int64    `json:"ttl"`
+}
+
+// EncodeTaskPage encodes one page of a tasks/list result.
+// Revisions without tasks return ErrUnsupportedFeature.
+func (w *MCPWire) EncodeTaskPage(ctx context.Context, page *Page[*task.Task]) ([]byte, error) {
+	if err := w.checkMethod(MCPMethodTasksList); err != nil {
+		return nil, err
+	}
+	list := mcpTaskList{
+		Tasks:      make([]mcpTask, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, t := range page.Items {
+		list.Tasks = append(list.Tasks, mcpTask{
+			TaskID:        t.ID,
+			Status:        mcpTaskStatus(t.State),
+			StatusMessage: t.Message,
+			CreatedAt:     t.CreatedAt,
+			LastUpdatedAt: t.UpdatedAt,
+		})
+	}
+	return json.Marshal(list)
+}
+
+// DecodeTaskPage decodes one page of a tasks/list result. MCP does not
+// distinguish pending from running tasks; both decode as running.
+func (w *MCPWire) DecodeTaskPage(ctx context.Context, data []byte) (*Page[*task.Task], error) {
+	var list mcpTaskList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decode task list: %w", err)
+	}
+	tasks := make([]*task.Task, 0, len(list.Tasks))
+	for _, mt := range list.Tasks {
+		tasks = append(tasks, &task.Task{
+			ID:        mt.TaskID,
+			State:     taskStateFromMCP(mt.Status),
+			Message:   mt.StatusMessage,
+			CreatedAt: mt.CreatedAt,
+			UpdatedAt: mt.LastUpdatedAt,
+		})
+	}
+	return &Page[*task.Task]{Items: tasks, NextCursor: list.NextCursor}, nil
+}
+
+// mcpTaskStatus maps task manager states to MCP task statuses.
+func mcpTaskStatus(s task.State) string {
+	switch s {
+	case task.StateComplete:
+		return MCPTaskCompleted
+	case task.StateFailed:
+		return MCPTaskFailed
+	case task.StateCancelled:
+		return MCPTaskCancelled
+	default:
+		return MCPTaskWorking
+	}
+}
+
+// taskStateFromMCP maps MCP task statuses to task manager states.
+func taskStateFromMCP(status string) task.State {
+	switch status {
+	case MCPTaskCompleted:
+		return task.StateComplete
+	case MCPTaskFailed:
+		return task.StateFailed
+	case MCPTaskCancelled:
+		return task.StateCancelled
+	default:
+		return task.StateRunning
+	}
+}
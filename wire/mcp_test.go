@@ -3,7 +3,12 @@ package wire
 import (
 	"context"
 	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/content"
 )
 
 func TestMCPWire_Name(t *testing.T) {
@@ -559,3 +564,124 @@ func TestMCPWire_EncodeResponse_ResourceNoMime(t *testing.T) {
 		t.Fatalf("invalid JSON: %v", err)
 	}
 }
+
+func TestMCPWire_ToolExecutionError_RoundTrip(t *testing.T) {
+	w := NewMCP()
+	ctx := context.Background()
+
+	resp := &Response{
+		ID:      "4",
+		Content: []Content{{Type: ContentTypeText, Text: "API rate limit exceeded"}},
+		IsError: true,
+	}
+	data, err := w.EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	var rpc struct {
+		Result map[string]any `json:"result"`
+		Error  any            `json:"error"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if rpc.Result["isError"] != true || rpc.Error != nil {
+		t.Errorf("encoded = %s, want result with isError", data)
+	}
+
+	got, err := w.DecodeResponse(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if !got.IsError || got.Error != nil || len(got.Content) != 1 {
+		t.Errorf("decoded = %+v, want tool error with content", got)
+	}
+}
+
+func TestMCPWire_DecodeResponse_EmbeddedResource(t *testing.T) {
+	data := []byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"resource","resource":{"uri":"resource://example","mimeType":"text/plain","text":"Resource content"}}]}}`)
+	resp, err := NewMCP().DecodeResponse(context.Background(), data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	want := Content{Type: ContentTypeResource, URI: "resource://example", MIMEType: "text/plain", Text: "Resource content"}
+	if len(resp.Content) != 1 || !reflect.DeepEqual(resp.Content[0], want) {
+		t.Errorf("Content = %+v, want [%+v]", resp.Content, want)
+	}
+}
+
+func TestMCPWire_EncodeResponse_OtherContentTypes(t *testing.T) {
+	w := NewMCP()
+	ctx := context.Background()
+
+	resp := &Response{
+		ID:      "2",
+		Content: []Content{{Type: "audio", MIMEType: "audio/wav", Data: []byte("RIFF")}},
+	}
+	data, err := w.EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	got, err := w.DecodeResponse(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Content, resp.Content) {
+		t.Errorf("Content = %+v, want %+v", got.Content, resp.Content)
+	}
+}
+
+func TestMCPWire_Response_EmbeddedResourceRoundTrip(t *testing.T) {
+	w := NewMCP()
+	ctx := context.Background()
+
+	resp := &Response{
+		ID: "3",
+		Content: []Content{
+			{Type: ContentTypeResource, URI: "file:///a.txt", MIMEType: "text/plain", Text: "hello"},
+			{Type: ContentTypeResource, URI: "file:///a.bin", MIMEType: "application/octet-stream", Data: []byte{1, 2, 3}},
+		},
+	}
+	data, err := w.EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	if want := `{"resource":{"mimeType":"text/plain","text":"hello","uri":"file:///a.txt"},"type":"resource"}`; !strings.Contains(string(data), want) {
+		t.Errorf("encoded = %s, want embedded resource %s", data, want)
+	}
+	got, err := w.DecodeResponse(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Content, resp.Content) {
+		t.Errorf("Content = %+v, want %+v", got.Content, resp.Content)
+	}
+}
+
+func TestMCPWire_Response_Annotations(t *testing.T) {
+	w := NewMCP()
+	ctx := context.Background()
+
+	resp := &Response{
+		ID: "4",
+		Content: []Content{{Type: ContentTypeText, Text: "hi", Annotations: &content.Annotations{
+			Audience:     []content.Role{content.RoleUser, content.RoleAssistant},
+			Priority:     content.Priority(0.8),
+			LastModified: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		}}},
+	}
+	data, err := w.EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	if want := `"annotations":{"audience":["user","assistant"],"lastModified":"2025-01-02T03:04:05Z","priority":0.8}`; !strings.Contains(string(data), want) {
+		t.Errorf("encoded = %s, want %s", data, want)
+	}
+	got, err := w.DecodeResponse(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Content, resp.Content) {
+		t.Errorf("Content = %+v, want %+v", got.Content, resp.Content)
+	}
+}
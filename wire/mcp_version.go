@@ -0,0 +1,304 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MCP specification revisions understood by MCPWire.
+const (
+	MCPVersion20241105 = "2024-11-05"
+	MCPVersion20250326 = "2025-03-26"
+	MCPVersion20250618 = "2025-06-18"
+	MCPVersion20251125 = "2025-11-25"
+)
+
+// MCPFeatures describes which optional protocol features a specification
+// revision supports.
+type MCPFeatures struct {
+	// Batching indicates JSON-RPC batch support (2025-03-26 only).
+	Batching bool
+
+	// AudioContent indicates support for audio content blocks.
+	AudioContent bool
+
+	// ToolAnnotations indicates support for tool behavior annotations.
+	ToolAnnotations bool
+
+	// LastModified indicates content annotations carry lastModified.
+	LastModified bool
+
+	// ProgressMessage indicates progress notifications carry a message.
+	ProgressMessage bool
+
+	// StructuredOutput indicates support for outputSchema and structuredContent.
+	StructuredOutput bool
+
+	// Elicitation indicates support for elicitation/create requests.
+	Elicitation bool
+
+	// ResourceLinks indicates support for resource_link content blocks.
+	ResourceLinks bool
+
+	// ProtocolVersionHeader indicates HTTP requests carry MCP-Protocol-Version.
+	ProtocolVersionHeader bool
+
+	// Tasks indicates support for task-augmented requests and tasks/* methods.
+	Tasks bool
+
+	// URLElicitation indicates support for URL-mode elicitation.
+	URLElicitation bool
+}
+
+// mcpFeatures maps each known revision to its feature set.
+var mcpFeatures = map[string]MCPFeatures{
+	MCPVersion20241105: {},
+	MCPVersion20250326: {
+		Batching:        true,
+		AudioContent:    true,
+		ToolAnnotations: true,
+		ProgressMessage: true,
+	},
+	MCPVersion20250618: {
+		AudioContent:          true,
+		ToolAnnotations:       true,
+		LastModified:          true,
+		ProgressMessage:       true,
+		StructuredOutput:      true,
+		Elicitation:           true,
+		ResourceLinks:         true,
+		ProtocolVersionHeader: true,
+	},
+	MCPVersion20251125: {
+		AudioContent:          true,
+		ToolAnnotations:       true,
+		LastModified:          true,
+		ProgressMessage:       true,
+		StructuredOutput:      true,
+		Elicitation:           true,
+		ResourceLinks:         true,
+		ProtocolVersionHeader: true,
+		Tasks:                 true,
+		URLElicitation:        true,
+	},
+}
+
+// MCPSupportedVersions returns every MCP revision understood by MCPWire,
+// newest first.
+func MCPSupportedVersions() []string {
+	versions := make([]string, 0, len(mcpFeatures))
+	for v := range mcpFeatures {
+		versions = append(versions, v)
+	}
+	// Revisions are ISO dates, so lexical order is chronological.
+	slices.Sort(versions)
+	slices.Reverse(versions)
+	return versions
+}
+
+// Features returns the feature set of the codec's revision.
+func (w *MCPWire) Features() MCPFeatures {
+	return mcpFeatures[w.Version()]
+}
+
+// checkMethod rejects methods the codec's revision cannot express.
+func (w *MCPWire) checkMethod(method string) error {
+	f := w.Features()
+	switch {
+	case strings.HasPrefix(method, "elicitation/") && !f.Elicitation,
+		strings.HasPrefix(method, "tasks/") && !f.Tasks:
+		return fmt.Errorf("%w: %s not available in mcp %s", ErrUnsupportedFeature, method, w.Version())
+	}
+	return nil
+}
+
+// NegotiateMCPVersion selects the revision a server should answer an
+// initialize request with.
+//
+// If the requested revision is supported it is returned unchanged.
+// Otherwise the highest supported revision older than the request is
+// chosen, falling back to the newest supported revision; the client then
+// decides whether it can proceed. Unknown entries in supported are ignored
+// and a nil supported list means every revision MCPWire understands.
+func NegotiateMCPVersion(requested string, supported []string) (string, error) {
+	known := knownMCPVersions(supported)
+	if len(known) == 0 {
+		return "", fmt.Errorf("%w: no supported mcp versions", ErrUnsupportedVersion)
+	}
+	if slices.Contains(known, requested) {
+		return requested, nil
+	}
+	for _, v := range known {
+		if v < requested {
+			return v, nil
+		}
+	}
+	return known[0], nil
+}
+
+// knownMCPVersions filters supported to known revisions, newest first.
+func knownMCPVersions(supported []string) []string {
+	if supported == nil {
+		return MCPSupportedVersions()
+	}
+	known := make([]string, 0, len(supported))
+	for _, v := range supported {
+		if _, ok := mcpFeatures[v]; ok && !slices.Contains(known, v) {
+			known = append(known, v)
+		}
+	}
+	slices.Sort(known)
+	slices.Reverse(known)
+	return known
+}
+
+// MCPImplementation identifies an MCP client or server.
+type MCPImplementation struct {
+	Name    string `json:"name"`
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version"`
+}
+
+// MCPInitializeParams are the parameters of an initialize request.
+type MCPInitializeParams struct {
+	ProtocolVersion string            `json:"protocolVersion"`
+	Capabilities    map[string]any    `json:"capabilities"`
+	ClientInfo      MCPImplementation `json:"clientInfo"`
+}
+
+// MCPInitializeResult is the result of an initialize request.
+type MCPInitializeResult struct {
+	ProtocolVersion string            `json:"protocolVersion"`
+	Capabilities    map[string]any    `json:"capabilities"`
+	ServerInfo      MCPImplementation `json:"serverInfo"`
+	Instructions    string            `json:"instructions,omitempty"`
+}
+
+// MCPHandshake drives the MCP initialize exchange and yields a codec
+// pinned to the negotiated revision.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use; the handshake holds no state
+//     beyond its configuration.
+//   - Errors: Version mismatches return ErrUnsupportedVersion.
+type MCPHandshake struct {
+	// Supported lists the revisions this side accepts. Nil means every
+	// revision MCPWire understands.
+	Supported []string
+}
+
+// Params builds initialize parameters announcing the newest supported revision.
+func (h *MCPHandshake) Params(info MCPImplementation, capabilities map[string]any) (*MCPInitializeParams, error) {
+	known := knownMCPVersions(h.Supported)
+	if len(known) == 0 {
+		return nil, fmt.Errorf("%w: no supported mcp versions", ErrUnsupportedVersion)
+	}
+	if capabilities == nil {
+		capabilities = map[string]any{}
+	}
+	return &MCPInitializeParams{
+		ProtocolVersion: known[0],
+		Capabilities:    capabilities,
+		ClientInfo:      info,
+	}, nil
+}
+
+// Respond answers a client's initialize request on the server side.
+// It returns the result to send and a codec for the negotiated revision.
+func (h *MCPHandshake) Respond(params *MCPInitializeParams, info MCPImplementation, capabilities map[string]any) (*MCPInitializeResult, *MCPWire, error) {
+	version, err := NegotiateMCPVersion(params.ProtocolVersion, h.Supported)
+	if err != nil {
+		return nil, nil, err
+	}
+	if capabilities == nil {
+		capabilities = map[string]any{}
+	}
+	codec, err := NewMCPVersion(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &MCPInitializeResult{
+		ProtocolVersion: version,
+		Capabilities:    capabilities,
+		ServerInfo:      info,
+	}, codec, nil
+}
+
+// Complete validates a server's initialize result on the client side and
+// returns a codec for the agreed revision.
+func (h *MCPHandshake) Complete(result *MCPInitializeResult) (*MCPWire, error) {
+	if !slices.Contains(knownMCPVersions(h.Supported), result.ProtocolVersion) {
+		return nil, fmt.Errorf("%w: server selected mcp %q", ErrUnsupportedVersion, result.ProtocolVersion)
+	}
+	return NewMCPVersion(result.ProtocolVersion)
+}
+
+// EncodeInitialize encodes an initialize request.
+func (w *MCPWire) EncodeInitialize(ctx context.Context, id string, params *MCPInitializeParams) ([]byte, error) {
+	return json.Marshal(struct {
+		JSONRPC string               `json:"jsonrpc"`
+		ID      string               `json:"id"`
+		Method  string               `json:"method"`
+		Params  *MCPInitializeParams `json:"params"`
+	}{"2.0", id, "initialize", params})
+}
+
+// DecodeInitialize decodes an initialize request, returning its ID and params.
+func (w *MCPWire) DecodeInitialize(ctx context.Context, data []byte) (string, *MCPInitializeParams, error) {
+	var rpc struct {
+		ID     any                  `json:"id"`
+		Method string               `json:"method"`
+		Params *MCPInitializeParams `json:"params"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return "", nil, fmt.Errorf("decode initialize: %w", err)
+	}
+	if rpc.Method != "initialize" || rpc.Params == nil {
+		return "", nil, fmt.Errorf("decode initialize: %w: not an initialize request", ErrDecodeFailure)
+	}
+	return rpcID(rpc.ID), rpc.Params, nil
+}
+
+// EncodeInitializeResult encodes the response to an initialize request.
+func (w *MCPWire) EncodeInitializeResult(ctx context.Context, id string, result *MCPInitializeResult) ([]byte, error) {
+	return json.Marshal(struct {
+		JSONRPC string               `json:"jsonrpc"`
+		ID      string               `json:"id"`
+		Result  *MCPInitializeResult `json:"result"`
+	}{"2.0", id, result})
+}
+
+// DecodeInitializeResult decodes the response to an initialize request.
+// JSON-RPC errors are returned as *Error.
+func (w *MCPWire) DecodeInitializeResult(ctx context.Context, data []byte) (string, *MCPInitializeResult, error) {
+	var rpc struct {
+		ID     any                  `json:"id"`
+		Result *MCPInitializeResult `json:"result"`
+		Error  *jsonrpcError        `json:"error"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return "", nil, fmt.Errorf("decode initialize result: %w", err)
+	}
+	id := rpcID(rpc.ID)
+	if rpc.Error != nil {
+		return id, nil, decodedError("mcp", rpc.Error.Code, rpc.Error.Message, rpc.Error.Data)
+	}
+	if rpc.Result == nil {
+		return id, nil, fmt.Errorf("decode initialize result: %w: missing result", ErrDecodeFailure)
+	}
+	return id, rpc.Result, nil
+}
+
+// rpcID normalizes a JSON-RPC ID, which may be a string or a number.
+func rpcID(v any) string {
+	switch id := v.(type) {
+	case string:
+		return id
+	case float64:
+		return fmt.Sprintf("%.0f", id)
+	}
+	return ""
+}
@@ -0,0 +1,319 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/content"
+)
+
+func TestNewMCPVersion(t *testing.T) {
+	for _, v := range MCPSupportedVersions() {
+		w, err := NewMCPVersion(v)
+		if err != nil {
+			t.Fatalf("NewMCPVersion(%q) error = %v", v, err)
+		}
+		if w.Version() != v {
+			t.Errorf("Version() = %q, want %q", w.Version(), v)
+		}
+	}
+
+	if _, err := NewMCPVersion("2023-01-01"); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("NewMCPVersion(unknown) error = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestMCPSupportedVersions_Order(t *testing.T) {
+	got := MCPSupportedVersions()
+	want := []string{MCPVersion20251125, MCPVersion20250618, MCPVersion20250326, MCPVersion20241105}
+	if len(got) != len(want) {
+		t.Fatalf("len = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("versions[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if got[0] != MCPVersion {
+		t.Errorf("newest = %q, want MCPVersion %q", got[0], MCPVersion)
+	}
+}
+
+func TestMCPWire_Features(t *testing.T) {
+	tests := []struct {
+		version    string
+		batching   bool
+		structured bool
+		elicit     bool
+		tasks      bool
+	}{
+		{MCPVersion20241105, false, false, false, false},
+		{MCPVersion20250326, true, false, false, false},
+		{MCPVersion20250618, false, true, true, false},
+		{MCPVersion20251125, false, true, true, true},
+	}
+	for _, tt := range tests {
+		w, _ := NewMCPVersion(tt.version)
+		f := w.Features()
+		if f.Batching != tt.batching || f.StructuredOutput != tt.structured ||
+			f.Elicitation != tt.elicit || f.Tasks != tt.tasks {
+			t.Errorf("%s: Features() = %+v", tt.version, f)
+		}
+		if w.Capabilities().BatchRequests != tt.batching {
+			t.Errorf("%s: BatchRequests = %v, want %v", tt.version, w.Capabilities().BatchRequests, tt.batching)
+		}
+	}
+
+	var zero MCPWire
+	if !zero.Features().Tasks {
+		t.Error("zero MCPWire should use the latest revision")
+	}
+}
+
+func TestMCPWire_StructuredOutputGating(t *testing.T) {
+	ctx := context.Background()
+	resp := &Response{ID: "1", StructuredContent: map[string]any{"temperature": 22.5}}
+	tools := []Tool{{Name: "weather", OutputSchema: map[string]any{"type": "object"}}}
+
+	latest := NewMCP()
+	data, err := latest.EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	decoded, err := latest.DecodeResponse(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if decoded.StructuredContent["temperature"] != 22.5 {
+		t.Errorf("StructuredContent = %v, want temperature 22.5", decoded.StructuredContent)
+	}
+	list, _ := latest.EncodeToolList(ctx, tools)
+	back, _ := latest.DecodeToolList(ctx, list)
+	if back[0].OutputSchema == nil {
+		t.Error("OutputSchema dropped by latest revision")
+	}
+
+	old, _ := NewMCPVersion(MCPVersion20250326)
+	data, err = old.EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	var rpc struct {
+		Result map[string]any `json:"result"`
+	}
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if _, ok := rpc.Result["structuredContent"]; ok {
+		t.Error("structuredContent emitted for 2025-03-26")
+	}
+	decoded, _ = old.DecodeResponse(ctx, data)
+	if len(decoded.Content) != 1 || decoded.Content[0].Text != `{"temperature":22.5}` {
+		t.Errorf("Content = %v, want serialized JSON text fallback", decoded.Content)
+	}
+
+	list, _ = old.EncodeToolList(ctx, tools)
+	var toolList map[string][]map[string]any
+	_ = json.Unmarshal(list, &toolList)
+	if _, ok := toolList["tools"][0]["outputSchema"]; ok {
+		t.Error("outputSchema emitted for 2025-03-26")
+	}
+}
+
+func TestMCPWire_ContentGating(t *testing.T) {
+	ctx := context.Background()
+	annotated := &content.Annotations{
+		Priority:     content.Priority(0.5),
+		LastModified: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	link := &Response{ID: "1", Content: []Content{{Type: "resource_link", URI: "file:///a", MIMEType: "text/plain", Annotations: annotated}}}
+	audio := &Response{ID: "2", Content: []Content{{Type: ContentTypeAudio, MIMEType: "audio/wav", Data: []byte("RIFF")}}}
+	tools := []Tool{{Name: "read", Annotations: map[string]any{"readOnlyHint": true}}}
+
+	tests := []struct {
+		version      string
+		audio        bool
+		linkType     string
+		lastModified bool
+		toolHints    bool
+	}{
+		{MCPVersion20241105, false, "resource", false, false},
+		{MCPVersion20250326, true, "resource", false, true},
+		{MCPVersion20250618, true, "resource_link", true, true},
+		{MCPVersion20251125, true, "resource_link", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			w, _ := NewMCPVersion(tt.version)
+
+			_, err := w.EncodeResponse(ctx, audio)
+			if gotAudio := err == nil; gotAudio != tt.audio {
+				t.Errorf("audio encoded = %v (error %v), want %v", gotAudio, err, tt.audio)
+			}
+			if !tt.audio && !errors.Is(err, ErrUnsupportedFeature) {
+				t.Errorf("audio error = %v, want ErrUnsupportedFeature", err)
+			}
+
+			data, err := w.EncodeResponse(ctx, link)
+			if err != nil {
+				t.Fatalf("EncodeResponse error = %v", err)
+			}
+			var rpc struct {
+				Result struct {
+					Content []map[string]any `json:"content"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(data, &rpc); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			block := rpc.Result.Content[0]
+			if block["type"] != tt.linkType || block["uri"] != "file:///a" {
+				t.Errorf("link block = %v, want type %s", block, tt.linkType)
+			}
+			annotations, _ := block["annotations"].(map[string]any)
+			if _, ok := annotations["lastModified"]; ok != tt.lastModified || annotations["priority"] != 0.5 {
+				t.Errorf("annotations = %v, want lastModified %v", annotations, tt.lastModified)
+			}
+
+			list, _ := w.EncodeToolList(ctx, tools)
+			back, _ := w.DecodeToolList(ctx, list)
+			if hasHints := back[0].Annotations != nil; hasHints != tt.toolHints {
+				t.Errorf("tool annotations = %v, want present %v", back[0].Annotations, tt.toolHints)
+			}
+		})
+	}
+}
+
+func TestMCPWire_MethodGating(t *testing.T) {
+	ctx := context.Background()
+	old, _ := NewMCPVersion(MCPVersion20250326)
+
+	for _, method := range []string{"elicitation/create", "tasks/get"} {
+		if _, err := old.EncodeRequest(ctx, &Request{ID: "1", Method: method}); !errors.Is(err, ErrUnsupportedFeature) {
+			t.Errorf("EncodeRequest(%s) error = %v, want ErrUnsupportedFeature", method, err)
+		}
+		if _, err := NewMCP().EncodeRequest(ctx, &Request{ID: "1", Method: method}); err != nil {
+			t.Errorf("latest EncodeRequest(%s) error = %v", method, err)
+		}
+	}
+}
+
+func TestNegotiateMCPVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		supported []string
+		want      string
+	}{
+		{"exact", MCPVersion20250618, nil, MCPVersion20250618},
+		{"newer client", "2099-01-01", nil, MCPVersion20251125},
+		{"older server", MCPVersion20251125, []string{MCPVersion20241105, MCPVersion20250326}, MCPVersion20250326},
+		{"older client", MCPVersion20241105, []string{MCPVersion20250618}, MCPVersion20250618},
+		{"ignores unknown", MCPVersion20250618, []string{"bogus", MCPVersion20250326}, MCPVersion20250326},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NegotiateMCPVersion(tt.requested, tt.supported)
+			if err != nil {
+				t.Fatalf("NegotiateMCPVersion error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NegotiateMCPVersion = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NegotiateMCPVersion(MCPVersion, []string{"bogus"}); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("NegotiateMCPVersion(no known) error = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestMCPHandshake(t *testing.T) {
+	ctx := context.Background()
+	client := &MCPHandshake{Supported: []string{MCPVersion20250618, MCPVersion20250326}}
+	server := &MCPHandshake{Supported: []string{MCPVersion20250326, MCPVersion20241105}}
+
+	params, err := client.Params(MCPImplementation{Name: "client", Version: "1.0"}, nil)
+	if err != nil {
+		t.Fatalf("Params error = %v", err)
+	}
+	if params.ProtocolVersion != MCPVersion20250618 {
+		t.Errorf("ProtocolVersion = %q, want newest client version", params.ProtocolVersion)
+	}
+
+	// Round-trip the initialize request through the codec.
+	codec := NewMCP()
+	data, err := codec.EncodeInitialize(ctx, "0", params)
+	if err != nil {
+		t.Fatalf("EncodeInitialize error = %v", err)
+	}
+	id, gotParams, err := codec.DecodeInitialize(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeInitialize error = %v", err)
+	}
+
+	result, serverCodec, err := server.Respond(gotParams, MCPImplementation{Name: "server", Version: "2.0"}, map[string]any{"tools": map[string]any{}})
+	if err != nil {
+		t.Fatalf("Respond error = %v", err)
+	}
+	if result.ProtocolVersion != MCPVersion20250326 {
+		t.Errorf("negotiated = %q, want %q", result.ProtocolVersion, MCPVersion20250326)
+	}
+	if serverCodec.Version() != MCPVersion20250326 {
+		t.Errorf("server codec version = %q", serverCodec.Version())
+	}
+
+	data, err = codec.EncodeInitializeResult(ctx, id, result)
+	if err != nil {
+		t.Fatalf("EncodeInitializeResult error = %v", err)
+	}
+	_, gotResult, err := codec.DecodeInitializeResult(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeInitializeResult error = %v", err)
+	}
+
+	clientCodec, err := client.Complete(gotResult)
+	if err != nil {
+		t.Fatalf("Complete error = %v", err)
+	}
+	if clientCodec.Version() != MCPVersion20250326 {
+		t.Errorf("client codec version = %q", clientCodec.Version())
+	}
+
+	strict := &MCPHandshake{Supported: []string{MCPVersion20251125}}
+	if _, err := strict.Complete(gotResult); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Complete(unsupported) error = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestMCPWire_DecodeInitialize_Errors(t *testing.T) {
+	ctx := context.Background()
+	w := NewMCP()
+
+	if _, _, err := w.DecodeInitialize(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}`)); !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("DecodeInitialize(ping) error = %v, want ErrDecodeFailure", err)
+	}
+
+	_, _, err := w.DecodeInitializeResult(ctx, []byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Unsupported protocol version"}}`))
+	var wireErr *Error
+	if !errors.As(err, &wireErr) || wireErr.Code != -32602 {
+		t.Errorf("DecodeInitializeResult error = %v, want *Error code -32602", err)
+	}
+}
+
+func TestDetector_VersionedMCPCodec(t *testing.T) {
+	header := http.Header{}
+	header.Set(MCPProtocolVersionHeader, MCPVersion20250326)
+
+	det, err := Detect([]byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`), header)
+	if err != nil {
+		t.Fatalf("Detect error = %v", err)
+	}
+	if det.Wire.Version() != MCPVersion20250326 {
+		t.Errorf("Wire.Version() = %q, want %q", det.Wire.Version(), MCPVersion20250326)
+	}
+}
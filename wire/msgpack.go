@@ -0,0 +1,296 @@
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// msgpackEncoding encodes values in MessagePack using the smallest
+// format for each value: fixint, fixstr, fixarray and fixmap where they
+// fit, integers for integral numbers and float32 where it is exact.
+//
+// Decoding accepts every MessagePack format except extension types,
+// whose meaning is application-defined. Map keys must be strings.
+type msgpackEncoding struct{}
+
+func (msgpackEncoding) Name() string        { return "msgpack" }
+func (msgpackEncoding) ContentType() string { return MediaTypeMsgPack }
+
+func (msgpackEncoding) Marshal(v any) ([]byte, error) {
+	var w msgpackWriter
+	if err := writeValue(&w, v, 0); err != nil {
+		return nil, fmt.Errorf("%w: msgpack: %w", ErrEncodeFailure, err)
+	}
+	return w.buf, nil
+}
+
+func (msgpackEncoding) Unmarshal(data []byte) (any, error) {
+	r := &msgpackReader{data: data}
+	v, err := r.value(0)
+	if err == nil && r.off != len(data) {
+		err = errors.New("trailing data")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: msgpack: %w", ErrDecodeFailure, malformedError{err})
+	}
+	return v, nil
+}
+
+type msgpackWriter struct {
+	buf []byte
+}
+
+func (w *msgpackWriter) writeNil() { w.buf = append(w.buf, 0xc0) }
+
+func (w *msgpackWriter) writeBool(b bool) {
+	if b {
+		w.buf = append(w.buf, 0xc3)
+		return
+	}
+	w.buf = append(w.buf, 0xc2)
+}
+
+func (w *msgpackWriter) writeInt(n int64) {
+	switch {
+	case n >= 0:
+		w.writeUint(uint64(n))
+	case n >= -32:
+		w.buf = append(w.buf, byte(n))
+	case n >= math.MinInt8:
+		w.buf = append(w.buf, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xd2), uint32(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xd3), uint64(n))
+	}
+}
+
+func (w *msgpackWriter) writeUint(n uint64) {
+	switch {
+	case n <= 0x7f:
+		w.buf = append(w.buf, byte(n))
+	case n <= math.MaxUint8:
+		w.buf = append(w.buf, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, 0xcd), uint16(n))
+	case n <= math.MaxUint32:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xce), uint32(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xcf), n)
+	}
+}
+
+func (w *msgpackWriter) writeFloat(f float64) {
+	if f32 := float32(f); float64(f32) == f {
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, 0xca), math.Float32bits(f32))
+		return
+	}
+	w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xcb), math.Float64bits(f))
+}
+
+func (w *msgpackWriter) writeString(s string) {
+	n := len(s)
+	switch {
+	case n <= 31:
+		w.buf = append(w.buf, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		w.buf = append(w.buf, 0xd9, byte(n))
+	default:
+		w.length(0xda, 0xdb, n)
+	}
+	w.buf = append(w.buf, s...)
+}
+
+func (w *msgpackWriter) writeBytes(b []byte) {
+	if len(b) <= math.MaxUint8 {
+		w.buf = append(w.buf, 0xc4, byte(len(b)))
+	} else {
+		w.length(0xc5, 0xc6, len(b))
+	}
+	w.buf = append(w.buf, b...)
+}
+
+func (w *msgpackWriter) writeArrayHeader(n int) {
+	if n <= 15 {
+		w.buf = append(w.buf, 0x90|byte(n))
+		return
+	}
+	w.length(0xdc, 0xdd, n)
+}
+
+func (w *msgpackWriter) writeMapHeader(n int) {
+	if n <= 15 {
+		w.buf = append(w.buf, 0x80|byte(n))
+		return
+	}
+	w.length(0xde, 0xdf, n)
+}
+
+// length writes a 16- or 32-bit length with the matching format byte.
+func (w *msgpackWriter) length(f16, f32 byte, n int) {
+	if n <= math.MaxUint16 {
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, f16), uint16(n))
+		return
+	}
+	w.buf = binary.BigEndian.AppendUint32(append(w.buf, f32), uint32(n))
+}
+
+type msgpackReader struct {
+	data []byte
+	off  int
+}
+
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(r.data)-r.off {
+		return nil, fmt.Errorf("unexpected end of data at offset %d", r.off)
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b, nil
+}
+
+// uint reads a big-endian unsigned integer of size bytes.
+func (r *msgpackReader) uint(size int) (uint64, error) {
+	b, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n, nil
+}
+
+func (r *msgpackReader) value(depth int) (any, error) {
+	if depth > maxEncodingDepth {
+		return nil, fmt.Errorf("nesting exceeds %d levels", maxEncodingDepth)
+	}
+	b, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	f := b[0]
+	switch {
+	case f <= 0x7f:
+		return float64(f), nil
+	case f >= 0xe0:
+		return float64(int8(f)), nil
+	case f&0xe0 == 0xa0:
+		return r.str(int(f & 0x1f))
+	case f&0xf0 == 0x90:
+		return r.array(int(f&0x0f), depth)
+	case f&0xf0 == 0x80:
+		return r.object(int(f&0x0f), depth)
+	}
+
+	switch f {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := r.uint(1 << (f - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		p, err := r.next(int(min(n, math.MaxInt32)))
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), p...), nil
+	case 0xca:
+		n, err := r.uint(4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := r.uint(8)
+		return math.Float64frombits(n), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := r.uint(1 << (f - 0xcc))
+		return float64(n), err
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (f - 0xd0)
+		n, err := r.uint(size)
+		// Sign-extend from size bytes.
+		shift := 64 - 8*size
+		return float64(int64(n<<shift) >> shift), err
+	case 0xd9, 0xda, 0xdb:
+		n, err := r.uint(1 << (f - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return r.str(int(min(n, math.MaxInt32)))
+	case 0xdc, 0xdd:
+		n, err := r.uint(2 << (f - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return r.array(int(min(n, math.MaxInt32)), depth)
+	case 0xde, 0xdf:
+		n, err := r.uint(2 << (f - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return r.object(int(min(n, math.MaxInt32)), depth)
+	case 0xc7, 0xc8, 0xc9, 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return nil, fmt.Errorf("unsupported extension type at offset %d", r.off-1)
+	}
+	return nil, fmt.Errorf("invalid format byte 0x%02x at offset %d", f, r.off-1)
+}
+
+func (r *msgpackReader) str(n int) (string, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("invalid UTF-8 in string")
+	}
+	return string(b), nil
+}
+
+func (r *msgpackReader) array(n, depth int) ([]any, error) {
+	// Every item takes at least one byte.
+	if n > len(r.data)-r.off {
+		return nil, fmt.Errorf("array length %d exceeds data", n)
+	}
+	out := make([]any, n)
+	for i := range out {
+		v, err := r.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func (r *msgpackReader) object(n, depth int) (map[string]any, error) {
+	if n > (len(r.data)-r.off)/2 {
+		return nil, fmt.Errorf("map length %d exceeds data", n)
+	}
+	out := make(map[string]any, n)
+	for range n {
+		k, err := r.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key %v is not a string", k)
+		}
+		v, err := r.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = v
+	}
+	return out, nil
+}
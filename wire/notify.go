@@ -0,0 +1,400 @@
+package wire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/jonwraymond/toolprotocol/stream"
+)
+
+// Notification methods for progress and cancellation.
+const (
+	MethodProgress  = "notifications/progress"
+	MethodCancelled = "notifications/cancelled"
+)
+
+// ID is a JSON-RPC request ID or an MCP progress token: a string or an
+// integer. It keeps the JSON type it was decoded with, so a numeric ID is
+// sent back as a number. The zero value is the absent ID.
+type ID struct {
+	text   string
+	number bool
+}
+
+// StringID returns a string ID.
+func StringID(s string) ID {
+	return ID{text: s}
+}
+
+// IntID returns an integer ID.
+func IntID(n int64) ID {
+	return ID{text: strconv.FormatInt(n, 10), number: true}
+}
+
+// String returns the ID's text; integers are in decimal form.
+func (id ID) String() string {
+	return id.text
+}
+
+// IsZero reports whether the ID is absent or the empty string.
+func (id ID) IsZero() bool {
+	return id.text == ""
+}
+
+// IsNumber reports whether the ID is an integer.
+func (id ID) IsNumber() bool {
+	return id.number
+}
+
+// MarshalJSON encodes the ID as a JSON string or number, or null when
+// it is zero.
+func (id ID) MarshalJSON() ([]byte, error) {
+	switch {
+	case id.IsZero():
+		return []byte("null"), nil
+	case id.number:
+		return []byte(id.text), nil
+	}
+	return json.Marshal(id.text)
+}
+
+// UnmarshalJSON decodes a JSON string or integer; null leaves the ID
+// zero. Other values return ErrDecodeFailure.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	if v == nil {
+		*id = ID{}
+		return nil
+	}
+	got, ok := idFromValue(v)
+	if !ok {
+		return fmt.Errorf("%w: id %s is not a string or an integer", ErrDecodeFailure, data)
+	}
+	*id = got
+	return nil
+}
+
+// value returns the ID as a string or int64, for metadata maps.
+func (id ID) value() any {
+	if id.number {
+		n, _ := strconv.ParseInt(id.text, 10, 64)
+		return n
+	}
+	return id.text
+}
+
+// idFromValue converts a decoded string or integral number to an ID.
+func idFromValue(v any) (ID, bool) {
+	switch t := v.(type) {
+	case string:
+		return StringID(t), true
+	case float64:
+		if t != math.Trunc(t) || math.Abs(t) > 1<<53 {
+			return ID{}, false
+		}
+		return IntID(int64(t)), true
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			return IntID(n), true
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return ID{}, false
+		}
+		return idFromValue(f)
+	case int:
+		return IntID(int64(t)), true
+	case int64:
+		return IntID(t), true
+	}
+	return ID{}, false
+}
+
+// Progress reports how far a long-running request has advanced.
+type Progress struct {
+	// Token is the progress token the requester supplied in _meta.
+	Token ID
+
+	// Progress is the amount of work done so far. It increases with
+	// every notification, even when the total is unknown.
+	Progress float64
+
+	// Total is the total amount of work, or zero when unknown.
+	Total float64
+
+	// Message describes the current step. Protocol revisions without
+	// progress messages drop it.
+	Message string
+}
+
+// Fraction returns Progress/Total, clamped to [0, 1]. It reports false
+// when the total is unknown.
+func (p *Progress) Fraction() (float64, bool) {
+	if p.Total <= 0 {
+		return 0, false
+	}
+	return min(max(p.Progress/p.Total, 0), 1), true
+}
+
+// Cancellation asks the receiver to stop processing a request.
+type Cancellation struct {
+	// RequestID is the ID of the request to cancel.
+	RequestID ID
+
+	// Reason optionally explains why the request was cancelled.
+	Reason string
+}
+
+// NotificationCodec is implemented by wire formats that carry progress
+// and cancellation as standalone notifications.
+//
+// Contract:
+//   - Concurrency: Implementations must be safe for concurrent use.
+//   - Errors: Decode methods return ErrDecodeFailure for other messages.
+type NotificationCodec interface {
+	// EncodeProgress encodes a progress notification.
+	EncodeProgress(ctx context.Context, p *Progress) ([]byte, error)
+
+	// DecodeProgress decodes a progress notification.
+	DecodeProgress(ctx context.Context, data []byte) (*Progress, error)
+
+	// EncodeCancellation encodes a cancellation notification.
+	EncodeCancellation(ctx context.Context, c *Cancellation) ([]byte, error)
+
+	// DecodeCancellation decodes a cancellation notification.
+	DecodeCancellation(ctx context.Context, data []byte) (*Cancellation, error)
+}
+
+var _ NotificationCodec = (*MCPWire)(nil)
+
+// mcpProgressParams are the params of notifications/progress.
+type mcpProgressParams struct {
+	ProgressToken ID      `json:"progressToken"`
+	Progress      float64 `json:"progress"`
+	Total         float64 `json:"total,omitempty"`
+	Message       string  `json:"message,omitempty"`
+}
+
+// mcpCancelledParams are the params of notifications/cancelled.
+type mcpCancelledParams struct {
+	RequestID ID     `json:"requestId"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// mcpNotification is a JSON-RPC notification with typed params.
+type mcpNotification[P any] struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  P      `json:"params"`
+}
+
+// EncodeProgress encodes a notifications/progress message. The message
+// is dropped for revisions before 2025-03-26.
+func (w *MCPWire) EncodeProgress(ctx context.Context, p *Progress) ([]byte, error) {
+	if p.Token.IsZero() {
+		return nil, fmt.Errorf("%w: progress token required", ErrEncodeFailure)
+	}
+	params := mcpProgressParams{
+		ProgressToken: p.Token,
+		Progress:      p.Progress,
+		Total:         p.Total,
+	}
+	if w.Features().ProgressMessage {
+		params.Message = p.Message
+	}
+	return json.Marshal(mcpNotification[mcpProgressParams]{"2.0", MethodProgress, params})
+}
+
+// DecodeProgress decodes a notifications/progress message.
+func (w *MCPWire) DecodeProgress(ctx context.Context, data []byte) (*Progress, error) {
+	var n mcpNotification[*mcpProgressParams]
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, fmt.Errorf("decode progress: %w", err)
+	}
+	if n.Method != MethodProgress || n.Params == nil {
+		return nil, fmt.Errorf("decode progress: %w: not a progress notification", ErrDecodeFailure)
+	}
+	if n.Params.ProgressToken.IsZero() {
+		return nil, fmt.Errorf("decode progress: %w: missing progress token", ErrDecodeFailure)
+	}
+	return &Progress{
+		Token:    n.Params.ProgressToken,
+		Progress: n.Params.Progress,
+		Total:    n.Params.Total,
+		Message:  n.Params.Message,
+	}, nil
+}
+
+// EncodeCancellation encodes a notifications/cancelled message.
+func (w *MCPWire) EncodeCancellation(ctx context.Context, c *Cancellation) ([]byte, error) {
+	if c.RequestID.IsZero() {
+		return nil, fmt.Errorf("%w: request id required", ErrEncodeFailure)
+	}
+	params := mcpCancelledParams{RequestID: c.RequestID, Reason: c.Reason}
+	return json.Marshal(mcpNotification[mcpCancelledParams]{"2.0", MethodCancelled, params})
+}
+
+// DecodeCancellation decodes a notifications/cancelled message.
+func (w *MCPWire) DecodeCancellation(ctx context.Context, data []byte) (*Cancellation, error) {
+	var n mcpNotification[*mcpCancelledParams]
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, fmt.Errorf("decode cancellation: %w", err)
+	}
+	if n.Method != MethodCancelled || n.Params == nil {
+		return nil, fmt.Errorf("decode cancellation: %w: not a cancellation notification", ErrDecodeFailure)
+	}
+	if n.Params.RequestID.IsZero() {
+		return nil, fmt.Errorf("decode cancellation: %w: missing request id", ErrDecodeFailure)
+	}
+	return &Cancellation{RequestID: n.Params.RequestID, Reason: n.Params.Reason}, nil
+}
+
+// ProgressToken returns the progress token from request metadata.
+// MCP allows string and integer tokens; the ID keeps which one it was.
+func ProgressToken(meta map[string]any) (ID, bool) {
+	v, ok := meta["progressToken"]
+	if !ok {
+		return ID{}, false
+	}
+	id, ok := idFromValue(v)
+	return id, ok && !id.IsZero()
+}
+
+// SetProgressToken stores a progress token in the request metadata,
+// asking the receiver to report progress for the request.
+func SetProgressToken(req *Request, token ID) {
+	if req.Meta == nil {
+		req.Meta = make(map[string]any)
+	}
+	req.Meta["progressToken"] = token.value()
+}
+
+// ProgressEvent converts a progress notification to a stream event.
+// The event ID is the progress token's text and the data is p.
+func ProgressEvent(p *Progress) stream.Event {
+	return stream.Event{Type: stream.EventProgress, ID: p.Token.String(), Data: p}
+}
+
+// ProgressFromEvent converts a stream progress event to a progress
+// notification for token. Event data may be a *Progress or Progress, or
+// a float64 fraction between 0 and 1 as produced by stream sources.
+// A zero token keeps the one carried by the data, or else uses the event
+// ID as a string token. It reports false for other events.
+func ProgressFromEvent(ev stream.Event, token ID) (*Progress, bool) {
+	if ev.Type != stream.EventProgress {
+		return nil, false
+	}
+	var p Progress
+	switch d := ev.Data.(type) {
+	case *Progress:
+		if d == nil {
+			return nil, false
+		}
+		p = *d
+	case Progress:
+		p = d
+	case float64:
+		p = Progress{Progress: d, Total: 1}
+	default:
+		return nil, false
+	}
+	switch {
+	case !token.IsZero():
+		p.Token = token
+	case p.Token.IsZero():
+		p.Token = StringID(ev.ID)
+	}
+	return &p, true
+}
+
+// CancellationFromContext returns the cancellation to send for
+// requestID once ctx is done, or nil while ctx is live. The reason is
+// the context's cause.
+func CancellationFromContext(ctx context.Context, requestID ID) *Cancellation {
+	if ctx.Err() == nil {
+		return nil
+	}
+	return &Cancellation{RequestID: requestID, Reason: context.Cause(ctx).Error()}
+}
+
+// Cancellations tracks in-flight requests so that cancellation
+// notifications cancel the matching request context. Requests are
+// tracked by the text of their ID, as Request.ID holds it, so a
+// cancellation for the integer 42 matches the request tracked as "42".
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use.
+//   - Lifecycle: The release function returned by Track must be called
+//     when the request finishes; it is idempotent.
+type Cancellations struct {
+	mu       sync.Mutex
+	inflight map[string]*inflightRequest
+}
+
+type inflightRequest struct {
+	cancel context.CancelCauseFunc
+}
+
+// NewCancellations creates an empty request tracker.
+func NewCancellations() *Cancellations {
+	return &Cancellations{inflight: make(map[string]*inflightRequest)}
+}
+
+// Track returns a context for handling requestID that is cancelled when
+// Cancel is called for the same ID. Tracking an ID that is already in
+// flight cancels the earlier request.
+func (c *Cancellations) Track(ctx context.Context, requestID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	r := &inflightRequest{cancel: cancel}
+	c.mu.Lock()
+	if prev, ok := c.inflight[requestID]; ok {
+		prev.cancel(ErrRequestCancelled)
+	}
+	c.inflight[requestID] = r
+	c.mu.Unlock()
+
+	return ctx, func() {
+		c.mu.Lock()
+		if c.inflight[requestID] == r {
+			delete(c.inflight, requestID)
+		}
+		c.mu.Unlock()
+		cancel(context.Canceled)
+	}
+}
+
+// Cancel cancels the tracked request named by n. The context cause wraps
+// ErrRequestCancelled with the notification's reason. It reports whether
+// a request was found; unknown IDs are ignored, as MCP requires.
+func (c *Cancellations) Cancel(n *Cancellation) bool {
+	c.mu.Lock()
+	r, ok := c.inflight[n.RequestID.String()]
+	delete(c.inflight, n.RequestID.String())
+	c.mu.Unlock()
+	if !ok {
+		return false
+	}
+	cause := ErrRequestCancelled
+	if n.Reason != "" {
+		cause = fmt.Errorf("%w: %s", ErrRequestCancelled, n.Reason)
+	}
+	r.cancel(cause)
+	return true
+}
+
+// Len returns the number of tracked requests.
+func (c *Cancellations) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.inflight)
+}
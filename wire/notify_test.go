@@ -0,0 +1,353 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/jonwraymond/toolprotocol/stream"
+)
+
+// TestCapabilities_Notifications checks that every codec claiming
+// progress or cancellation support encodes and decodes it.
+func TestCapabilities_Notifications(t *testing.T) {
+	ctx := context.Background()
+	wires := []Wire{NewBinary(EncodingCBOR), NewBinary(EncodingMsgPack), NewCanonical(NewA2A())}
+	for _, name := range DefaultRegistry().List() {
+		wires = append(wires, DefaultRegistry().Get(name))
+	}
+	for _, v := range []string{MCPVersion20241105, MCPVersion20250326, MCPVersion20250618, MCPVersion20251125} {
+		w, err := NewMCPVersion(v)
+		if err != nil {
+			t.Fatalf("NewMCPVersion(%s) error = %v", v, err)
+		}
+		wires = append(wires, w)
+	}
+	for _, w := range wires {
+		caps := w.Capabilities()
+		codec, ok := notificationCodec(w)
+		if !ok {
+			if caps.Progress || caps.Cancellation {
+				t.Errorf("%s@%s: Capabilities = %+v without a NotificationCodec", w.Name(), w.Version(), caps)
+			}
+			continue
+		}
+		if caps.Progress {
+			p := &Progress{Token: StringID("tok-1"), Progress: 3, Total: 10}
+			data, err := codec.EncodeProgress(ctx, p)
+			if err != nil {
+				t.Errorf("%s@%s: EncodeProgress error = %v", w.Name(), w.Version(), err)
+			} else if got, err := codec.DecodeProgress(ctx, data); err != nil || *got != *p {
+				t.Errorf("%s@%s: DecodeProgress = %+v, %v, want %+v", w.Name(), w.Version(), got, err, p)
+			}
+		}
+		if caps.Cancellation {
+			c := &Cancellation{RequestID: StringID("req-1")}
+			data, err := codec.EncodeCancellation(ctx, c)
+			if err != nil {
+				t.Errorf("%s@%s: EncodeCancellation error = %v", w.Name(), w.Version(), err)
+			} else if got, err := codec.DecodeCancellation(ctx, data); err != nil || *got != *c {
+				t.Errorf("%s@%s: DecodeCancellation = %+v, %v, want %+v", w.Name(), w.Version(), got, err, c)
+			}
+		}
+	}
+}
+
+// notificationCodec finds the NotificationCodec of w or a wire it wraps.
+func notificationCodec(w Wire) (NotificationCodec, bool) {
+	for {
+		if codec, ok := w.(NotificationCodec); ok {
+			return codec, true
+		}
+		u, ok := w.(interface{ Unwrap() Wire })
+		if !ok {
+			return nil, false
+		}
+		w = u.Unwrap()
+	}
+}
+
+func TestMCPWire_Progress(t *testing.T) {
+	ctx := context.Background()
+	p := &Progress{Token: StringID("tok-1"), Progress: 3, Total: 10, Message: "indexing"}
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{MCPVersion20251125, `{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":"tok-1","progress":3,"total":10,"message":"indexing"}}`},
+		{MCPVersion20241105, `{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":"tok-1","progress":3,"total":10}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			w, _ := NewMCPVersion(tt.version)
+			data, err := w.EncodeProgress(ctx, p)
+			if err != nil {
+				t.Fatalf("EncodeProgress error: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("EncodeProgress = %s, want %s", data, tt.want)
+			}
+			got, err := w.DecodeProgress(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeProgress error: %v", err)
+			}
+			if got.Token != StringID("tok-1") || got.Progress != 3 || got.Total != 10 {
+				t.Errorf("DecodeProgress = %+v", got)
+			}
+		})
+	}
+}
+
+func TestMCPWire_DecodeProgress(t *testing.T) {
+	ctx := context.Background()
+	w := NewMCP()
+
+	got, err := w.DecodeProgress(ctx, []byte(`{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":42,"progress":0.5}}`))
+	if err != nil {
+		t.Fatalf("DecodeProgress error: %v", err)
+	}
+	if got.Token != IntID(42) || got.Total != 0 {
+		t.Errorf("DecodeProgress = %+v, want integer token and unknown total", got)
+	}
+	// The integer token is sent back as a number.
+	data, err := w.EncodeProgress(ctx, got)
+	if err != nil {
+		t.Fatalf("EncodeProgress error: %v", err)
+	}
+	if want := `{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":42,"progress":0.5}}`; string(data) != want {
+		t.Errorf("EncodeProgress = %s, want %s", data, want)
+	}
+	if _, ok := got.Fraction(); ok {
+		t.Error("Fraction reported a value for an unknown total")
+	}
+
+	for name, data := range map[string]string{
+		"wrong method":   `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":"1"}}`,
+		"no params":      `{"jsonrpc":"2.0","method":"notifications/progress"}`,
+		"fraction token": `{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":1.5,"progress":1}}`,
+		"boolean token":  `{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":true,"progress":1}}`,
+		"no token":       `{"jsonrpc":"2.0","method":"notifications/progress","params":{"progress":1}}`,
+	} {
+		if _, err := w.DecodeProgress(ctx, []byte(data)); !errors.Is(err, ErrDecodeFailure) {
+			t.Errorf("%s: DecodeProgress error = %v, want ErrDecodeFailure", name, err)
+		}
+	}
+	if _, err := w.EncodeProgress(ctx, &Progress{Progress: 1}); !errors.Is(err, ErrEncodeFailure) {
+		t.Errorf("EncodeProgress without token error = %v, want ErrEncodeFailure", err)
+	}
+}
+
+func TestMCPWire_Cancellation(t *testing.T) {
+	ctx := context.Background()
+	w := NewMCP()
+
+	data, err := w.EncodeCancellation(ctx, &Cancellation{RequestID: StringID("req-7"), Reason: "user aborted"})
+	if err != nil {
+		t.Fatalf("EncodeCancellation error: %v", err)
+	}
+	want := `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":"req-7","reason":"user aborted"}}`
+	if string(data) != want {
+		t.Errorf("EncodeCancellation = %s, want %s", data, want)
+	}
+	got, err := w.DecodeCancellation(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeCancellation error: %v", err)
+	}
+	if *got != (Cancellation{RequestID: StringID("req-7"), Reason: "user aborted"}) {
+		t.Errorf("DecodeCancellation = %+v", got)
+	}
+
+	got, err = w.DecodeCancellation(ctx, []byte(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":3}}`))
+	if err != nil || got.RequestID != IntID(3) {
+		t.Errorf("DecodeCancellation numeric id = %+v, %v", got, err)
+	}
+	data, err = w.EncodeCancellation(ctx, got)
+	if want := `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":3}}`; err != nil || string(data) != want {
+		t.Errorf("EncodeCancellation numeric id = %s, %v, want %s", data, err, want)
+	}
+	if _, err := w.DecodeCancellation(ctx, []byte(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{}}`)); !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("DecodeCancellation without id error = %v, want ErrDecodeFailure", err)
+	}
+	if _, err := w.EncodeCancellation(ctx, &Cancellation{}); !errors.Is(err, ErrEncodeFailure) {
+		t.Errorf("EncodeCancellation without id error = %v, want ErrEncodeFailure", err)
+	}
+}
+
+func TestID_JSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ID
+		out  string
+	}{
+		{`"abc"`, StringID("abc"), `"abc"`},
+		{`"7"`, StringID("7"), `"7"`},
+		{`7`, IntID(7), `7`},
+		{`-3`, IntID(-3), `-3`},
+		{`7.0`, IntID(7), `7`},
+		{`null`, ID{}, `null`},
+	}
+	for _, tt := range tests {
+		var id ID
+		if err := json.Unmarshal([]byte(tt.in), &id); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", tt.in, err)
+		}
+		if id != tt.want {
+			t.Errorf("Unmarshal(%s) = %#v, want %#v", tt.in, id, tt.want)
+		}
+		out, err := json.Marshal(id)
+		if err != nil || string(out) != tt.out {
+			t.Errorf("Marshal(%s) = %s, %v, want %s", tt.in, out, err, tt.out)
+		}
+	}
+	if StringID("7") == IntID(7) {
+		t.Error(`StringID("7") == IntID(7)`)
+	}
+	for _, in := range []string{`1.5`, `true`, `{}`, `[1]`, `1e300`} {
+		var id ID
+		if err := json.Unmarshal([]byte(in), &id); !errors.Is(err, ErrDecodeFailure) {
+			t.Errorf("Unmarshal(%s) error = %v, want ErrDecodeFailure", in, err)
+		}
+	}
+}
+
+func TestProgressToken(t *testing.T) {
+	req := &Request{ID: "1", Method: "tools/call", ToolID: "search"}
+	if _, ok := ProgressToken(req.Meta); ok {
+		t.Error("ProgressToken found a token in empty meta")
+	}
+	SetProgressToken(req, StringID("abc"))
+
+	// The token survives an MCP round trip through _meta.
+	ctx := context.Background()
+	w := NewMCP()
+	data, _ := w.EncodeRequest(ctx, req)
+	decoded, err := w.DecodeRequest(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeRequest error: %v", err)
+	}
+	if tok, ok := ProgressToken(decoded.Meta); !ok || tok != StringID("abc") {
+		t.Errorf("ProgressToken = %v, %v, want abc", tok, ok)
+	}
+	if tok, ok := ProgressToken(map[string]any{"progressToken": float64(7)}); !ok || tok != IntID(7) {
+		t.Errorf("ProgressToken(7) = %v, %v", tok, ok)
+	}
+
+	// Integer tokens stay integers through _meta.
+	SetProgressToken(req, IntID(9))
+	data, _ = w.EncodeRequest(ctx, req)
+	decoded, _ = w.DecodeRequest(ctx, data)
+	if tok, ok := ProgressToken(decoded.Meta); !ok || tok != IntID(9) {
+		t.Errorf("ProgressToken = %v, %v, want integer 9", tok, ok)
+	}
+	if _, ok := ProgressToken(map[string]any{"progressToken": true}); ok {
+		t.Error("ProgressToken accepted a boolean token")
+	}
+}
+
+func TestProgressEvent(t *testing.T) {
+	p := &Progress{Token: StringID("tok"), Progress: 5, Total: 20, Message: "step"}
+	ev := ProgressEvent(p)
+	if ev.Type != stream.EventProgress || ev.ID != "tok" {
+		t.Errorf("ProgressEvent = %+v", ev)
+	}
+	back, ok := ProgressFromEvent(ev, ID{})
+	if !ok || *back != *p {
+		t.Errorf("ProgressFromEvent = %+v, %v, want %+v", back, ok, p)
+	}
+	if f, _ := back.Fraction(); f != 0.25 {
+		t.Errorf("Fraction = %v, want 0.25", f)
+	}
+
+	// Stream sources report a bare fraction.
+	got, ok := ProgressFromEvent(stream.Event{Type: stream.EventProgress, Data: 0.5}, IntID(3))
+	if !ok || got.Token != IntID(3) || got.Progress != 0.5 || got.Total != 1 {
+		t.Errorf("ProgressFromEvent(0.5) = %+v, %v", got, ok)
+	}
+	if _, ok := ProgressFromEvent(stream.Event{Type: stream.EventPartial, Data: 0.5}, StringID("t")); ok {
+		t.Error("ProgressFromEvent accepted a partial event")
+	}
+	if _, ok := ProgressFromEvent(stream.Event{Type: stream.EventProgress, Data: "half"}, StringID("t")); ok {
+		t.Error("ProgressFromEvent accepted string data")
+	}
+}
+
+func TestCancellationFromContext(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	if c := CancellationFromContext(ctx, StringID("req-1")); c != nil {
+		t.Errorf("CancellationFromContext(live) = %+v, want nil", c)
+	}
+	cancel(errors.New("client went away"))
+	c := CancellationFromContext(ctx, IntID(1))
+	if c == nil || c.RequestID != IntID(1) || c.Reason != "client went away" {
+		t.Errorf("CancellationFromContext = %+v", c)
+	}
+}
+
+func TestCancellations(t *testing.T) {
+	c := NewCancellations()
+	ctx, release := c.Track(context.Background(), "req-1")
+	defer release()
+	if c.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", c.Len())
+	}
+
+	if c.Cancel(&Cancellation{RequestID: StringID("other")}) {
+		t.Error("Cancel reported an unknown request")
+	}
+	if !c.Cancel(&Cancellation{RequestID: StringID("req-1"), Reason: "timeout"}) {
+		t.Fatal("Cancel did not find req-1")
+	}
+	<-ctx.Done()
+	cause := context.Cause(ctx)
+	if !errors.Is(cause, ErrRequestCancelled) || cause.Error() != "wire: request cancelled: timeout" {
+		t.Errorf("cause = %v", cause)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len after Cancel = %d, want 0", c.Len())
+	}
+}
+
+func TestCancellations_Release(t *testing.T) {
+	c := NewCancellations()
+	first, releaseFirst := c.Track(context.Background(), "req-1")
+	second, releaseSecond := c.Track(context.Background(), "req-1")
+
+	// Re-tracking an ID cancels the earlier request.
+	<-first.Done()
+	if !errors.Is(context.Cause(first), ErrRequestCancelled) {
+		t.Errorf("first cause = %v", context.Cause(first))
+	}
+
+	// Releasing the replaced request leaves the newer one tracked.
+	releaseFirst()
+	if c.Len() != 1 || second.Err() != nil {
+		t.Fatalf("Len = %d, second err = %v", c.Len(), second.Err())
+	}
+	releaseSecond()
+	releaseSecond()
+	if c.Len() != 0 || second.Err() == nil {
+		t.Errorf("Len = %d, second err = %v, want released", c.Len(), second.Err())
+	}
+}
+
+func TestCancellations_Concurrent(t *testing.T) {
+	c := NewCancellations()
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := string(rune('a' + i%26))
+			_, release := c.Track(context.Background(), id)
+			c.Cancel(&Cancellation{RequestID: StringID(id)})
+			release()
+		}()
+	}
+	wg.Wait()
+	if c.Len() != 0 {
+		t.Errorf("Len = %d, want 0", c.Len())
+	}
+}
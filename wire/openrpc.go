@@ -0,0 +1,211 @@
+package wire
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// OpenRPCVersion is the OpenRPC specification version of generated
+// documents.
+const OpenRPCVersion = "1.3.2"
+
+// OpenRPCDocument is an OpenRPC service description.
+type OpenRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       OpenRPCInfo       `json:"info"`
+	Methods    []OpenRPCMethod   `json:"methods"`
+	Components OpenRPCComponents `json:"components"`
+}
+
+// OpenRPCInfo is the metadata of an OpenRPC document.
+type OpenRPCInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// OpenRPCMethod describes one method of an OpenRPC document.
+type OpenRPCMethod struct {
+	Name           string                     `json:"name"`
+	Summary        string                     `json:"summary,omitempty"`
+	ParamStructure string                     `json:"paramStructure,omitempty"`
+	Params         []OpenRPCContentDescriptor `json:"params"`
+	Result         *OpenRPCContentDescriptor  `json:"result,omitempty"`
+}
+
+// OpenRPCContentDescriptor describes a method param or result.
+type OpenRPCContentDescriptor struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      map[string]any `json:"schema"`
+}
+
+// OpenRPCComponents holds the reusable schemas of an OpenRPC document.
+type OpenRPCComponents struct {
+	Schemas map[string]any `json:"schemas,omitempty"`
+}
+
+// OpenRPC describes w and tools as an OpenRPC document.
+//
+// Each method in the wire's MessageSchemas becomes a by-name method. The
+// input and output schemas of every tool are added to the components as
+// "<name>.input" and "<name>.output", and methods that invoke tools
+// narrow their tool name param to the listed names and their arguments
+// param to the tools' input schemas. Local references in tool schemas,
+// such as "#" or "#/$defs/node", are rewritten to point into their
+// component. The message envelopes are added as
+// the "Request", "Response", "ToolList" and "Error" components.
+//
+// An empty info title or version defaults to the wire's name and
+// version. Wires without schemas return ErrUnsupportedFeature, as do
+// wires that do not speak JSON-RPC.
+func OpenRPC(w Wire, tools []Tool, info OpenRPCInfo) (*OpenRPCDocument, error) {
+	schemas, err := ExportSchemas(w)
+	if err != nil {
+		return nil, err
+	}
+	if len(schemas.Methods) == 0 {
+		return nil, fmt.Errorf("%w: %s has no JSON-RPC methods", ErrUnsupportedFeature, w.Name())
+	}
+	if info.Title == "" {
+		info.Title = w.Name()
+	}
+	if info.Version == "" {
+		info.Version = w.Version()
+	}
+
+	components := map[string]any{
+		"Request":  schemas.Request,
+		"Response": schemas.Response,
+		"ToolList": schemas.ToolList,
+		"Error":    schemas.Error,
+	}
+	names := make([]any, 0, len(tools))
+	inputs := make([]any, 0, len(tools))
+	for _, t := range tools {
+		key := openRPCName(t.Name)
+		if _, dup := components[key+".input"]; dup {
+			return nil, fmt.Errorf("%w: tool %q: duplicate component name %q", ErrInvalidSchema, t.Name, key)
+		}
+		base := "#/components/schemas/" + escapePointer(key)
+		input := openObject()
+		if t.InputSchema != nil {
+			input = rebaseRefs(t.InputSchema, base+".input").(map[string]any)
+		}
+		input = maps.Clone(input)
+		if _, ok := input["title"]; !ok {
+			input["title"] = t.Name
+		}
+		if _, ok := input["description"]; !ok && t.Description != "" {
+			input["description"] = t.Description
+		}
+		components[key+".input"] = input
+		if t.OutputSchema != nil {
+			components[key+".output"] = rebaseRefs(t.OutputSchema, base+".output")
+		}
+		names = append(names, t.Name)
+		inputs = append(inputs, map[string]any{"$ref": "#/components/schemas/" + key + ".input"})
+	}
+
+	doc := &OpenRPCDocument{
+		OpenRPC:    OpenRPCVersion,
+		Info:       info,
+		Methods:    make([]OpenRPCMethod, 0, len(schemas.Methods)),
+		Components: OpenRPCComponents{Schemas: components},
+	}
+	for _, m := range schemas.Methods {
+		method := OpenRPCMethod{
+			Name:           m.Name,
+			Summary:        m.Summary,
+			ParamStructure: "by-name",
+			Params:         openRPCParams(m.Params),
+			Result:         &OpenRPCContentDescriptor{Name: "result", Schema: m.Result},
+		}
+		if len(tools) > 0 {
+			for i, p := range method.Params {
+				switch p.Name {
+				case m.ToolName:
+					method.Params[i].Schema = map[string]any{"type": "string", "enum": names}
+				case m.ToolArguments:
+					method.Params[i].Schema = map[string]any{"anyOf": slices.Clone(inputs)}
+				}
+			}
+		}
+		doc.Methods = append(doc.Methods, method)
+	}
+	return doc, nil
+}
+
+// rebaseRefs returns a copy of schema whose local references point into
+// base instead of the document root. Subschemas with their own $id are
+// kept, as their references resolve against them.
+func rebaseRefs(schema any, base string) any {
+	switch s := schema.(type) {
+	case map[string]any:
+		if _, ok := s["$id"]; ok {
+			return s
+		}
+		out := make(map[string]any, len(s))
+		for k, v := range s {
+			ref, isRef := v.(string)
+			switch {
+			case k == "$ref" && isRef && strings.HasPrefix(ref, "#"):
+				out[k] = base + ref[1:]
+			case k == "enum" || k == "const":
+				out[k] = v
+			default:
+				out[k] = rebaseRefs(v, base)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(s))
+		for i, v := range s {
+			out[i] = rebaseRefs(v, base)
+		}
+		return out
+	}
+	return schema
+}
+
+// openRPCParams splits a params object schema into content descriptors,
+// required params first.
+func openRPCParams(params map[string]any) []OpenRPCContentDescriptor {
+	props, _ := params["properties"].(map[string]any)
+	required, _ := stringList(params["required"])
+	out := make([]OpenRPCContentDescriptor, 0, len(props))
+	for _, name := range slices.Sorted(maps.Keys(props)) {
+		schema, _ := props[name].(map[string]any)
+		out = append(out, OpenRPCContentDescriptor{
+			Name:     name,
+			Required: slices.Contains(required, name),
+			Schema:   schema,
+		})
+	}
+	slices.SortStableFunc(out, func(a, b OpenRPCContentDescriptor) int {
+		switch {
+		case a.Required == b.Required:
+			return 0
+		case a.Required:
+			return -1
+		}
+		return 1
+	})
+	return out
+}
+
+// openRPCName maps a tool name to the component name characters
+// OpenRPC allows, [a-zA-Z0-9._-].
+func openRPCName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '.', r == '_', r == '-':
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,187 @@
+package wire
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestOpenRPC(t *testing.T) {
+	tools := []Tool{
+		{
+			Name:         "search",
+			Description:  "Search the web",
+			InputSchema:  map[string]any{"type": "object", "properties": map[string]any{"q": map[string]any{"type": "string"}}},
+			OutputSchema: map[string]any{"type": "object"},
+		},
+		{Name: "files/read"},
+	}
+	doc, err := OpenRPC(NewMCP(), tools, OpenRPCInfo{Title: "Example"})
+	if err != nil {
+		t.Fatalf("OpenRPC() error = %v", err)
+	}
+	if doc.OpenRPC != OpenRPCVersion || doc.Info.Title != "Example" || doc.Info.Version != MCPVersion {
+		t.Errorf("OpenRPC() header = %s %+v", doc.OpenRPC, doc.Info)
+	}
+
+	var names []string
+	for _, m := range doc.Methods {
+		names = append(names, m.Name)
+	}
+	if want := []string{"tools/call", "tools/list"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("methods = %v, want %v", names, want)
+	}
+
+	call := doc.Methods[0]
+	if call.ParamStructure != "by-name" || call.Result == nil || call.Result.Schema == nil {
+		t.Errorf("tools/call = %+v, want by-name params and a result", call)
+	}
+	var params []string
+	for _, p := range call.Params {
+		params = append(params, p.Name)
+	}
+	if want := []string{"arguments", "name", "_meta"}; !reflect.DeepEqual(params, want) {
+		t.Errorf("tools/call params = %v, want required params first: %v", params, want)
+	}
+	if !call.Params[0].Required || call.Params[2].Required {
+		t.Errorf("tools/call params required = %v %v %v", call.Params[0].Required, call.Params[1].Required, call.Params[2].Required)
+	}
+	wantArgs := map[string]any{"anyOf": []any{
+		map[string]any{"$ref": "#/components/schemas/search.input"},
+		map[string]any{"$ref": "#/components/schemas/files_read.input"},
+	}}
+	if !reflect.DeepEqual(call.Params[0].Schema, wantArgs) {
+		t.Errorf("arguments schema = %v, want %v", call.Params[0].Schema, wantArgs)
+	}
+	if enum := call.Params[1].Schema["enum"]; !reflect.DeepEqual(enum, []any{"search", "files/read"}) {
+		t.Errorf("name enum = %v", enum)
+	}
+
+	schemas := doc.Components.Schemas
+	for _, key := range []string{"Request", "Response", "ToolList", "Error", "search.input", "search.output", "files_read.input"} {
+		if _, ok := schemas[key]; !ok {
+			t.Errorf("components.schemas has no %q", key)
+		}
+	}
+	input := schemas["search.input"].(map[string]any)
+	if input["title"] != "search" || input["description"] != "Search the web" {
+		t.Errorf("search.input = %v, want title and description", input)
+	}
+	if _, ok := tools[0].InputSchema["title"]; ok {
+		t.Error("OpenRPC modified the tool's input schema")
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded OpenRPCDocument
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Methods[0].Name != "tools/call" {
+		t.Errorf("document does not round-trip: %v", err)
+	}
+}
+
+func TestOpenRPC_Protocols(t *testing.T) {
+	tools := []Tool{{Name: "summarizer"}}
+	tests := []struct {
+		w       Wire
+		methods []string
+		tool    string
+	}{
+		{NewA2A(), []string{A2AMethodMessageSend, A2AMethodTasksGet, A2AMethodTasksCancel}, ""},
+		{NewACP(), []string{"agents/run"}, "agentId"},
+		{NewCanonical(NewMCP()), []string{"tools/call", "tools/list"}, "name"},
+	}
+	for _, tt := range tests {
+		doc, err := OpenRPC(tt.w, tools, OpenRPCInfo{})
+		if err != nil {
+			t.Fatalf("OpenRPC(%s) error = %v", tt.w.Name(), err)
+		}
+		if doc.Info.Title != tt.w.Name() || doc.Info.Version != tt.w.Version() {
+			t.Errorf("OpenRPC(%s) info = %+v, want wire defaults", tt.w.Name(), doc.Info)
+		}
+		var names []string
+		for _, m := range doc.Methods {
+			names = append(names, m.Name)
+			for _, p := range m.Params {
+				if _, err := CompileSchema(p.Schema); err != nil && p.Name != "arguments" && p.Name != "input" {
+					t.Errorf("%s %s param %s: %v", tt.w.Name(), m.Name, p.Name, err)
+				}
+				if p.Name == tt.tool && p.Schema["enum"] == nil {
+					t.Errorf("%s %s param %s = %v, want tool enum", tt.w.Name(), m.Name, p.Name, p.Schema)
+				}
+			}
+		}
+		if !reflect.DeepEqual(names, tt.methods) {
+			t.Errorf("OpenRPC(%s) methods = %v, want %v", tt.w.Name(), names, tt.methods)
+		}
+	}
+}
+
+func TestOpenRPC_RecursiveSchemas(t *testing.T) {
+	node, err := SchemaFor[schemaNode]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	tree, err := SchemaFor[schemaTree]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	tools := []Tool{{Name: "node", InputSchema: node, OutputSchema: tree}}
+	doc, err := OpenRPC(NewMCP(), tools, OpenRPCInfo{})
+	if err != nil {
+		t.Fatalf("OpenRPC() error = %v", err)
+	}
+
+	input := doc.Components.Schemas["node.input"].(map[string]any)
+	next := input["properties"].(map[string]any)["next"]
+	if want := map[string]any{"$ref": "#/components/schemas/node.input"}; !reflect.DeepEqual(next, want) {
+		t.Errorf("node.input next = %v, want %v", next, want)
+	}
+	output := doc.Components.Schemas["node.output"].(map[string]any)
+	root := output["properties"].(map[string]any)["root"]
+	if want := map[string]any{"$ref": "#/components/schemas/node.output/$defs/schemaNode"}; !reflect.DeepEqual(root, want) {
+		t.Errorf("node.output root = %v, want %v", root, want)
+	}
+	if next := node["properties"].(map[string]any)["next"]; !reflect.DeepEqual(next, map[string]any{"$ref": "#"}) {
+		t.Errorf("OpenRPC modified the tool's input schema: next = %v", next)
+	}
+
+	// The references resolve within the document.
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var full map[string]any
+	if err := json.Unmarshal(data, &full); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"node.input", "node.output"} {
+		full["properties"] = map[string]any{"x": map[string]any{"$ref": "#/components/schemas/" + key}}
+		s, err := CompileSchema(full)
+		if err != nil {
+			t.Fatalf("CompileSchema(%s) error = %v", key, err)
+		}
+		bad := map[string]any{"value": "a", "next": map[string]any{"value": 1}}
+		if key == "node.output" {
+			bad = map[string]any{"root": bad}
+		}
+		if err := s.Validate(map[string]any{"x": bad}); err == nil {
+			t.Errorf("%s accepted a nested value of the wrong type", key)
+		}
+	}
+}
+
+func TestOpenRPC_Errors(t *testing.T) {
+	if _, err := OpenRPC(NewBinary(EncodingCBOR), nil, OpenRPCInfo{}); !errors.Is(err, ErrUnsupportedFeature) {
+		t.Errorf("OpenRPC(binary) error = %v, want ErrUnsupportedFeature", err)
+	}
+	if _, err := OpenRPC(&mockWire{}, nil, OpenRPCInfo{}); !errors.Is(err, ErrUnsupportedFeature) {
+		t.Errorf("OpenRPC(mockWire) error = %v, want ErrUnsupportedFeature", err)
+	}
+	dup := []Tool{{Name: "a/b"}, {Name: "a_b"}}
+	if _, err := OpenRPC(NewMCP(), dup, OpenRPCInfo{}); !errors.Is(err, ErrInvalidSchema) {
+		t.Errorf("OpenRPC(colliding names) error = %v, want ErrInvalidSchema", err)
+	}
+}
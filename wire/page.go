@@ -0,0 +1,62 @@
+package wire
+
+import (
+	"context"
+
+	"github.com/jonwraymond/toolprotocol/prompt"
+	"github.com/jonwraymond/toolprotocol/resource"
+	"github.com/jonwraymond/toolprotocol/task"
+)
+
+// PageCodec is implemented by wire formats that carry paginated lists of
+// tools, resources, prompts and tasks. The plain Wire tool list methods
+// encode a single page without a cursor.
+//
+// Contract:
+//   - Concurrency: Implementations must be safe for concurrent use.
+//   - Compatibility: Decode methods accept lists without a cursor.
+//   - Errors: Lists the protocol does not have return
+//     ErrUnsupportedFeature.
+type PageCodec interface {
+	// EncodeToolPage encodes one page of a tool list with its next cursor.
+	EncodeToolPage(ctx context.Context, page *Page[Tool]) ([]byte, error)
+
+	// DecodeToolPage decodes one page of a tool list and its next cursor.
+	DecodeToolPage(ctx context.Context, data []byte) (*Page[Tool], error)
+
+	// EncodeResourcePage encodes one page of a resource list.
+	EncodeResourcePage(ctx context.Context, page *Page[resource.Resource]) ([]byte, error)
+
+	// DecodeResourcePage decodes one page of a resource list.
+	DecodeResourcePage(ctx context.Context, data []byte) (*Page[resource.Resource], error)
+
+	// EncodePromptPage encodes one page of a prompt list.
+	EncodePromptPage(ctx context.Context, page *Page[prompt.Prompt]) ([]byte, error)
+
+	// DecodePromptPage decodes one page of a prompt list.
+	DecodePromptPage(ctx context.Context, data []byte) (*Page[prompt.Prompt], error)
+
+	// EncodeTaskPage encodes one page of a task list.
+	EncodeTaskPage(ctx context.Context, page *Page[*task.Task]) ([]byte, error)
+
+	// DecodeTaskPage decodes one page of a task list.
+	DecodeTaskPage(ctx context.Context, data []byte) (*Page[*task.Task], error)
+}
+
+var (
+	_ PageCodec = (*MCPWire)(nil)
+	_ PageCodec = (*A2AWire)(nil)
+	_ PageCodec = (*ACPWire)(nil)
+)
+
+// ListRequest is a request for one page of a list operation.
+type ListRequest struct {
+	// ID is the request identifier.
+	ID string
+
+	// Method is the list method, such as "tools/list".
+	Method string
+
+	// Cursor is the NextCursor of the previous page; empty for the first.
+	Cursor string
+}
@@ -0,0 +1,279 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/prompt"
+	"github.com/jonwraymond/toolprotocol/resource"
+	"github.com/jonwraymond/toolprotocol/task"
+)
+
+func TestPageCodec_ToolPage(t *testing.T) {
+	ctx := context.Background()
+	page := &Page[Tool]{
+		Items:      []Tool{{Name: "search", Description: "Search"}},
+		NextCursor: "abc",
+	}
+	codecs := map[string]PageCodec{"mcp": NewMCP(), "a2a": NewA2A(), "acp": NewACP()}
+	for name, c := range codecs {
+		t.Run(name, func(t *testing.T) {
+			data, err := c.EncodeToolPage(ctx, page)
+			if err != nil {
+				t.Fatalf("EncodeToolPage error: %v", err)
+			}
+			if !strings.Contains(string(data), `"nextCursor":"abc"`) {
+				t.Errorf("EncodeToolPage = %s, want nextCursor", data)
+			}
+			got, err := c.DecodeToolPage(ctx, data)
+			if err != nil {
+				t.Fatalf("DecodeToolPage error: %v", err)
+			}
+			if !reflect.DeepEqual(got, page) {
+				t.Errorf("DecodeToolPage = %+v, want %+v", got, page)
+			}
+
+			// The last page and plain tool lists carry no cursor.
+			data, _ = c.(Wire).EncodeToolList(ctx, page.Items)
+			if strings.Contains(string(data), "nextCursor") {
+				t.Errorf("EncodeToolList = %s, want no cursor", data)
+			}
+		})
+	}
+}
+
+func TestMCPWire_ListRequest(t *testing.T) {
+	ctx := context.Background()
+	w := NewMCP()
+
+	first, err := w.EncodeListRequest(ctx, &ListRequest{ID: "1", Method: MCPMethodToolsList})
+	if err != nil {
+		t.Fatalf("EncodeListRequest error: %v", err)
+	}
+	if want := `{"jsonrpc":"2.0","id":"1","method":"tools/list"}`; string(first) != want {
+		t.Errorf("EncodeListRequest = %s, want %s", first, want)
+	}
+	next, _ := w.EncodeListRequest(ctx, &ListRequest{ID: "2", Method: MCPMethodResourcesList, Cursor: "c1"})
+	got, err := w.DecodeListRequest(ctx, next)
+	if err != nil {
+		t.Fatalf("DecodeListRequest error: %v", err)
+	}
+	if *got != (ListRequest{ID: "2", Method: MCPMethodResourcesList, Cursor: "c1"}) {
+		t.Errorf("DecodeListRequest = %+v", got)
+	}
+
+	if _, err := w.EncodeListRequest(ctx, &ListRequest{ID: "3", Method: "tools/call"}); err == nil {
+		t.Error("EncodeListRequest accepted tools/call")
+	}
+	if _, err := w.DecodeListRequest(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call"}`)); err == nil {
+		t.Error("DecodeListRequest accepted tools/call")
+	}
+	old, _ := NewMCPVersion(MCPVersion20250618)
+	if _, err := old.EncodeListRequest(ctx, &ListRequest{ID: "4", Method: MCPMethodTasksList}); err == nil {
+		t.Error("EncodeListRequest accepted tasks/list before 2025-11-25")
+	}
+}
+
+func TestMCPWire_ResourcePage(t *testing.T) {
+	ctx := context.Background()
+	w := NewMCP()
+	page := &Page[resource.Resource]{
+		Items: []resource.Resource{{
+			URI:         "file:///readme.md",
+			Name:        "readme",
+			MIMEType:    "text/markdown",
+			Annotations: map[string]any{"priority": 0.5},
+		}},
+		NextCursor: "n",
+	}
+	data, err := w.EncodeResourcePage(ctx, page)
+	if err != nil {
+		t.Fatalf("EncodeResourcePage error: %v", err)
+	}
+	want := `{"resources":[{"uri":"file:///readme.md","name":"readme","mimeType":"text/markdown","annotations":{"priority":0.5}}],"nextCursor":"n"}`
+	if string(data) != want {
+		t.Errorf("EncodeResourcePage = %s, want %s", data, want)
+	}
+	got, err := w.DecodeResourcePage(ctx, data)
+	if err != nil || !reflect.DeepEqual(got, page) {
+		t.Errorf("DecodeResourcePage = %+v, %v", got, err)
+	}
+}
+
+func TestMCPWire_PromptPage(t *testing.T) {
+	ctx := context.Background()
+	w := NewMCP()
+	page := &Page[prompt.Prompt]{Items: []prompt.Prompt{{
+		Name:      "review",
+		Arguments: []prompt.Argument{{Name: "code", Required: true}, {Name: "style"}},
+	}}}
+	data, err := w.EncodePromptPage(ctx, page)
+	if err != nil {
+		t.Fatalf("EncodePromptPage error: %v", err)
+	}
+	want := `{"prompts":[{"name":"review","arguments":[{"name":"code","required":true},{"name":"style"}]}]}`
+	if string(data) != want {
+		t.Errorf("EncodePromptPage = %s, want %s", data, want)
+	}
+	got, err := w.DecodePromptPage(ctx, data)
+	if err != nil || !reflect.DeepEqual(got, page) {
+		t.Errorf("DecodePromptPage = %+v, %v", got, err)
+	}
+}
+
+func TestMCPWire_TaskPage(t *testing.T) {
+	ctx := context.Background()
+	w := NewMCP()
+	created := time.Date(2025, 11, 25, 10, 0, 0, 0, time.UTC)
+	page := &Page[*task.Task]{Items: []*task.Task{
+		{ID: "t1", State: task.StateRunning, Message: "indexing", CreatedAt: created, UpdatedAt: created.Add(time.Minute)},
+		{ID: "t2", State: task.StateCancelled, CreatedAt: created, UpdatedAt: created},
+	}}
+	data, err := w.EncodeTaskPage(ctx, page)
+	if err != nil {
+		t.Fatalf("EncodeTaskPage error: %v", err)
+	}
+	want := `{"tasks":[` +
+		`{"taskId":"t1","status":"working","statusMessage":"indexing","createdAt":"2025-11-25T10:00:00Z","lastUpdatedAt":"2025-11-25T10:01:00Z","ttl":null},` +
+		`{"taskId":"t2","status":"cancelled","createdAt":"2025-11-25T10:00:00Z","lastUpdatedAt":"2025-11-25T10:00:00Z","ttl":null}]}`
+	if string(data) != want {
+		t.Errorf("EncodeTaskPage = %s, want %s", data, want)
+	}
+	got, err := w.DecodeTaskPage(ctx, data)
+	if err != nil || !reflect.DeepEqual(got, page) {
+		t.Errorf("DecodeTaskPage = %+v, %v", got, err)
+	}
+
+	old, _ := NewMCPVersion(MCPVersion20250618)
+	if _, err := old.EncodeTaskPage(ctx, page); err == nil {
+		t.Error("EncodeTaskPage succeeded before 2025-11-25")
+	}
+}
+
+func TestA2AWire_TaskPage(t *testing.T) {
+	ctx := context.Background()
+	w := NewA2A()
+	updated := time.Date(2025, 11, 25, 10, 1, 0, 0, time.UTC)
+	page := &Page[*task.Task]{Items: []*task.Task{
+		{ID: "t1", State: task.StateRunning, Message: "indexing", UpdatedAt: updated},
+		{ID: "t2", State: task.StatePending},
+	}, NextCursor: "n"}
+	data, err := w.EncodeTaskPage(ctx, page)
+	if err != nil {
+		t.Fatalf("EncodeTaskPage error: %v", err)
+	}
+	want := `{"tasks":[` +
+		`{"kind":"task","id":"t1","contextId":"","status":{"state":"working","message":{"kind":"message","messageId":"t1-status","role":"agent","parts":[{"kind":"text","text":"indexing"}],"taskId":"t1"},"timestamp":"2025-11-25T10:01:00Z"}},` +
+		`{"kind":"task","id":"t2","contextId":"","status":{"state":"submitted"}}],"nextCursor":"n"}`
+	if string(data) != want {
+		t.Errorf("EncodeTaskPage = %s, want %s", data, want)
+	}
+	got, err := w.DecodeTaskPage(ctx, data)
+	if err != nil || !reflect.DeepEqual(got, page) {
+		t.Errorf("DecodeTaskPage = %+v, %v", got, err)
+	}
+
+	got, err = w.DecodeTaskPage(ctx, []byte(`{"tasks":[{"kind":"task","id":"t3","contextId":"c","status":{"state":"input-required"}}]}`))
+	if err != nil || len(got.Items) != 1 || got.Items[0].State != task.StateRunning {
+		t.Errorf("DecodeTaskPage(input-required) = %+v, %v, want running", got, err)
+	}
+}
+
+func TestBinaryWire_ListPages(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2025, 11, 25, 10, 0, 0, 0, time.UTC)
+	completed := created.Add(time.Hour)
+	resources := &Page[resource.Resource]{Items: []resource.Resource{{
+		URI:         "file:///readme.md",
+		Name:        "readme",
+		Description: "Project readme",
+		MIMEType:    "text/markdown",
+		Annotations: map[string]any{"priority": 0.5},
+	}}, NextCursor: "r"}
+	prompts := &Page[prompt.Prompt]{Items: []prompt.Prompt{{
+		Name:      "review",
+		Arguments: []prompt.Argument{{Name: "code", Description: "Code to review", Required: true}, {Name: "style"}},
+	}}, NextCursor: "p"}
+	tasks := &Page[*task.Task]{Items: []*task.Task{
+		{ID: "t1", State: task.StatePending, CreatedAt: created, UpdatedAt: created},
+		{ID: "t2", State: task.StateComplete, Progress: 1, Message: "done", CreatedAt: created, UpdatedAt: completed, CompletedAt: &completed},
+	}, NextCursor: "t"}
+
+	for _, enc := range []Encoding{EncodingCBOR, EncodingMsgPack} {
+		w := NewBinary(enc)
+		t.Run(w.Name(), func(t *testing.T) {
+			data, err := w.EncodeResourcePage(ctx, resources)
+			if err != nil {
+				t.Fatalf("EncodeResourcePage error: %v", err)
+			}
+			if got, err := w.DecodeResourcePage(ctx, data); err != nil || !reflect.DeepEqual(got, resources) {
+				t.Errorf("DecodeResourcePage = %+v, %v", got, err)
+			}
+
+			data, err = w.EncodePromptPage(ctx, prompts)
+			if err != nil {
+				t.Fatalf("EncodePromptPage error: %v", err)
+			}
+			if got, err := w.DecodePromptPage(ctx, data); err != nil || !reflect.DeepEqual(got, prompts) {
+				t.Errorf("DecodePromptPage = %+v, %v", got, err)
+			}
+
+			data, err = w.EncodeTaskPage(ctx, tasks)
+			if err != nil {
+				t.Fatalf("EncodeTaskPage error: %v", err)
+			}
+			if got, err := w.DecodeTaskPage(ctx, data); err != nil || !reflect.DeepEqual(got, tasks) {
+				t.Errorf("DecodeTaskPage = %+v, %v", got, err)
+			}
+
+			bad, _ := w.enc.Marshal(map[string]any{"tasks": []any{map[string]any{"id": "t", "createdAt": "yesterday"}}})
+			if _, err := w.DecodeTaskPage(ctx, bad); !errors.Is(err, ErrDecodeFailure) {
+				t.Errorf("DecodeTaskPage(bad time) error = %v, want ErrDecodeFailure", err)
+			}
+		})
+	}
+}
+
+func TestPageCodec_Unsupported(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		codec     PageCodec
+		resources bool
+		prompts   bool
+		tasks     bool
+	}{
+		{NewA2A(), false, false, true},
+		{NewACP(), false, false, false},
+	}
+	for _, tt := range tests {
+		name := tt.codec.(Wire).Name()
+		_, err := tt.codec.EncodeResourcePage(ctx, &Page[resource.Resource]{})
+		if got := !errors.Is(err, ErrUnsupportedFeature); got != tt.resources {
+			t.Errorf("%s: EncodeResourcePage error = %v", name, err)
+		}
+		_, err = tt.codec.DecodeResourcePage(ctx, []byte(`{"resources":[]}`))
+		if got := !errors.Is(err, ErrUnsupportedFeature); got != tt.resources {
+			t.Errorf("%s: DecodeResourcePage error = %v", name, err)
+		}
+		_, err = tt.codec.EncodePromptPage(ctx, &Page[prompt.Prompt]{})
+		if got := !errors.Is(err, ErrUnsupportedFeature); got != tt.prompts {
+			t.Errorf("%s: EncodePromptPage error = %v", name, err)
+		}
+		_, err = tt.codec.DecodePromptPage(ctx, []byte(`{"prompts":[]}`))
+		if got := !errors.Is(err, ErrUnsupportedFeature); got != tt.prompts {
+			t.Errorf("%s: DecodePromptPage error = %v", name, err)
+		}
+		_, err = tt.codec.EncodeTaskPage(ctx, &Page[*task.Task]{})
+		if got := !errors.Is(err, ErrUnsupportedFeature); got != tt.tasks {
+			t.Errorf("%s: EncodeTaskPage error = %v", name, err)
+		}
+		_, err = tt.codec.DecodeTaskPage(ctx, []byte(`{"tasks":[]}`))
+		if got := !errors.Is(err, ErrUnsupportedFeature); got != tt.tasks {
+			t.Errorf("%s: DecodeTaskPage error = %v", name, err)
+		}
+	}
+}
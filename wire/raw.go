@@ -0,0 +1,186 @@
+package wire
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// RawRequest is a decoded request whose arguments and metadata are kept
+// as raw JSON. Servers that know their argument types decode them
+// straight into structs with DecodeRawArguments, skipping the
+// map[string]any that Request.Arguments requires.
+type RawRequest struct {
+	// ID is the request identifier. Numeric IDs are in decimal form.
+	ID string
+
+	// Method is the RPC method.
+	Method string
+
+	// ToolID is the tool, skill or agent to invoke.
+	ToolID string
+
+	// Arguments is the JSON object of tool arguments, or nil.
+	Arguments json.RawMessage
+
+	// Meta is the JSON object of request metadata, or nil.
+	Meta json.RawMessage
+}
+
+// RawRequestDecoder is implemented by wire formats that can decode a
+// request without materializing its arguments.
+//
+// Contract:
+//   - Concurrency: Implementations must be safe for concurrent use.
+//   - Equivalence: DecodeRawRequest followed by RawRequest.Request yields
+//     the same Request as DecodeRequest.
+//   - Ownership: The raw fields do not alias data.
+type RawRequestDecoder interface {
+	// DecodeRawRequest decodes a request, keeping arguments as raw JSON.
+	DecodeRawRequest(ctx context.Context, data []byte) (*RawRequest, error)
+}
+
+var (
+	_ RawRequestDecoder = (*MCPWire)(nil)
+	_ RawRequestDecoder = (*A2AWire)(nil)
+	_ RawRequestDecoder = (*ACPWire)(nil)
+)
+
+// Request materializes the raw request. Arguments and metadata that are
+// not JSON objects are dropped, as DecodeRequest does.
+func (r *RawRequest) Request() (*Request, error) {
+	req := &Request{ID: r.ID, Method: r.Method, ToolID: r.ToolID}
+	var err error
+	if req.Arguments, err = rawObject(r.Arguments); err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+	if req.Meta, err = rawObject(r.Meta); err != nil {
+		return nil, fmt.Errorf("decode request: %w", err)
+	}
+	return req, nil
+}
+
+// DecodeRawArguments decodes raw request arguments into a value of type
+// T using encoding/json semantics. Missing arguments decode as an empty
+// object. Failures wrap ErrInvalidArguments, so FromError reports them
+// as invalid params.
+func DecodeRawArguments[T any](r *RawRequest) (T, error) {
+	var v T
+	args := r.Arguments
+	if isJSONNull(args) {
+		args = json.RawMessage("{}")
+	}
+	if err := json.Unmarshal(args, &v); err != nil {
+		return v, fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	return v, nil
+}
+
+// decodeRequest decodes a request through d's raw form.
+func decodeRequest(ctx context.Context, d RawRequestDecoder, data []byte) (*Request, error) {
+	raw, err := d.DecodeRawRequest(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return raw.Request()
+}
+
+// rawID converts a raw JSON-RPC ID to a string as rpcID does, without
+// decoding it into an interface value first.
+func rawID(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	switch c := raw[0]; {
+	case c == '"':
+		if bytes.IndexByte(raw, '\\') < 0 {
+			return string(raw[1 : len(raw)-1])
+		}
+		var s string
+		_ = json.Unmarshal(raw, &s)
+		return s
+	case c == '-' || c >= '0' && c <= '9':
+		f, err := strconv.ParseFloat(string(raw), 64)
+		if err != nil {
+			return ""
+		}
+		return strconv.FormatFloat(f, 'f', 0, 64)
+	}
+	return ""
+}
+
+// rawObject decodes raw if it is a JSON object and returns nil otherwise.
+func rawObject(raw json.RawMessage) (map[string]any, error) {
+	if !isJSONObject(raw) {
+		return nil, nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// objectOrNil returns raw if it is a JSON object and nil otherwise, so
+// raw fields carry the same values the map decoders would keep.
+func objectOrNil(raw json.RawMessage) json.RawMessage {
+	if !isJSONObject(raw) {
+		return nil
+	}
+	return raw
+}
+
+func isJSONObject(raw json.RawMessage) bool {
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	return len(raw) > 0 && raw[0] == '{'
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+// looseString decodes a JSON string and ignores other values, matching
+// the type assertions of map-based decoding.
+type looseString string
+
+func (s *looseString) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '"' {
+		return nil
+	}
+	if bytes.IndexByte(data, '\\') < 0 {
+		*s = looseString(data[1 : len(data)-1])
+		return nil
+	}
+	return json.Unmarshal(data, (*string)(s))
+}
+
+// looseBytes decodes base64 binary data as produced by encoding/json for
+// []byte values. Malformed or missing data yields nil, as decodeData does.
+type looseBytes []byte
+
+func (b *looseBytes) UnmarshalJSON(data []byte) error {
+	var s looseString
+	_ = s.UnmarshalJSON(data)
+	if s == "" {
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(string(s))
+	if err == nil {
+		*b = decoded
+	}
+	return nil
+}
+
+// looseObject decodes a JSON object and ignores other values.
+type looseObject map[string]any
+
+func (o *looseObject) UnmarshalJSON(data []byte) error {
+	if !isJSONObject(data) {
+		return nil
+	}
+	return json.Unmarshal(data, (*map[string]any)(o))
+}
@@ -0,0 +1,225 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type searchArgs struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit"`
+}
+
+func TestDecodeRawRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		wire RawRequestDecoder
+		data string
+		want RawRequest
+	}{
+		{
+			name: "mcp tools/call",
+			wire: NewMCP(),
+			data: `{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"name":"search","arguments":{"query":"go","limit":5},"_meta":{"progressToken":"p"}}}`,
+			want: RawRequest{ID: "1", Method: "tools/call", ToolID: "search",
+				Arguments: json.RawMessage(`{"query":"go","limit":5}`), Meta: json.RawMessage(`{"progressToken":"p"}`)},
+		},
+		{
+			name: "mcp non-object arguments",
+			wire: NewMCP(),
+			data: `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":7,"arguments":[1]}}`,
+			want: RawRequest{ID: "2", Method: "tools/call"},
+		},
+		{
+			name: "a2a message",
+			wire: NewA2A(),
+			data: `{"jsonrpc":"2.0","id":"3","method":"message/send","params":{"message":{"kind":"message","messageId":"m","role":"user","contextId":"c1","metadata":{"skillId":"search"},"parts":[{"kind":"text","text":"hi"},{"kind":"data","data":{"query":"go"}}]},"metadata":{"trace":"t"}}}`,
+			want: RawRequest{ID: "3", Method: "message/send", ToolID: "search",
+				Arguments: json.RawMessage(`{"query":"go"}`), Meta: json.RawMessage(`{"contextId":"c1","trace":"t"}`)},
+		},
+		{
+			name: "a2a text only",
+			wire: NewA2A(),
+			data: `{"jsonrpc":"2.0","id":"4","method":"message/send","params":{"message":{"kind":"message","messageId":"m","role":"user","parts":[{"kind":"text","text":"a"},{"kind":"text","text":"b"}]}}}`,
+			want: RawRequest{ID: "4", Method: "message/send", Arguments: json.RawMessage(`{"text":"a\nb"}`)},
+		},
+		{
+			name: "a2a task params",
+			wire: NewA2A(),
+			data: `{"jsonrpc":"2.0","id":"5","method":"tasks/get","params":{"id":"t1","historyLength":2,"metadata":{"k":"v"}}}`,
+			want: RawRequest{ID: "5", Method: "tasks/get",
+				Arguments: json.RawMessage(`{"historyLength":2,"id":"t1"}`), Meta: json.RawMessage(`{"k":"v"}`)},
+		},
+		{
+			name: "a2a task params with message argument",
+			wire: NewA2A(),
+			data: `{"jsonrpc":"2.0","id":"9","method":"tasks/cancel","params":{"id":"t1","message":{"reason":"user aborted"}}}`,
+			want: RawRequest{ID: "9", Method: "tasks/cancel",
+				Arguments: json.RawMessage(`{"id":"t1","message":{"reason":"user aborted"}}`)},
+		},
+		{
+			name: "a2a string message",
+			wire: NewA2A(),
+			data: `{"jsonrpc":"2.0","id":"10","method":"message/send","params":{"message":"hi","skillId":"s"}}`,
+			want: RawRequest{ID: "10", Method: "message/send", ToolID: "s",
+				Arguments: json.RawMessage(`{"message":"hi"}`)},
+		},
+		{
+			name: "acp session method",
+			wire: NewACP(),
+			data: `{"jsonrpc":"2.0","id":6,"method":"session/prompt","params":{"sessionId":"s","_meta":{"k":1}}}`,
+			want: RawRequest{ID: "6", Method: "session/prompt",
+				Arguments: json.RawMessage(`{"sessionId":"s"}`), Meta: json.RawMessage(`{"k":1}`)},
+		},
+		{
+			name: "acp session method without meta",
+			wire: NewACP(),
+			data: `{"jsonrpc":"2.0","id":7,"method":"session/cancel","params":{"sessionId":"s"}}`,
+			want: RawRequest{ID: "7", Method: "session/cancel", Arguments: json.RawMessage(`{"sessionId":"s"}`)},
+		},
+		{
+			name: "acp agent invocation",
+			wire: NewACP(),
+			data: `{"jsonrpc":"2.0","id":"8","method":"agent/invoke","params":{"agentId":"a","input":{"query":"go"},"metadata":{"k":"v"}}}`,
+			want: RawRequest{ID: "8", Method: "agent/invoke", ToolID: "a",
+				Arguments: json.RawMessage(`{"query":"go"}`), Meta: json.RawMessage(`{"k":"v"}`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(tt.data)
+			got, err := tt.wire.DecodeRawRequest(context.Background(), data)
+			if err != nil {
+				t.Fatalf("DecodeRawRequest error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("DecodeRawRequest = %+v\nwant %+v", got, tt.want)
+			}
+
+			// Raw fields must not alias the input.
+			before := *got
+			before.Arguments = append(json.RawMessage(nil), got.Arguments...)
+			for i := range data {
+				data[i] = ' '
+			}
+			if !reflect.DeepEqual(got.Arguments, before.Arguments) {
+				t.Error("Arguments alias the input buffer")
+			}
+		})
+	}
+}
+
+func TestA2AWire_RawRequestRoundTrip_TaskMessageArgument(t *testing.T) {
+	ctx := context.Background()
+	w := NewA2A()
+	req := &Request{ID: "1", Method: "tasks/cancel", Arguments: map[string]any{"id": "t1", "message": "user aborted"}}
+	data, err := w.EncodeRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("EncodeRequest error: %v", err)
+	}
+	raw, err := w.DecodeRawRequest(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeRawRequest error: %v", err)
+	}
+	got, err := raw.Request()
+	if err != nil {
+		t.Fatalf("Request error: %v", err)
+	}
+	if !reflect.DeepEqual(got, req) {
+		t.Errorf("round trip = %+v, want %+v", got, req)
+	}
+	eager, err := w.DecodeRequest(ctx, data)
+	if err != nil {
+		t.Fatalf("DecodeRequest error: %v", err)
+	}
+	if !reflect.DeepEqual(eager, req) {
+		t.Errorf("DecodeRequest = %+v, want %+v", eager, req)
+	}
+}
+
+func TestRawRequest_Request(t *testing.T) {
+	raw := &RawRequest{
+		ID:        "1",
+		Method:    "tools/call",
+		ToolID:    "search",
+		Arguments: json.RawMessage(`{"query":"go","limit":5}`),
+		Meta:      json.RawMessage(`[1]`),
+	}
+	req, err := raw.Request()
+	if err != nil {
+		t.Fatalf("Request error: %v", err)
+	}
+	want := &Request{ID: "1", Method: "tools/call", ToolID: "search",
+		Arguments: map[string]any{"query": "go", "limit": 5.0}}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("Request = %+v, want %+v", req, want)
+	}
+
+	if _, err := (&RawRequest{Arguments: json.RawMessage(`{bad`)}).Request(); err == nil {
+		t.Error("Request accepted malformed arguments")
+	}
+}
+
+func TestDecodeRawArguments(t *testing.T) {
+	raw, err := NewMCP().DecodeRawRequest(context.Background(),
+		[]byte(`{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"name":"search","arguments":{"query":"go","limit":5}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := DecodeRawArguments[searchArgs](raw)
+	if err != nil || args != (searchArgs{Query: "go", Limit: 5}) {
+		t.Errorf("DecodeRawArguments = %+v, %v", args, err)
+	}
+
+	empty, err := DecodeRawArguments[searchArgs](&RawRequest{})
+	if err != nil || empty != (searchArgs{}) {
+		t.Errorf("DecodeRawArguments(no arguments) = %+v, %v", empty, err)
+	}
+
+	bad := &RawRequest{Arguments: json.RawMessage(`{"limit":"five"}`)}
+	if _, err := DecodeRawArguments[searchArgs](bad); !errors.Is(err, ErrInvalidArguments) {
+		t.Errorf("DecodeRawArguments type mismatch error = %v, want ErrInvalidArguments", err)
+	}
+}
+
+func TestRawID(t *testing.T) {
+	tests := map[string]string{
+		``:         "",
+		`null`:     "",
+		`"abc"`:    "abc",
+		`"a\"b"`:   `a"b`,
+		`"\u0041"`: "A",
+		`42`:       "42",
+		`-7`:       "-7",
+		`1e2`:      "100",
+		`true`:     "",
+		`{"x":1}`:  "",
+	}
+	for in, want := range tests {
+		if got := rawID(json.RawMessage(in)); got != want {
+			t.Errorf("rawID(%s) = %q, want %q", in, got, want)
+		}
+		if in != "" && in != "true" && in != `{"x":1}` {
+			var v any
+			_ = json.Unmarshal([]byte(in), &v)
+			if got := rpcID(v); got != want {
+				t.Errorf("rpcID(%s) = %q, rawID = %q", in, got, want)
+			}
+		}
+	}
+}
+
+func TestMCPWire_DecodeResponse_Lenient(t *testing.T) {
+	data := []byte(`{"jsonrpc":"2.0","id":1,"result":{"content":[{"type":"image","data":"not base64!","mimeType":5}],"structuredContent":[1],"_meta":"x"}}`)
+	resp, err := NewMCP().DecodeResponse(context.Background(), data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error: %v", err)
+	}
+	want := &Response{ID: "1", Content: []Content{{Type: ContentTypeImage}}}
+	if !reflect.DeepEqual(resp, want) {
+		t.Errorf("DecodeResponse = %+v, want %+v", resp, want)
+	}
+}
@@ -0,0 +1,243 @@
+package wire
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RedactedValue replaces values selected by a Redactor.
+const RedactedValue = "[REDACTED]"
+
+// Redactor replaces the values selected by JSON paths in requests and
+// responses, typically secrets in arguments before a message is logged.
+//
+// Paths address a message in the layout BinaryWire uses: requests are
+// {"id", "method", "toolId", "arguments", "meta"} and responses are
+// {"id", "content", "structuredContent", "isError", "error", "meta"},
+// with content items {"type", "text", "mimeType", "data", "uri",
+// "altText", "name", "size", "duration", "annotations"} and the error
+// {"code", "message", "data"}. A path is "$" followed by segments:
+//
+//   - .name or ['name']: an object member; use the bracket form for
+//     names with dots or brackets ("$.meta['example.com/tenant']")
+//   - [n]: an array element
+//   - .* or [*]: every member or element
+//   - ..name or ..*: the member at any depth ("$..password")
+//
+// Selected values become RedactedValue, or its bytes for binary content
+// data. Only maps and []any are descended into; arguments built from
+// other Go types are left as they are.
+//
+// Contract:
+//   - Concurrency: Immutable and safe for concurrent use.
+//   - Ownership: Messages are never modified. Redacted messages are
+//     copies that share unchanged maps with the original; when nothing
+//     matches, the original is returned.
+type Redactor struct {
+	paths [][]pathStep
+}
+
+// NewRedactor compiles paths into a Redactor. Malformed paths return
+// ErrInvalidPath.
+func NewRedactor(paths ...string) (*Redactor, error) {
+	r := &Redactor{paths: make([][]pathStep, 0, len(paths))}
+	for _, p := range paths {
+		steps, err := parsePath(p)
+		if err != nil {
+			return nil, err
+		}
+		r.paths = append(r.paths, steps)
+	}
+	return r, nil
+}
+
+// RedactRequest returns req with the selected values replaced. Paths
+// that replace a field of the wrong type, such as "$.arguments" with a
+// string, are reported as ErrDecodeFailure.
+func (r *Redactor) RedactRequest(req *Request) (*Request, error) {
+	m, ok := r.apply(requestMap(req))
+	if !ok {
+		return req, nil
+	}
+	out, err := requestFromMap(m)
+	if err != nil {
+		return nil, fmt.Errorf("redact request: %w", err)
+	}
+	return out, nil
+}
+
+// RedactResponse returns resp with the selected values replaced, as
+// RedactRequest does.
+func (r *Redactor) RedactResponse(resp *Response) (*Response, error) {
+	m, ok := r.apply(responseMap(resp))
+	if !ok {
+		return resp, nil
+	}
+	out, err := responseFromMap(m)
+	if err != nil {
+		return nil, fmt.Errorf("redact response: %w", err)
+	}
+	return out, nil
+}
+
+// apply redacts every path in m and reports whether anything matched.
+func (r *Redactor) apply(m map[string]any) (map[string]any, bool) {
+	var v any = m
+	changed := false
+	for _, steps := range r.paths {
+		var ok bool
+		if v, ok = redactPath(v, steps); ok {
+			changed = true
+		}
+	}
+	return v.(map[string]any), changed
+}
+
+// pathStep is one segment of a compiled path.
+type pathStep struct {
+	key      string
+	index    int // -1 unless the step is an array index
+	wildcard bool
+	descend  bool
+}
+
+func (s pathStep) matches(key string, index int) bool {
+	switch {
+	case s.wildcard:
+		return true
+	case s.index >= 0:
+		return index == s.index
+	}
+	return index < 0 && key == s.key
+}
+
+// parsePath compiles a path of the form described on Redactor.
+func parsePath(path string) ([]pathStep, error) {
+	invalid := func(why string) error {
+		return fmt.Errorf("%w: %q: %s", ErrInvalidPath, path, why)
+	}
+	if !strings.HasPrefix(path, "$") {
+		return nil, invalid("must start with $")
+	}
+	var steps []pathStep
+	rest := path[1:]
+	for rest != "" {
+		step := pathStep{index: -1}
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			step.descend = true
+			rest = rest[2:]
+		case rest[0] == '.':
+			rest = rest[1:]
+		case rest[0] != '[':
+			return nil, invalid("expected . or [")
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, invalid("unclosed [")
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				step.wildcard = true
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				step.key = inner[1 : len(inner)-1]
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, invalid(fmt.Sprintf("bad index %q", inner))
+				}
+				step.index = n
+			}
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			if name == "" {
+				return nil, invalid("empty name")
+			}
+			if name == "*" {
+				step.wildcard = true
+			} else {
+				step.key = name
+			}
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 {
+		return nil, invalid("selects the whole message")
+	}
+	return steps, nil
+}
+
+// redactPath returns v with the values steps select replaced, copying
+// the maps and slices on the way to them.
+func redactPath(v any, steps []pathStep) (any, bool) {
+	if len(steps) == 0 {
+		if _, ok := v.([]byte); ok {
+			return []byte(RedactedValue), true
+		}
+		return RedactedValue, true
+	}
+	s, rest := steps[0], steps[1:]
+	out, changed := mapChildren(v, func(key string, index int, child any) (any, bool) {
+		if !s.matches(key, index) {
+			return child, false
+		}
+		return redactPath(child, rest)
+	})
+	if s.descend {
+		// The step may also match below any child.
+		var deeper bool
+		out, deeper = mapChildren(out, func(_ string, _ int, child any) (any, bool) {
+			return redactPath(child, steps)
+		})
+		changed = changed || deeper
+	}
+	return out, changed
+}
+
+// mapChildren applies fn to the members of a map or the elements of a
+// slice, copying the container on the first change. Map members have
+// index -1.
+func mapChildren(v any, fn func(key string, index int, child any) (any, bool)) (any, bool) {
+	switch x := v.(type) {
+	case map[string]any:
+		var out map[string]any
+		for k, c := range x {
+			if nc, ok := fn(k, -1, c); ok {
+				if out == nil {
+					out = maps.Clone(x)
+				}
+				out[k] = nc
+			}
+		}
+		if out != nil {
+			return out, true
+		}
+	case []any:
+		var out []any
+		for i, c := range x {
+			if nc, ok := fn("", i, c); ok {
+				if out == nil {
+					out = slices.Clone(x)
+				}
+				out[i] = nc
+			}
+		}
+		if out != nil {
+			return out, true
+		}
+	}
+	return v, false
+}
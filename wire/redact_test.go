@@ -0,0 +1,188 @@
+package wire
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewRedactor_InvalidPaths(t *testing.T) {
+	for _, p := range []string{
+		"",
+		"$",
+		"arguments.password",
+		"$arguments",
+		"$.",
+		"$.a..",
+		"$[",
+		"$[x]",
+		"$[-1]",
+	} {
+		if _, err := NewRedactor(p); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("NewRedactor(%q) error = %v, want ErrInvalidPath", p, err)
+		}
+	}
+}
+
+func TestRedactor_RedactRequest(t *testing.T) {
+	req := &Request{
+		ID:     "1",
+		Method: "tools/call",
+		ToolID: "login",
+		Arguments: map[string]any{
+			"user":     "ada",
+			"password": "hunter2",
+			"headers":  map[string]any{"Authorization": "Bearer x", "Accept": "*/*"},
+			"keys":     []any{"k1", "k2"},
+			"nested":   []any{map[string]any{"password": "p"}, "plain"},
+		},
+		Meta: map[string]any{"example.com/token": "t", "trace": "abc"},
+	}
+	tests := []struct {
+		name  string
+		paths []string
+		want  map[string]any
+		meta  map[string]any
+	}{
+		{
+			name:  "member",
+			paths: []string{"$.arguments.password"},
+			want: map[string]any{
+				"user":     "ada",
+				"password": RedactedValue,
+				"headers":  map[string]any{"Authorization": "Bearer x", "Accept": "*/*"},
+				"keys":     []any{"k1", "k2"},
+				"nested":   []any{map[string]any{"password": "p"}, "plain"},
+			},
+		},
+		{
+			name:  "bracket and index",
+			paths: []string{"$['arguments'].headers[\"Authorization\"]", "$.arguments.keys[1]"},
+			want: map[string]any{
+				"user":     "ada",
+				"password": "hunter2",
+				"headers":  map[string]any{"Authorization": RedactedValue, "Accept": "*/*"},
+				"keys":     []any{"k1", RedactedValue},
+				"nested":   []any{map[string]any{"password": "p"}, "plain"},
+			},
+		},
+		{
+			name:  "wildcards",
+			paths: []string{"$.arguments.headers.*", "$.arguments.keys[*]"},
+			want: map[string]any{
+				"user":     "ada",
+				"password": "hunter2",
+				"headers":  map[string]any{"Authorization": RedactedValue, "Accept": RedactedValue},
+				"keys":     []any{RedactedValue, RedactedValue},
+				"nested":   []any{map[string]any{"password": "p"}, "plain"},
+			},
+		},
+		{
+			name:  "recursive descent",
+			paths: []string{"$..password"},
+			want: map[string]any{
+				"user":     "ada",
+				"password": RedactedValue,
+				"headers":  map[string]any{"Authorization": "Bearer x", "Accept": "*/*"},
+				"keys":     []any{"k1", "k2"},
+				"nested":   []any{map[string]any{"password": RedactedValue}, "plain"},
+			},
+		},
+		{
+			name:  "dotted meta key",
+			paths: []string{"$.meta['example.com/token']"},
+			meta:  map[string]any{"example.com/token": RedactedValue, "trace": "abc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRedactor(tt.paths...)
+			if err != nil {
+				t.Fatalf("NewRedactor() error = %v", err)
+			}
+			got, err := r.RedactRequest(req)
+			if err != nil {
+				t.Fatalf("RedactRequest() error = %v", err)
+			}
+			want, meta := tt.want, tt.meta
+			if want == nil {
+				want = req.Arguments
+			}
+			if meta == nil {
+				meta = req.Meta
+			}
+			if !reflect.DeepEqual(got.Arguments, want) {
+				t.Errorf("Arguments = %v, want %v", got.Arguments, want)
+			}
+			if !reflect.DeepEqual(got.Meta, meta) {
+				t.Errorf("Meta = %v, want %v", got.Meta, meta)
+			}
+			if got.ID != "1" || got.Method != "tools/call" || got.ToolID != "login" {
+				t.Errorf("RedactRequest() = %+v, want header fields kept", got)
+			}
+		})
+	}
+	if req.Arguments["password"] != "hunter2" || req.Meta["example.com/token"] != "t" {
+		t.Error("RedactRequest modified the original request")
+	}
+	if nested := req.Arguments["nested"].([]any)[0].(map[string]any); nested["password"] != "p" {
+		t.Error("RedactRequest modified a nested map of the original request")
+	}
+}
+
+func TestRedactor_NoMatch(t *testing.T) {
+	r, err := NewRedactor("$.arguments.missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &Request{ID: "1", Arguments: map[string]any{"a": 1}}
+	if got, err := r.RedactRequest(req); err != nil || got != req {
+		t.Errorf("RedactRequest() = %p, %v, want the original request", got, err)
+	}
+}
+
+func TestRedactor_RedactResponse(t *testing.T) {
+	r, err := NewRedactor("$.content[*].text", "$.content[1].data", "$.error.data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := &Response{
+		ID: "1",
+		Content: []Content{
+			{Type: ContentTypeText, Text: "secret"},
+			{Type: ContentTypeImage, MIMEType: "image/png", Data: []byte{0x89, 'P'}},
+		},
+		IsError: true,
+		Error:   &Error{Code: -32000, Message: "failed", Data: map[string]any{"token": "x"}},
+	}
+	got, err := r.RedactResponse(resp)
+	if err != nil {
+		t.Fatalf("RedactResponse() error = %v", err)
+	}
+	want := &Response{
+		ID: "1",
+		Content: []Content{
+			{Type: ContentTypeText, Text: RedactedValue},
+			{Type: ContentTypeImage, MIMEType: "image/png", Data: []byte(RedactedValue)},
+		},
+		IsError: true,
+		Error:   &Error{Code: -32000, Message: "failed", Data: RedactedValue},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RedactResponse() = %+v, want %+v", got, want)
+	}
+	if resp.Content[0].Text != "secret" {
+		t.Error("RedactResponse modified the original response")
+	}
+}
+
+func TestRedactor_WrongType(t *testing.T) {
+	r, err := NewRedactor("$.arguments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.RedactRequest(&Request{Arguments: map[string]any{"a": 1}})
+	if !errors.Is(err, ErrDecodeFailure) {
+		t.Errorf("RedactRequest() error = %v, want ErrDecodeFailure", err)
+	}
+}
@@ -0,0 +1,462 @@
+package wire
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaFor derives a JSON Schema for T by reflection.
+//
+// Struct fields follow encoding/json: the json tag names the property,
+// "-" and unexported fields are skipped, and embedded structs without a
+// name are flattened, with shallower and then tagged fields winning
+// conflicting names. A jsonschema tag adds constraints as a
+// comma-separated list of key=value pairs and flags; a literal comma in
+// a value is written as "\,":
+//
+//	type SearchArgs struct {
+//	    Query string   `json:"query" jsonschema:"required,description=Search terms,minLength=1"`
+//	    Limit int      `json:"limit,omitempty" jsonschema:"minimum=1,maximum=100"`
+//	    Sort  string   `json:"sort,omitempty" jsonschema:"enum=relevance,enum=date"`
+//	}
+//
+// Supported keys are required, description, title, format, pattern,
+// enum (repeatable), minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, minLength, maxLength, minItems and maxItems.
+//
+// Slices and arrays become arrays, maps become objects constrained by
+// additionalProperties, []byte becomes a base64 string, time.Time becomes
+// a date-time string and pointers take their element's schema. Types
+// with a custom MarshalJSON accept any value, and TextMarshalers are
+// strings. Recursive types are emitted once under $defs and referenced.
+//
+// Channels, functions, complex numbers and map keys encoding/json cannot
+// encode return an error wrapping ErrInvalidSchema, as do malformed tags.
+func SchemaFor[T any]() (map[string]any, error) {
+	return SchemaOf(reflect.TypeFor[T]())
+}
+
+// SchemaOf derives a JSON Schema for the Go type t. See SchemaFor.
+func SchemaOf(t reflect.Type) (map[string]any, error) {
+	g := &schemaGen{
+		active:    make(map[reflect.Type]bool),
+		recursive: make(map[reflect.Type]bool),
+		defs:      make(map[string]any),
+		names:     make(map[reflect.Type]string),
+		named:     make(map[string]bool),
+		root:      derefType(t),
+	}
+	schema, err := g.schema(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+	return schema, nil
+}
+
+// NewTool returns a Tool whose input schema is derived from In and
+// whose output schema is derived from Out.
+func NewTool[In, Out any](name, description string) (Tool, error) {
+	in, err := SchemaFor[In]()
+	if err != nil {
+		return Tool{}, fmt.Errorf("tool %q input: %w", name, err)
+	}
+	out, err := SchemaFor[Out]()
+	if err != nil {
+		return Tool{}, fmt.Errorf("tool %q output: %w", name, err)
+	}
+	return Tool{
+		Name:         name,
+		Description:  description,
+		InputSchema:  in,
+		OutputSchema: out,
+	}, nil
+}
+
+// DecodeArguments decodes request arguments into a value of type T
+// using encoding/json semantics. Failures wrap ErrInvalidArguments, so
+// FromError reports them as invalid params.
+func DecodeArguments[T any](args map[string]any) (T, error) {
+	var v T
+	if args == nil {
+		args = map[string]any{}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return v, fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	return v, nil
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schemaGen carries state across one SchemaOf call.
+type schemaGen struct {
+	active    map[reflect.Type]bool // structs being generated
+	recursive map[reflect.Type]bool // structs that reference themselves
+	defs      map[string]any
+	names     map[reflect.Type]string // $defs names handed out
+	named     map[string]bool
+	root      reflect.Type
+}
+
+func (g *schemaGen) schema(t reflect.Type) (map[string]any, error) {
+	t = derefType(t)
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case t == rawMessageType:
+		return map[string]any{}, nil
+	case implements(t, jsonMarshalerType):
+		// Custom JSON encodings cannot be described by reflection.
+		return map[string]any{}, nil
+	case implements(t, textMarshalerType):
+		return map[string]any{"type": "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer", "minimum": 0.0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		s := map[string]any{"type": "array", "items": items}
+		if t.Kind() == reflect.Array {
+			s["minItems"] = float64(t.Len())
+			s["maxItems"] = float64(t.Len())
+		}
+		return s, nil
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			if !implements(t.Key(), textMarshalerType) {
+				return nil, fmt.Errorf("%w: map key type %s", ErrInvalidSchema, t.Key())
+			}
+		}
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %s", ErrInvalidSchema, t)
+	}
+}
+
+// structRef returns a struct's schema, or a $ref to it when the struct
+// is recursive.
+func (g *schemaGen) structRef(t reflect.Type) (map[string]any, error) {
+	if g.active[t] {
+		g.recursive[t] = true
+		return map[string]any{"$ref": g.ref(t)}, nil
+	}
+	g.active[t] = true
+	s, err := g.structSchema(t)
+	delete(g.active, t)
+	if err != nil || !g.recursive[t] || t == g.root {
+		return s, err
+	}
+	g.defs[g.defName(t)] = s
+	return map[string]any{"$ref": g.ref(t)}, nil
+}
+
+func (g *schemaGen) ref(t reflect.Type) string {
+	if t == g.root {
+		return "#"
+	}
+	return "#/$defs/" + escapePointer(g.defName(t))
+}
+
+func (g *schemaGen) structSchema(t reflect.Type) (map[string]any, error) {
+	props := make(map[string]any)
+	var required []any
+	for _, f := range visibleFields(t) {
+		var s map[string]any
+		if f.asString {
+			s = map[string]any{"type": "string"}
+		} else {
+			var err error
+			if s, err = g.schema(f.field.Type); err != nil {
+				return nil, fmt.Errorf("field %s.%s: %w", f.owner.Name(), f.field.Name, err)
+			}
+		}
+		isRequired, err := applySchemaTag(s, f.field.Tag.Get("jsonschema"))
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", f.owner.Name(), f.field.Name, err)
+		}
+		props[f.name] = s
+		if isRequired {
+			required = append(required, f.name)
+		}
+	}
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s, nil
+}
+
+// schemaField is a struct field encoding/json encodes as a property.
+type schemaField struct {
+	name     string
+	field    reflect.StructField
+	owner    reflect.Type
+	index    []int
+	tagged   bool
+	asString bool
+}
+
+// visibleFields returns the fields of t that encoding/json encodes, in
+// its order, flattening embedded structs breadth first. As in
+// encoding/json, a shallower field hides deeper ones of the same name, a
+// tagged field wins among fields at the same depth, and names still
+// ambiguous are dropped. A struct already flattened at a shallower
+// depth, such as one embedding a pointer to itself, is not expanded
+// again.
+func visibleFields(t reflect.Type) []schemaField {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	var found []schemaField
+	visited := map[reflect.Type]bool{}
+	for next := []embedded{{typ: t}}; len(next) > 0; {
+		level := next
+		next = nil
+		for _, e := range level {
+			visited[e.typ] = true
+		}
+		for _, e := range level {
+			st := e.typ
+			for i := range st.NumField() {
+				index := append(slices.Clone(e.index), i)
+				f := st.Field(i)
+				jsonTag := f.Tag.Get("json")
+				if jsonTag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(jsonTag, ",")
+				if et := derefType(f.Type); f.Anonymous && name == "" && et.Kind() == reflect.Struct {
+					if !visited[et] {
+						next = append(next, embedded{et, index})
+					}
+					continue
+				}
+				if !f.IsExported() {
+					continue
+				}
+				tagged := name != ""
+				if !tagged {
+					name = f.Name
+				}
+				found = append(found, schemaField{
+					name:     name,
+					field:    f,
+					owner:    st,
+					index:    index,
+					tagged:   tagged,
+					asString: hasTagOption(opts, "string"),
+				})
+			}
+		}
+	}
+
+	// Keep the dominant field of each name.
+	byName := make(map[string][]int)
+	for i, f := range found {
+		byName[f.name] = append(byName[f.name], i)
+	}
+	var fields []schemaField
+	for i, f := range found {
+		if dominantField(found, byName[f.name]) == i {
+			fields = append(fields, f)
+		}
+	}
+	slices.SortFunc(fields, func(a, b schemaField) int {
+		return slices.Compare(a.index, b.index)
+	})
+	return fields
+}
+
+// dominantField returns the index of the field that wins among the
+// fields at idx sharing one name, or -1 if none does.
+func dominantField(found []schemaField, idx []int) int {
+	if len(idx) == 1 {
+		return idx[0]
+	}
+	depth := len(found[idx[0]].index)
+	for _, i := range idx {
+		depth = min(depth, len(found[i].index))
+	}
+	winner, candidates, tagged := -1, 0, 0
+	for _, i := range idx {
+		if len(found[i].index) != depth {
+			continue
+		}
+		candidates++
+		if found[i].tagged {
+			tagged++
+			winner = i
+		} else if tagged == 0 {
+			winner = i
+		}
+	}
+	if candidates == 1 || tagged == 1 {
+		return winner
+	}
+	return -1
+}
+
+// applySchemaTag adds jsonschema tag constraints to s and reports
+// whether the field is required.
+func applySchemaTag(s map[string]any, tag string) (bool, error) {
+	if tag == "" {
+		return false, nil
+	}
+	required := false
+	for _, item := range splitTag(tag) {
+		key, value, hasValue := strings.Cut(item, "=")
+		switch key {
+		case "required":
+			required = true
+		case "description", "title", "format", "pattern":
+			if !hasValue {
+				return false, fmt.Errorf("%w: jsonschema %s needs a value", ErrInvalidSchema, key)
+			}
+			s[key] = value
+		case "enum":
+			v, err := tagValue(s, value)
+			if err != nil {
+				return false, err
+			}
+			enum, _ := s["enum"].([]any)
+			s["enum"] = append(enum, v)
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+			"minLength", "maxLength", "minItems", "maxItems":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return false, fmt.Errorf("%w: jsonschema %s=%q is not a number", ErrInvalidSchema, key, value)
+			}
+			s[key] = n
+		default:
+			return false, fmt.Errorf("%w: unknown jsonschema key %q", ErrInvalidSchema, key)
+		}
+	}
+	return required, nil
+}
+
+// tagValue parses an enum value according to the schema's type.
+func tagValue(s map[string]any, value string) (any, error) {
+	switch s["type"] {
+	case "integer", "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: jsonschema enum=%q is not a number", ErrInvalidSchema, value)
+		}
+		return n, nil
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: jsonschema enum=%q is not a boolean", ErrInvalidSchema, value)
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+// splitTag splits a jsonschema tag on commas not escaped as "\,".
+func splitTag(tag string) []string {
+	var items []string
+	var cur strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			cur.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			items = append(items, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(tag[i])
+		}
+	}
+	return append(items, cur.String())
+}
+
+func hasTagOption(opts, name string) bool {
+	for opt := range strings.SplitSeq(opts, ",") {
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// defName names a recursive type in $defs. Types sharing a name with
+// one already defined are qualified by their package path, and numbered
+// if that still collides, as function-local types can.
+func (g *schemaGen) defName(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if name == "" {
+		name = t.String()
+	}
+	if g.named[name] && t.PkgPath() != "" {
+		name = t.PkgPath() + "." + name
+	}
+	for i, base := 2, name; g.named[name]; i++ {
+		name = base + "-" + strconv.Itoa(i)
+	}
+	g.names[t] = name
+	g.named[name] = true
+	return name
+}
@@ -0,0 +1,359 @@
+package wire
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	Street string `json:"street" jsonschema:"required"`
+	City   string `json:"city,omitempty"`
+}
+
+type schemaBase struct {
+	ID string `json:"id" jsonschema:"required,pattern=^[a-z0-9-]+$"`
+}
+
+type schemaArgs struct {
+	schemaBase
+	Query    string          `json:"query" jsonschema:"required,description=Search terms\\, quoted or bare,minLength=1"`
+	Limit    int             `json:"limit,omitempty" jsonschema:"minimum=1,maximum=100"`
+	Sort     string          `json:"sort,omitempty" jsonschema:"enum=relevance,enum=date"`
+	Level    int             `json:"level,omitempty" jsonschema:"enum=1,enum=2"`
+	Ratio    float64         `json:"ratio"`
+	Count    uint8           `json:"count"`
+	Tags     []string        `json:"tags,omitempty" jsonschema:"maxItems=5"`
+	Point    [2]float64      `json:"point"`
+	Labels   map[string]int  `json:"labels,omitempty"`
+	Address  *schemaAddress  `json:"address,omitempty"`
+	Since    time.Time       `json:"since"`
+	Raw      json.RawMessage `json:"raw,omitempty"`
+	Blob     []byte          `json:"blob,omitempty"`
+	Any      any             `json:"any,omitempty"`
+	Quoted   int64           `json:"quoted,string"`
+	Skipped  string          `json:"-"`
+	NoTag    bool
+	hidden   string            //nolint:unused // exercises unexported fields
+	Meta     map[int]string    `json:"meta,omitempty"`
+	Children map[string]string `json:"children,omitempty" jsonschema:"title=Child map"`
+}
+
+type schemaNode struct {
+	Value string        `json:"value"`
+	Next  *schemaNode   `json:"next,omitempty"`
+	Kids  []*schemaNode `json:"kids,omitempty"`
+}
+
+type schemaTree struct {
+	Root schemaNode `json:"root"`
+}
+
+func mustJSON(t *testing.T, src string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(src), &m); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return m
+}
+
+func TestSchemaFor(t *testing.T) {
+	got, err := SchemaFor[schemaArgs]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	want := mustJSON(t, `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "string", "pattern": "^[a-z0-9-]+$"},
+			"query": {"type": "string", "description": "Search terms, quoted or bare", "minLength": 1},
+			"limit": {"type": "integer", "minimum": 1, "maximum": 100},
+			"sort": {"type": "string", "enum": ["relevance", "date"]},
+			"level": {"type": "integer", "enum": [1, 2]},
+			"ratio": {"type": "number"},
+			"count": {"type": "integer", "minimum": 0},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 5},
+			"point": {"type": "array", "items": {"type": "number"}, "minItems": 2, "maxItems": 2},
+			"labels": {"type": "object", "additionalProperties": {"type": "integer"}},
+			"address": {
+				"type": "object",
+				"properties": {"street": {"type": "string"}, "city": {"type": "string"}},
+				"required": ["street"]
+			},
+			"since": {"type": "string", "format": "date-time"},
+			"raw": {},
+			"blob": {"type": "string", "contentEncoding": "base64"},
+			"any": {},
+			"quoted": {"type": "string"},
+			"NoTag": {"type": "boolean"},
+			"meta": {"type": "object", "additionalProperties": {"type": "string"}},
+			"children": {"type": "object", "additionalProperties": {"type": "string"}, "title": "Child map"}
+		},
+		"required": ["id", "query"]
+	}`)
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Errorf("SchemaFor =\n%s", gotJSON)
+	}
+
+	// The generated schema compiles and accepts a marshaled value.
+	s, err := CompileSchema(got)
+	if err != nil {
+		t.Fatalf("CompileSchema error: %v", err)
+	}
+	data, _ := json.Marshal(schemaArgs{schemaBase: schemaBase{ID: "a-1"}, Query: "go", Limit: 5, Sort: "date", Level: 2})
+	var v map[string]any
+	_ = json.Unmarshal(data, &v)
+	if err := s.Validate(v); err != nil {
+		t.Errorf("Validate marshaled value error: %v", err)
+	}
+}
+
+func TestSchemaFor_Recursive(t *testing.T) {
+	got, err := SchemaFor[schemaTree]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	want := mustJSON(t, `{
+		"type": "object",
+		"properties": {"root": {"$ref": "#/$defs/schemaNode"}},
+		"$defs": {
+			"schemaNode": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "string"},
+					"next": {"$ref": "#/$defs/schemaNode"},
+					"kids": {"type": "array", "items": {"$ref": "#/$defs/schemaNode"}}
+				}
+			}
+		}
+	}`)
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Errorf("SchemaFor =\n%s", gotJSON)
+	}
+
+	root, err := SchemaFor[schemaNode]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	if next := root["properties"].(map[string]any)["next"]; !reflect.DeepEqual(next, map[string]any{"$ref": "#"}) {
+		t.Errorf("root self reference = %v, want #", next)
+	}
+	if _, err := CompileSchema(root); err != nil {
+		t.Errorf("CompileSchema error: %v", err)
+	}
+}
+
+// schemaEmbedNode embeds a pointer to itself; encoding/json flattens
+// its fields once.
+type schemaEmbedNode struct {
+	*schemaEmbedNode
+	X int `json:"x"`
+}
+
+type schemaEmbedA struct {
+	*schemaEmbedB
+	A string `json:"a"`
+}
+
+type schemaEmbedB struct {
+	*schemaEmbedA
+	B string `json:"b"`
+}
+
+func TestSchemaFor_EmbeddedCycle(t *testing.T) {
+	got, err := SchemaFor[schemaEmbedNode]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	want := mustJSON(t, `{"type": "object", "properties": {"x": {"type": "integer"}}}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SchemaFor = %v, want %v", got, want)
+	}
+
+	got, err = SchemaFor[schemaEmbedA]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	want = mustJSON(t, `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SchemaFor = %v, want %v", got, want)
+	}
+}
+
+type schemaShadowInner struct {
+	Name  string `json:"name" jsonschema:"required"`
+	Inner bool   `json:"inner"`
+}
+
+type schemaShadowOuter struct {
+	schemaShadowInner
+	Name int `json:"name" jsonschema:"required"`
+}
+
+type schemaShadowX struct {
+	Note string
+	Kind string
+}
+
+type schemaShadowY struct {
+	Note string
+	Kind string `json:"Kind"`
+}
+
+type schemaShadowBoth struct {
+	schemaShadowX
+	schemaShadowY
+}
+
+func TestSchemaFor_EmbeddedShadowing(t *testing.T) {
+	got, err := SchemaFor[schemaShadowOuter]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	want := mustJSON(t, `{"type": "object", "properties": {"name": {"type": "integer"}, "inner": {"type": "boolean"}}, "required": ["name"]}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SchemaFor = %v, want %v", got, want)
+	}
+
+	// At equal depth a tagged field wins; an ambiguous name is dropped,
+	// as encoding/json drops it.
+	got, err = SchemaFor[schemaShadowBoth]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	want = mustJSON(t, `{"type": "object", "properties": {"Kind": {"type": "string"}}}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SchemaFor = %v, want %v", got, want)
+	}
+	data, err := json.Marshal(schemaShadowBoth{
+		schemaShadowX{Note: "x", Kind: "x"},
+		schemaShadowY{Note: "y", Kind: "y"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"Kind":"y"}` {
+		t.Errorf("encoding/json = %s, want {\"Kind\":\"y\"}", data)
+	}
+}
+
+func TestSchemaFor_DefNameCollision(t *testing.T) {
+	// A function-local type shares the name and package of schemaNode.
+	type schemaNode struct {
+		Next *schemaNode `json:"next,omitempty"`
+	}
+	type pair struct {
+		B schemaTree `json:"b"`
+		C schemaNode `json:"c"`
+	}
+	got, err := SchemaFor[pair]()
+	if err != nil {
+		t.Fatalf("SchemaFor error: %v", err)
+	}
+	defs, _ := got["$defs"].(map[string]any)
+	local := reflect.TypeFor[schemaNode]()
+	qualified := local.PkgPath() + ".schemaNode"
+	if len(defs) != 2 || defs["schemaNode"] == nil || defs[qualified] == nil {
+		t.Fatalf("$defs keys = %v, want schemaNode and %s", reflect.ValueOf(defs).MapKeys(), qualified)
+	}
+	props := got["properties"].(map[string]any)
+	if ref := props["c"].(map[string]any)["$ref"]; ref != "#/$defs/"+escapePointer(qualified) {
+		t.Errorf("c $ref = %v", ref)
+	}
+	s, err := CompileSchema(got)
+	if err != nil {
+		t.Fatalf("CompileSchema error: %v", err)
+	}
+	v := map[string]any{"b": map[string]any{"root": map[string]any{"value": "x"}}, "c": map[string]any{"next": map[string]any{}}}
+	if err := s.Validate(v); err != nil {
+		t.Errorf("Validate error: %v", err)
+	}
+	v["b"] = map[string]any{"root": map[string]any{"value": 1}}
+	if err := s.Validate(v); err == nil {
+		t.Error("Validate accepted a number for the package-level schemaNode value")
+	}
+}
+
+func TestSchemaFor_Errors(t *testing.T) {
+	tests := map[string]func() error{
+		"chan": func() error { _, err := SchemaFor[chan int](); return err },
+		"func field": func() error {
+			_, err := SchemaFor[struct {
+				F func() `json:"f"`
+			}]()
+			return err
+		},
+		"map key": func() error { _, err := SchemaFor[map[bool]string](); return err },
+		"unknown key": func() error {
+			_, err := SchemaFor[struct {
+				A string `jsonschema:"color=red"`
+			}]()
+			return err
+		},
+		"bad number": func() error {
+			_, err := SchemaFor[struct {
+				A int `jsonschema:"minimum=one"`
+			}]()
+			return err
+		},
+		"bad enum": func() error {
+			_, err := SchemaFor[struct {
+				A int `jsonschema:"enum=x"`
+			}]()
+			return err
+		},
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := fn(); !errors.Is(err, ErrInvalidSchema) {
+				t.Errorf("error = %v, want ErrInvalidSchema", err)
+			}
+		})
+	}
+}
+
+func TestNewTool(t *testing.T) {
+	type out struct {
+		Total int `json:"total"`
+	}
+	tool, err := NewTool[schemaAddress, out]("geocode", "Resolve an address")
+	if err != nil {
+		t.Fatalf("NewTool error: %v", err)
+	}
+	if tool.Name != "geocode" || tool.Description != "Resolve an address" {
+		t.Errorf("tool = %+v", tool)
+	}
+	if tool.InputSchema["required"] == nil || tool.OutputSchema["properties"] == nil {
+		t.Errorf("schemas = %v / %v", tool.InputSchema, tool.OutputSchema)
+	}
+	if _, err := NewTool[chan int, out]("bad", ""); !errors.Is(err, ErrInvalidSchema) {
+		t.Errorf("NewTool error = %v, want ErrInvalidSchema", err)
+	}
+}
+
+func TestDecodeArguments(t *testing.T) {
+	got, err := DecodeArguments[schemaAddress](map[string]any{"street": "Main St", "city": "Springfield"})
+	if err != nil {
+		t.Fatalf("DecodeArguments error: %v", err)
+	}
+	if got != (schemaAddress{Street: "Main St", City: "Springfield"}) {
+		t.Errorf("DecodeArguments = %+v", got)
+	}
+
+	if _, err := DecodeArguments[schemaAddress](nil); err != nil {
+		t.Errorf("DecodeArguments(nil) error: %v", err)
+	}
+
+	_, err = DecodeArguments[schemaAddress](map[string]any{"street": 42})
+	if !errors.Is(err, ErrInvalidArguments) {
+		t.Fatalf("DecodeArguments error = %v, want ErrInvalidArguments", err)
+	}
+	if code := FromError(err).Code; code != CodeInvalidParams {
+		t.Errorf("FromError code = %d, want %d", code, CodeInvalidParams)
+	}
+}
@@ -0,0 +1,599 @@
+package wire
+
+import (
+	"fmt"
+
+	"github.com/jonwraymond/toolprotocol/content"
+)
+
+// JSONSchemaDialect is the JSON Schema dialect of exported schemas.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// MessageSchemas holds JSON Schemas for the messages a codec emits. Each
+// schema describes the whole encoded message, envelope included, and is
+// exact: objects the codec builds are closed with
+// additionalProperties false, while arguments, metadata and other
+// caller-supplied objects are open.
+//
+// Binary codecs are described in their JSON form, with byte strings as
+// base64 strings, which is what Schema.Validate checks for decoded
+// []byte values.
+type MessageSchemas struct {
+	// Request is a tool invocation request.
+	Request map[string]any
+
+	// Response is a tool invocation response, successful or not.
+	Response map[string]any
+
+	// ToolList is a tool list, or one page of it.
+	ToolList map[string]any
+
+	// Error is a response carrying a protocol error.
+	Error map[string]any
+
+	// Methods describes the JSON-RPC methods behind Request and
+	// ToolList. It is empty for codecs that do not speak JSON-RPC.
+	Methods []MethodSchema
+}
+
+// MethodSchema describes one JSON-RPC method: its params object and the
+// result member of its response.
+type MethodSchema struct {
+	// Name is the JSON-RPC method name.
+	Name string
+
+	// Summary is a short description of the method.
+	Summary string
+
+	// Params is the schema of the params object.
+	Params map[string]any
+
+	// Result is the schema of the result member.
+	Result map[string]any
+
+	// ToolName names the param that selects the tool, if any.
+	ToolName string
+
+	// ToolArguments names the param that carries the tool's arguments,
+	// if any.
+	ToolArguments string
+}
+
+// SchemaExporter is implemented by codecs that describe their messages
+// as JSON Schema.
+//
+// Contract:
+//   - Ownership: Every call returns new maps the caller may modify.
+//   - Accuracy: Every message the codec encodes validates against the
+//     schema for its kind.
+type SchemaExporter interface {
+	// MessageSchemas returns the schemas of the codec's messages.
+	MessageSchemas() *MessageSchemas
+}
+
+// ExportSchemas returns the message schemas of w, looking through
+// wrappers such as CanonicalWire and InterceptedWire. Codecs that do not
+// implement SchemaExporter return ErrUnsupportedFeature.
+func ExportSchemas(w Wire) (*MessageSchemas, error) {
+	for {
+		if e, ok := w.(SchemaExporter); ok {
+			return e.MessageSchemas(), nil
+		}
+		u, ok := w.(interface{ Unwrap() Wire })
+		if !ok {
+			return nil, fmt.Errorf("%w: %s exports no schemas", ErrUnsupportedFeature, w.Name())
+		}
+		w = u.Unwrap()
+	}
+}
+
+// MessageSchemas returns the schemas of MCP messages. Fields the codec's
+// revision omits, such as structuredContent before 2025-06-18, are not
+// part of its schemas.
+func (w *MCPWire) MessageSchemas() *MessageSchemas {
+	features := w.Features()
+	structured := features.StructuredOutput
+
+	callParams := func() map[string]any {
+		return closedObject(map[string]any{
+			"name":      stringSchema(),
+			"arguments": nullable(openObject()),
+			"_meta":     openObject(),
+		}, "name", "arguments")
+	}
+	callResult := func() map[string]any {
+		props := map[string]any{
+			"content": arraySchema(mcpContentSchema(features)),
+			"isError": constSchema(true),
+			"_meta":   openObject(),
+		}
+		if structured {
+			props["structuredContent"] = openObject()
+		}
+		return closedObject(props, "content")
+	}
+	toolList := func() map[string]any {
+		tool := map[string]any{
+			"name":        stringSchema(),
+			"description": stringSchema(),
+			"inputSchema": openObject(),
+		}
+		if structured {
+			tool["outputSchema"] = openObject()
+		}
+		if features.ToolAnnotations {
+			tool["annotations"] = openObject()
+		}
+		return closedObject(map[string]any{
+			"tools":      arraySchema(closedObject(tool, "name")),
+			"nextCursor": stringSchema(),
+		}, "tools")
+	}
+
+	return &MessageSchemas{
+		Request: rootSchema("MCP request", rpcRequestSchema(stringSchema(), callParams(), true)),
+		Response: rootSchema("MCP response", oneOf(
+			rpcResultSchema(callResult()),
+			rpcErrorSchema(),
+		)),
+		ToolList: rootSchema("MCP tool list", toolList()),
+		Error:    rootSchema("MCP error", rpcErrorSchema()),
+		Methods: []MethodSchema{
+			{
+				Name:          "tools/call",
+				Summary:       "Invoke a tool",
+				Params:        callParams(),
+				Result:        callResult(),
+				ToolName:      "name",
+				ToolArguments: "arguments",
+			},
+			{
+				Name:    "tools/list",
+				Summary: "List the available tools",
+				Params:  closedObject(map[string]any{"cursor": stringSchema()}),
+				Result:  toolList(),
+			},
+		},
+	}
+}
+
+// mcpContentSchema is an MCP content block as MCPWire encodes it for a
+// revision with features f.
+func mcpContentSchema(f MCPFeatures) map[string]any {
+	annotations := annotationsSchema()
+	if !f.LastModified {
+		delete(annotations["properties"].(map[string]any), "lastModified")
+	}
+	reserved := []string{"text", "image", "resource"}
+	if !f.AudioContent {
+		reserved = append(reserved, "audio")
+	}
+	if !f.ResourceLinks {
+		reserved = append(reserved, "resource_link")
+	}
+	other := mcpContentBlock("", nil, annotations)
+	other["properties"].(map[string]any)["type"] = map[string]any{
+		"type": "string",
+		"not":  enumSchema(reserved...),
+	}
+	return oneOf(
+		mcpContentBlock("text", []string{"text"}, annotations),
+		mcpContentBlock("image", []string{"data", "mimeType"}, annotations),
+		mcpResourceBlock(annotations),
+		other,
+	)
+}
+
+// mcpResourceBlock is a resource block: a top-level URI, or an embedded
+// resource carrying text or a blob.
+func mcpResourceBlock(annotations map[string]any) map[string]any {
+	block := mcpContentBlock("resource", nil, annotations)
+	block["properties"].(map[string]any)["resource"] = closedObject(map[string]any{
+		"uri":      stringSchema(),
+		"mimeType": stringSchema(),
+		"text":     stringSchema(),
+		"blob":     base64Schema(),
+	}, "uri")
+	block["anyOf"] = schemaList([]map[string]any{
+		{"required": anyList("uri")},
+		{"required": anyList("resource")},
+	})
+	return block
+}
+
+func mcpContentBlock(typ string, required []string, annotations map[string]any) map[string]any {
+	return closedObject(map[string]any{
+		"type":        constSchema(typ),
+		"text":        stringSchema(),
+		"data":        nullable(base64Schema()),
+		"mimeType":    stringSchema(),
+		"uri":         stringSchema(),
+		"annotations": annotations,
+	}, append([]string{"type"}, required...)...)
+}
+
+// annotationsSchema is content annotations as the codecs encode them.
+func annotationsSchema() map[string]any {
+	return closedObject(map[string]any{
+		"audience":     arraySchema(enumSchema(string(content.RoleUser), string(content.RoleAssistant))),
+		"priority":     map[string]any{"type": "number", "minimum": 0, "maximum": 1},
+		"lastModified": map[string]any{"type": "string", "format": "date-time"},
+	})
+}
+
+// MessageSchemas returns the schemas of A2A messages.
+func (w *A2AWire) MessageSchemas() *MessageSchemas {
+	messageParams := func() map[string]any {
+		return closedObject(map[string]any{
+			"id":       stringSchema(),
+			"message":  a2aMessageSchema(),
+			"metadata": openObject(),
+		}, "message")
+	}
+	toolList := closedObject(map[string]any{
+		"skills": arraySchema(closedObject(map[string]any{
+			"id":          stringSchema(),
+			"name":        stringSchema(),
+			"description": stringSchema(),
+			"inputSchema": openObject(),
+		}, "id", "name")),
+		"nextCursor": stringSchema(),
+	}, "skills")
+
+	taskMethod := func() map[string]any {
+		return anyOf(
+			enumSchema(A2AMethodTasksGet, A2AMethodTasksCancel, A2AMethodTasksResubscribe),
+			map[string]any{"type": "string", "pattern": "^tasks/pushNotificationConfig/"},
+		)
+	}
+	messageMethod := map[string]any{"type": "string", "not": taskMethod()}
+
+	return &MessageSchemas{
+		Request: rootSchema("A2A request", oneOf(
+			rpcRequestSchema(messageMethod, messageParams(), true),
+			rpcRequestSchema(taskMethod(), openObject(), false),
+		)),
+		Response: rootSchema("A2A response", oneOf(
+			rpcResultSchema(a2aTaskSchema()),
+			rpcErrorSchema(),
+		)),
+		ToolList: rootSchema("A2A skill list", toolList),
+		Error:    rootSchema("A2A error", rpcErrorSchema()),
+		Methods: []MethodSchema{
+			{
+				Name:    A2AMethodMessageSend,
+				Summary: "Send a message to the agent; tools are skills named by the message's skillId metadata",
+				Params:  messageParams(),
+				Result:  a2aTaskSchema(),
+			},
+			{
+				Name:    A2AMethodTasksGet,
+				Summary: "Get the state of a task",
+				Params:  a2aTaskIDParams(),
+				Result:  a2aTaskSchema(),
+			},
+			{
+				Name:    A2AMethodTasksCancel,
+				Summary: "Cancel a task",
+				Params:  a2aTaskIDParams(),
+				Result:  a2aTaskSchema(),
+			},
+		},
+	}
+}
+
+func a2aTaskIDParams() map[string]any {
+	s := openObject()
+	s["properties"] = map[string]any{
+		"id":            stringSchema(),
+		"historyLength": map[string]any{"type": "integer"},
+		"metadata":      openObject(),
+	}
+	s["required"] = []any{"id"}
+	return s
+}
+
+func a2aMessageSchema() map[string]any {
+	return closedObject(map[string]any{
+		"kind":             constSchema("message"),
+		"messageId":        stringSchema(),
+		"role":             enumSchema(string(A2ARoleUser), string(A2ARoleAgent)),
+		"parts":            arraySchema(a2aPartSchema()),
+		"contextId":        stringSchema(),
+		"taskId":           stringSchema(),
+		"referenceTaskIds": arraySchema(stringSchema()),
+		"extensions":       arraySchema(stringSchema()),
+		"metadata":         openObject(),
+	}, "kind", "messageId", "role", "parts")
+}
+
+func a2aPartSchema() map[string]any {
+	part := func(kind string, props map[string]any) map[string]any {
+		props["kind"] = constSchema(kind)
+		props["metadata"] = openObject()
+		return closedObject(props, "kind")
+	}
+	file := closedObject(map[string]any{
+		"name":     stringSchema(),
+		"mimeType": stringSchema(),
+		"bytes":    base64Schema(),
+		"uri":      stringSchema(),
+	})
+	return oneOf(
+		part(A2APartText, map[string]any{"text": stringSchema()}),
+		part(A2APartFile, map[string]any{"file": file}),
+		part(A2APartData, map[string]any{"data": openObject()}),
+	)
+}
+
+func a2aTaskSchema() map[string]any {
+	return closedObject(map[string]any{
+		"kind":      constSchema("task"),
+		"id":        stringSchema(),
+		"contextId": stringSchema(),
+		"status": closedObject(map[string]any{
+			"state": enumSchema(
+				string(A2ATaskStateSubmitted), string(A2ATaskStateWorking),
+				string(A2ATaskStateInputRequired), string(A2ATaskStateCompleted),
+				string(A2ATaskStateCanceled), string(A2ATaskStateFailed),
+				string(A2ATaskStateRejected), string(A2ATaskStateAuthRequired),
+				string(A2ATaskStateUnknown),
+			),
+			"message":   a2aMessageSchema(),
+			"timestamp": stringSchema(),
+		}, "state"),
+		"history": arraySchema(a2aMessageSchema()),
+		"artifacts": arraySchema(closedObject(map[string]any{
+			"artifactId":  stringSchema(),
+			"name":        stringSchema(),
+			"description": stringSchema(),
+			"parts":       arraySchema(a2aPartSchema()),
+			"extensions":  arraySchema(stringSchema()),
+			"metadata":    openObject(),
+		}, "artifactId", "parts")),
+		"metadata": openObject(),
+	}, "kind", "id", "contextId", "status")
+}
+
+// MessageSchemas returns the schemas of ACP messages. Agent Client
+// Protocol methods carry caller-defined params; other methods use the
+// agentId/input invocation, described by the "agents/run" method.
+func (w *ACPWire) MessageSchemas() *MessageSchemas {
+	runParams := func() map[string]any {
+		return closedObject(map[string]any{
+			"agentId":  stringSchema(),
+			"input":    nullable(openObject()),
+			"metadata": openObject(),
+		}, "agentId", "input")
+	}
+	runResult := func() map[string]any {
+		return closedObject(map[string]any{
+			"status":     constSchema("success"),
+			"stopReason": stringSchema(),
+			"output":     arraySchema(acpBlockSchema()),
+			"metadata":   openObject(),
+		}, "status", "output")
+	}
+	acpMethod := func() map[string]any {
+		return anyOf(
+			constSchema(ACPMethodInitialize),
+			map[string]any{"type": "string", "pattern": "^(session|fs|terminal)/"},
+		)
+	}
+	invokeMethod := map[string]any{"type": "string", "not": acpMethod()}
+
+	return &MessageSchemas{
+		Request: rootSchema("ACP request", oneOf(
+			rpcRequestSchema(acpMethod(), openObject(), false),
+			rpcRequestSchema(invokeMethod, runParams(), true),
+		)),
+		Response: rootSchema("ACP response", oneOf(
+			rpcResultSchema(runResult()),
+			rpcErrorSchema(),
+		)),
+		ToolList: rootSchema("ACP agent list", closedObject(map[string]any{
+			"agents": arraySchema(closedObject(map[string]any{
+				"id":          stringSchema(),
+				"name":        stringSchema(),
+				"description": stringSchema(),
+				"inputSchema": openObject(),
+			}, "id")),
+			"nextCursor": stringSchema(),
+		}, "agents")),
+		Error: rootSchema("ACP error", rpcErrorSchema()),
+		Methods: []MethodSchema{
+			{
+				Name:          "agents/run",
+				Summary:       "Run an agent",
+				Params:        runParams(),
+				Result:        runResult(),
+				ToolName:      "agentId",
+				ToolArguments: "input",
+			},
+		},
+	}
+}
+
+func acpBlockSchema() map[string]any {
+	return closedObject(map[string]any{
+		"type": enumSchema(ACPContentText, ACPContentImage, ACPContentAudio,
+			ACPContentResourceLink, ACPContentResource),
+		"text":        stringSchema(),
+		"data":        base64Schema(),
+		"mimeType":    stringSchema(),
+		"uri":         stringSchema(),
+		"name":        stringSchema(),
+		"title":       stringSchema(),
+		"description": stringSchema(),
+		"size":        map[string]any{"type": "integer"},
+		"resource": closedObject(map[string]any{
+			"uri":      stringSchema(),
+			"mimeType": stringSchema(),
+			"text":     stringSchema(),
+			"blob":     base64Schema(),
+		}, "uri"),
+		"annotations": openObject(),
+	}, "type")
+}
+
+// MessageSchemas returns the schemas of the binary message layout, the
+// one Redactor paths address.
+func (w *BinaryWire) MessageSchemas() *MessageSchemas {
+	errorObject := closedObject(map[string]any{
+		"code":    map[string]any{"type": "integer"},
+		"message": stringSchema(),
+		"data":    true,
+	}, "code", "message")
+	response := func(required ...string) map[string]any {
+		return closedObject(map[string]any{
+			"id": stringSchema(),
+			"content": arraySchema(closedObject(map[string]any{
+				"type":        stringSchema(),
+				"text":        stringSchema(),
+				"mimeType":    stringSchema(),
+				"data":        base64Schema(),
+				"uri":         stringSchema(),
+				"altText":     stringSchema(),
+				"name":        stringSchema(),
+				"size":        map[string]any{"type": "integer", "minimum": 0},
+				"duration":    map[string]any{"type": "integer", "minimum": 0},
+				"annotations": annotationsSchema(),
+			})),
+			"structuredContent": openObject(),
+			"isError":           constSchema(true),
+			"error":             errorObject,
+			"meta":              openObject(),
+		}, required...)
+	}
+	name := "binary (" + w.enc.Name() + ")"
+	return &MessageSchemas{
+		Request: rootSchema(name+" request", closedObject(map[string]any{
+			"id":        stringSchema(),
+			"method":    stringSchema(),
+			"toolId":    stringSchema(),
+			"arguments": openObject(),
+			"meta":      openObject(),
+		})),
+		Response: rootSchema(name+" response", response()),
+		ToolList: rootSchema(name+" tool list", closedObject(map[string]any{
+			"tools": arraySchema(closedObject(map[string]any{
+				"name":         stringSchema(),
+				"description":  stringSchema(),
+				"inputSchema":  openObject(),
+				"outputSchema": openObject(),
+				"annotations":  openObject(),
+			})),
+			"nextCursor": stringSchema(),
+		}, "tools")),
+		Error: rootSchema(name+" error", response("error")),
+	}
+}
+
+// rpcRequestSchema is a JSON-RPC 2.0 request envelope.
+func rpcRequestSchema(method, params map[string]any, paramsRequired bool) map[string]any {
+	required := []string{"jsonrpc", "id", "method"}
+	if paramsRequired {
+		required = append(required, "params")
+	}
+	return closedObject(map[string]any{
+		"jsonrpc": constSchema("2.0"),
+		"id":      stringSchema(),
+		"method":  method,
+		"params":  params,
+	}, required...)
+}
+
+// rpcResultSchema is a successful JSON-RPC 2.0 response envelope.
+func rpcResultSchema(result map[string]any) map[string]any {
+	return closedObject(map[string]any{
+		"jsonrpc": constSchema("2.0"),
+		"id":      stringSchema(),
+		"result":  result,
+	}, "jsonrpc", "id", "result")
+}
+
+// rpcErrorSchema is a JSON-RPC 2.0 error response envelope.
+func rpcErrorSchema() map[string]any {
+	return closedObject(map[string]any{
+		"jsonrpc": constSchema("2.0"),
+		"id":      stringSchema(),
+		"error": closedObject(map[string]any{
+			"code":    map[string]any{"type": "integer"},
+			"message": stringSchema(),
+			"data":    true,
+		}, "code", "message"),
+	}, "jsonrpc", "id", "error")
+}
+
+// rootSchema marks s as a top-level schema of the exported dialect.
+func rootSchema(title string, s map[string]any) map[string]any {
+	s["$schema"] = JSONSchemaDialect
+	s["title"] = title
+	return s
+}
+
+func closedObject(props map[string]any, required ...string) map[string]any {
+	s := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		s["required"] = anyList(required...)
+	}
+	return s
+}
+
+func openObject() map[string]any {
+	return map[string]any{"type": "object"}
+}
+
+func stringSchema() map[string]any {
+	return map[string]any{"type": "string"}
+}
+
+func base64Schema() map[string]any {
+	return map[string]any{"type": "string", "contentEncoding": "base64"}
+}
+
+func arraySchema(items map[string]any) map[string]any {
+	return map[string]any{"type": "array", "items": items}
+}
+
+func constSchema(v any) map[string]any {
+	return map[string]any{"const": v}
+}
+
+func enumSchema(values ...string) map[string]any {
+	return map[string]any{"type": "string", "enum": anyList(values...)}
+}
+
+// nullable widens a typed schema to also accept null.
+func nullable(s map[string]any) map[string]any {
+	s["type"] = []any{s["type"], "null"}
+	return s
+}
+
+func oneOf(schemas ...map[string]any) map[string]any {
+	return map[string]any{"oneOf": schemaList(schemas)}
+}
+
+func anyOf(schemas ...map[string]any) map[string]any {
+	return map[string]any{"anyOf": schemaList(schemas)}
+}
+
+func schemaList(schemas []map[string]any) []any {
+	l := make([]any, len(schemas))
+	for i, s := range schemas {
+		l[i] = s
+	}
+	return l
+}
+
+func anyList(values ...string) []any {
+	l := make([]any, len(values))
+	for i, v := range values {
+		l[i] = v
+	}
+	return l
+}
@@ -0,0 +1,225 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/content"
+)
+
+// schemaWires returns every codec that exports schemas, plus wrappers.
+func schemaWires(t *testing.T) []Wire {
+	t.Helper()
+	out := []Wire{
+		NewA2A(), NewACP(),
+		NewBinary(EncodingCBOR), NewBinary(EncodingMsgPack),
+		NewCanonical(NewMCP()), Intercept(NewA2A()),
+	}
+	for _, v := range MCPSupportedVersions() {
+		w, err := NewMCPVersion(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// compileSchemas compiles every schema in s.
+func compileSchemas(t *testing.T, s *MessageSchemas) map[string]*Schema {
+	t.Helper()
+	out := make(map[string]*Schema)
+	for name, schema := range map[string]map[string]any{
+		"request": s.Request, "response": s.Response, "tool list": s.ToolList, "error": s.Error,
+	} {
+		if schema["$schema"] != JSONSchemaDialect {
+			t.Errorf("%s $schema = %v, want %s", name, schema["$schema"], JSONSchemaDialect)
+		}
+		c, err := CompileSchema(schema)
+		if err != nil {
+			t.Fatalf("CompileSchema(%s) error = %v", name, err)
+		}
+		out[name] = c
+	}
+	for _, m := range s.Methods {
+		for name, schema := range map[string]map[string]any{"params": m.Params, "result": m.Result} {
+			if _, err := CompileSchema(schema); err != nil {
+				t.Fatalf("CompileSchema(%s %s) error = %v", m.Name, name, err)
+			}
+		}
+	}
+	return out
+}
+
+// decodeMessage returns the JSON form of a message encoded by w.
+func decodeMessage(t *testing.T, w Wire, data []byte) any {
+	t.Helper()
+	var v any
+	var err error
+	if b, ok := w.(*BinaryWire); ok {
+		v, err = b.enc.Unmarshal(data)
+	} else {
+		err = json.Unmarshal(data, &v)
+	}
+	if err != nil {
+		t.Fatalf("%s: decode %s: %v", w.Name(), data, err)
+	}
+	return v
+}
+
+func TestExportSchemas(t *testing.T) {
+	ctx := context.Background()
+	requests := []*Request{
+		{ID: "1", Method: "tools/call", ToolID: "search", Arguments: map[string]any{"q": "go"}, Meta: map[string]any{"trace": "t"}},
+		{ID: "2", Method: "tools/call", ToolID: "ping"},
+		{ID: "3", Method: "tasks/get", Arguments: map[string]any{"id": "task-1"}},
+		{ID: "4", Method: "session/prompt", Arguments: map[string]any{"sessionId": "s"}},
+	}
+	responses := []*Response{
+		{ID: "1", Content: []Content{
+			{Type: ContentTypeText, Text: "hi", Annotations: &content.Annotations{
+				Audience:     []content.Role{content.RoleUser},
+				Priority:     content.Priority(0.5),
+				LastModified: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			}},
+			{Type: ContentTypeImage, MIMEType: "image/png", Data: []byte{1, 2}},
+			{Type: ContentTypeResource, URI: "file:///a", MIMEType: "text/plain"},
+			{Type: "audio", MIMEType: "audio/wav", Data: []byte{3}},
+		}, Meta: map[string]any{"stopReason": "end_turn"}},
+		{ID: "2", StructuredContent: map[string]any{"n": 1.0}},
+		{ID: "5", Content: []Content{{Type: "resource_link", URI: "file:///b", MIMEType: "text/plain", Annotations: &content.Annotations{
+			LastModified: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		}}}},
+		{ID: "3", IsError: true, Content: []Content{{Type: ContentTypeText, Text: "tool failed"}}},
+	}
+	protocolError := &Response{ID: "4", IsError: true, Error: &Error{Code: -32602, Message: "bad", Data: map[string]any{"field": "q"}}}
+	tools := []Tool{
+		{Name: "search", Description: "Search", InputSchema: map[string]any{"type": "object"}, OutputSchema: map[string]any{"type": "object"}, Annotations: map[string]any{"readOnlyHint": true}},
+		{Name: "ping"},
+	}
+
+	for _, w := range schemaWires(t) {
+		t.Run(w.Name()+" "+w.Version(), func(t *testing.T) {
+			s, err := ExportSchemas(w)
+			if err != nil {
+				t.Fatalf("ExportSchemas() error = %v", err)
+			}
+			schemas := compileSchemas(t, s)
+			check := func(kind string, data []byte, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("encode %s: %v", kind, err)
+				}
+				if err := schemas[kind].Validate(decodeMessage(t, w, data)); err != nil {
+					t.Errorf("%s %s: %v", kind, data, err)
+				}
+			}
+			for _, req := range requests {
+				data, err := w.EncodeRequest(ctx, req)
+				if errors.Is(err, ErrUnsupportedFeature) {
+					continue
+				}
+				check("request", data, err)
+			}
+			for _, resp := range append(responses, protocolError) {
+				data, err := w.EncodeResponse(ctx, resp)
+				if errors.Is(err, ErrUnsupportedFeature) {
+					continue
+				}
+				check("response", data, err)
+			}
+			data, err := w.EncodeResponse(ctx, protocolError)
+			check("error", data, err)
+			data, err = w.EncodeToolList(ctx, tools)
+			check("tool list", data, err)
+			if pc, ok := w.(PageCodec); ok {
+				data, err = pc.EncodeToolPage(ctx, &Page[Tool]{Items: tools, NextCursor: "c"})
+				check("tool list", data, err)
+			}
+		})
+	}
+}
+
+func TestExportSchemas_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		w    Wire
+		kind string
+		msg  string
+	}{
+		{"mcp version", NewMCP(), "request", `{"jsonrpc":"1.0","id":"1","method":"tools/call","params":{"name":"t","arguments":{}}}`},
+		{"mcp extra member", NewMCP(), "request", `{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"name":"t","arguments":{},"x":1}}`},
+		{"mcp missing name", NewMCP(), "request", `{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"arguments":{}}}`},
+		{"mcp image without data", NewMCP(), "response", `{"jsonrpc":"2.0","id":"1","result":{"content":[{"type":"image","mimeType":"image/png"}]}}`},
+		{"mcp result and error", NewMCP(), "response", `{"jsonrpc":"2.0","id":"1","result":{"content":[]},"error":{"code":1,"message":"m"}}`},
+		{"mcp error code", NewMCP(), "error", `{"jsonrpc":"2.0","id":"1","error":{"code":1.5,"message":"m"}}`},
+		{"a2a task state", NewA2A(), "response", `{"jsonrpc":"2.0","id":"1","result":{"kind":"task","id":"t","contextId":"c","status":{"state":"done"}}}`},
+		{"a2a part kind", NewA2A(), "request", `{"jsonrpc":"2.0","id":"1","method":"message/send","params":{"message":{"kind":"message","messageId":"m","role":"user","parts":[{"kind":"video"}]}}}`},
+		{"acp block type", NewACP(), "response", `{"jsonrpc":"2.0","id":"1","result":{"status":"success","output":[{"type":"video"}]}}`},
+		{"acp skill list", NewACP(), "tool list", `{"skills":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ExportSchemas(tt.w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schemas := compileSchemas(t, s)
+			var v any
+			if err := json.Unmarshal([]byte(tt.msg), &v); err != nil {
+				t.Fatal(err)
+			}
+			if err := schemas[tt.kind].Validate(v); err == nil {
+				t.Errorf("Validate(%s) = nil, want violation", tt.msg)
+			}
+		})
+	}
+}
+
+func TestExportSchemas_MCPRevision(t *testing.T) {
+	old, err := NewMCPVersion(MCPVersion20241105)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		kind string
+		msg  string
+	}{
+		{"response", `{"jsonrpc":"2.0","id":"1","result":{"content":[],"structuredContent":{}}}`},
+		{"tool list", `{"tools":[{"name":"t","outputSchema":{"type":"object"}}]}`},
+	} {
+		var v any
+		if err := json.Unmarshal([]byte(tt.msg), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := compileSchemas(t, NewMCP().MessageSchemas())[tt.kind].Validate(v); err != nil {
+			t.Errorf("mcp %s: Validate(%s) error = %v", MCPVersion, tt.msg, err)
+		}
+		if err := compileSchemas(t, old.MessageSchemas())[tt.kind].Validate(v); err == nil {
+			t.Errorf("mcp %s: Validate(%s) = nil, want violation", MCPVersion20241105, tt.msg)
+		}
+	}
+}
+
+func TestExportSchemas_Ownership(t *testing.T) {
+	w := NewMCP()
+	s := w.MessageSchemas()
+	s.Request["title"] = "changed"
+	delete(s.Response, "oneOf")
+	again := w.MessageSchemas()
+	if again.Request["title"] != "MCP request" || again.Response["oneOf"] == nil {
+		t.Error("MessageSchemas() returned shared maps")
+	}
+}
+
+func TestExportSchemas_Unsupported(t *testing.T) {
+	if _, err := ExportSchemas(&mockWire{}); !errors.Is(err, ErrUnsupportedFeature) {
+		t.Errorf("ExportSchemas(mockWire) error = %v, want ErrUnsupportedFeature", err)
+	}
+	if _, err := ExportSchemas(Intercept(&mockWire{})); !errors.Is(err, ErrUnsupportedFeature) {
+		t.Errorf("ExportSchemas(Intercept(mockWire)) error = %v, want ErrUnsupportedFeature", err)
+	}
+}
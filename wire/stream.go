@@ -0,0 +1,173 @@
+package wire
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxMessageSize is the default limit for a single framed message.
+const DefaultMaxMessageSize = 4 << 20
+
+// StreamOption configures an Encoder or Decoder.
+type StreamOption func(*streamConfig)
+
+type streamConfig struct {
+	maxSize int
+}
+
+// WithMaxMessageSize limits the payload size of a single message.
+// A value of zero or less disables the limit.
+func WithMaxMessageSize(n int) StreamOption {
+	return func(c *streamConfig) {
+		c.maxSize = n
+	}
+}
+
+func newStreamConfig(opts []StreamOption) streamConfig {
+	cfg := streamConfig{maxSize: DefaultMaxMessageSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Encoder writes framed wire messages to an output stream.
+//
+// Contract:
+//   - Concurrency: Not safe for concurrent use; serialize calls externally.
+//   - Buffers: The frame buffer is reused across messages and each frame
+//     is written with a single Write call.
+//   - Limits: Messages larger than the maximum size are rejected with
+//     ErrMessageTooLarge and nothing is written.
+type Encoder struct {
+	w       io.Writer
+	wire    Wire
+	framing Framing
+	maxSize int
+	buf     []byte
+}
+
+// NewEncoder creates an encoder that writes messages encoded by w using
+// the given framing.
+func NewEncoder(out io.Writer, w Wire, framing Framing, opts ...StreamOption) *Encoder {
+	cfg := newStreamConfig(opts)
+	return &Encoder{
+		w:       out,
+		wire:    w,
+		framing: framing,
+		maxSize: cfg.maxSize,
+	}
+}
+
+// WriteMessage frames and writes an already encoded payload.
+func (e *Encoder) WriteMessage(payload []byte) error {
+	if e.maxSize > 0 && len(payload) > e.maxSize {
+		return fmt.Errorf("%w: %d > %d bytes", ErrMessageTooLarge, len(payload), e.maxSize)
+	}
+	e.buf = e.framing.AppendFrame(e.buf[:0], payload)
+	_, err := e.w.Write(e.buf)
+	return err
+}
+
+// EncodeRequest encodes and writes a request.
+func (e *Encoder) EncodeRequest(ctx context.Context, req *Request) error {
+	data, err := e.wire.EncodeRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+	return e.WriteMessage(data)
+}
+
+// EncodeResponse encodes and writes a response.
+func (e *Encoder) EncodeResponse(ctx context.Context, resp *Response) error {
+	data, err := e.wire.EncodeResponse(ctx, resp)
+	if err != nil {
+		return fmt.Errorf("encode response: %w", err)
+	}
+	return e.WriteMessage(data)
+}
+
+// EncodeToolList encodes and writes a tool list.
+func (e *Encoder) EncodeToolList(ctx context.Context, tools []Tool) error {
+	data, err := e.wire.EncodeToolList(ctx, tools)
+	if err != nil {
+		return fmt.Errorf("encode tool list: %w", err)
+	}
+	return e.WriteMessage(data)
+}
+
+// Decoder reads framed wire messages from an input stream.
+//
+// Contract:
+//   - Concurrency: Not safe for concurrent use; serialize calls externally.
+//   - Buffers: ReadMessage returns a slice into an internal buffer that is
+//     reused by the next call; copy it to retain the payload.
+//   - Limits: Messages larger than the maximum size yield
+//     ErrMessageTooLarge; the oversized frame is skipped where the framing
+//     allows it, so decoding can continue.
+//   - EOF: Returns io.EOF at a clean message boundary and
+//     io.ErrUnexpectedEOF for a truncated frame.
+type Decoder struct {
+	r       *bufio.Reader
+	wire    Wire
+	framing Framing
+	maxSize int
+	buf     []byte
+}
+
+// NewDecoder creates a decoder that reads messages framed with the given
+// framing and decodes them with w.
+func NewDecoder(in io.Reader, w Wire, framing Framing, opts ...StreamOption) *Decoder {
+	cfg := newStreamConfig(opts)
+	br, ok := in.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(in)
+	}
+	return &Decoder{
+		r:       br,
+		wire:    w,
+		framing: framing,
+		maxSize: cfg.maxSize,
+	}
+}
+
+// ReadMessage reads the next raw payload.
+func (d *Decoder) ReadMessage() ([]byte, error) {
+	buf, err := d.framing.ReadFrame(d.r, d.buf[:0], d.maxSize)
+	if cap(buf) > cap(d.buf) {
+		d.buf = buf[:0]
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// DecodeRequest reads and decodes the next request.
+func (d *Decoder) DecodeRequest(ctx context.Context) (*Request, error) {
+	data, err := d.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	return d.wire.DecodeRequest(ctx, data)
+}
+
+// DecodeResponse reads and decodes the next response.
+func (d *Decoder) DecodeResponse(ctx context.Context) (*Response, error) {
+	data, err := d.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	return d.wire.DecodeResponse(ctx, data)
+}
+
+// DecodeToolList reads and decodes the next tool list.
+func (d *Decoder) DecodeToolList(ctx context.Context) ([]Tool, error) {
+	data, err := d.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	return d.wire.DecodeToolList(ctx, data)
+}
@@ -0,0 +1,263 @@
+package wire
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFraming_RoundTrip(t *testing.T) {
+	payloads := []string{
+		`{"jsonrpc":"2.0","id":"1","method":"tools/list"}`,
+		`{"a":1}`,
+		`{"text":"line one\nline two"}`,
+	}
+
+	for _, framing := range []Framing{FramingNDJSON, FramingContentLength, FramingSSE} {
+		t.Run(framing.Name(), func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf, NewMCP(), framing)
+			for _, p := range payloads {
+				if err := enc.WriteMessage([]byte(p)); err != nil {
+					t.Fatalf("WriteMessage error = %v", err)
+				}
+			}
+
+			dec := NewDecoder(&buf, NewMCP(), framing)
+			for _, want := range payloads {
+				got, err := dec.ReadMessage()
+				if err != nil {
+					t.Fatalf("ReadMessage error = %v", err)
+				}
+				if string(got) != want {
+					t.Errorf("ReadMessage = %q, want %q", got, want)
+				}
+			}
+			if _, err := dec.ReadMessage(); err != io.EOF {
+				t.Errorf("ReadMessage at end error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+func TestFraming_Wire(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+
+	enc := NewEncoder(&buf, NewMCP(), FramingContentLength)
+	if err := enc.EncodeRequest(ctx, &Request{ID: "1", Method: "tools/call", ToolID: "search"}); err != nil {
+		t.Fatalf("EncodeRequest error = %v", err)
+	}
+	if err := enc.EncodeResponse(ctx, &Response{ID: "1", Content: []Content{{Type: ContentTypeText, Text: "ok"}}}); err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+	if err := enc.EncodeToolList(ctx, []Tool{{Name: "search"}}); err != nil {
+		t.Fatalf("EncodeToolList error = %v", err)
+	}
+
+	dec := NewDecoder(&buf, NewMCP(), FramingContentLength)
+	req, err := dec.DecodeRequest(ctx)
+	if err != nil {
+		t.Fatalf("DecodeRequest error = %v", err)
+	}
+	if req.ToolID != "search" {
+		t.Errorf("ToolID = %q, want %q", req.ToolID, "search")
+	}
+	resp, err := dec.DecodeResponse(ctx)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	if len(resp.Content) != 1 || resp.Content[0].Text != "ok" {
+		t.Errorf("Content = %v, want one text item", resp.Content)
+	}
+	tools, err := dec.DecodeToolList(ctx)
+	if err != nil {
+		t.Fatalf("DecodeToolList error = %v", err)
+	}
+	if len(tools) != 1 {
+		t.Errorf("len(tools) = %d, want 1", len(tools))
+	}
+}
+
+func TestFraming_NDJSON_SkipsBlankLines(t *testing.T) {
+	in := "\n{\"a\":1}\r\n\n   \n{\"b\":2}"
+	dec := NewDecoder(strings.NewReader(in), NewMCP(), FramingNDJSON)
+
+	for _, want := range []string{`{"a":1}`, `{"b":2}`} {
+		got, err := dec.ReadMessage()
+		if err != nil {
+			t.Fatalf("ReadMessage error = %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("ReadMessage = %q, want %q", got, want)
+		}
+	}
+	if _, err := dec.ReadMessage(); err != io.EOF {
+		t.Errorf("ReadMessage error = %v, want io.EOF", err)
+	}
+}
+
+func TestFraming_ContentLength_Headers(t *testing.T) {
+	in := "Content-Type: application/vscode-jsonrpc; charset=utf-8\r\ncontent-length: 7\r\n\r\n{\"a\":1}"
+	dec := NewDecoder(strings.NewReader(in), NewMCP(), FramingContentLength)
+
+	got, err := dec.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage error = %v", err)
+	}
+	if string(got) != `{"a":1}` {
+		t.Errorf("ReadMessage = %q, want %q", got, `{"a":1}`)
+	}
+}
+
+func TestFraming_ContentLength_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{"missing length", "Content-Type: x\r\n\r\n{}", ErrDecodeFailure},
+		{"bad length", "Content-Length: abc\r\n\r\n{}", ErrDecodeFailure},
+		{"malformed header", "garbage\r\n\r\n", ErrDecodeFailure},
+		{"truncated body", "Content-Length: 10\r\n\r\n{}", io.ErrUnexpectedEOF},
+		{"truncated headers", "Content-Length: 2\r\n", io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(tt.in), NewMCP(), FramingContentLength)
+			if _, err := dec.ReadMessage(); !errors.Is(err, tt.want) {
+				t.Errorf("ReadMessage error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFraming_SSE_Fields(t *testing.T) {
+	in := ": heartbeat\n\nevent: message\nid: 1\ndata: {\"a\":\ndata:1}\n\nretry: 100\ndata: {}\n"
+	dec := NewDecoder(strings.NewReader(in), NewMCP(), FramingSSE)
+
+	for _, want := range []string{"{\"a\":\n1}", "{}"} {
+		got, err := dec.ReadMessage()
+		if err != nil {
+			t.Fatalf("ReadMessage error = %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("ReadMessage = %q, want %q", got, want)
+		}
+	}
+	if _, err := dec.ReadMessage(); err != io.EOF {
+		t.Errorf("ReadMessage error = %v, want io.EOF", err)
+	}
+}
+
+func TestFraming_MaxMessageSize(t *testing.T) {
+	big := `{"data":"` + strings.Repeat("x", 100) + `"}`
+	small := `{"a":1}`
+
+	for _, framing := range []Framing{FramingNDJSON, FramingContentLength, FramingSSE} {
+		t.Run(framing.Name(), func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf, NewMCP(), framing)
+			_ = enc.WriteMessage([]byte(big))
+			_ = enc.WriteMessage([]byte(small))
+
+			// A reader buffer smaller than the frame exercises partial reads.
+			dec := NewDecoder(bufio.NewReaderSize(&buf, 16), NewMCP(), framing, WithMaxMessageSize(32))
+			if _, err := dec.ReadMessage(); !errors.Is(err, ErrMessageTooLarge) {
+				t.Fatalf("ReadMessage error = %v, want ErrMessageTooLarge", err)
+			}
+			got, err := dec.ReadMessage()
+			if err != nil {
+				t.Fatalf("ReadMessage after oversized frame error = %v", err)
+			}
+			if string(got) != small {
+				t.Errorf("ReadMessage = %q, want %q", got, small)
+			}
+		})
+	}
+}
+
+func TestFraming_NDJSON_MaxMessageSizeBoundary(t *testing.T) {
+	// A max equal to the reader buffer size splits lines across reads.
+	const max = 16
+	tests := []struct {
+		size   int
+		tooBig bool
+	}{
+		{max, false},
+		{max + 1, true},
+		{max + 2, true},
+	}
+	for _, tt := range tests {
+		for _, term := range []string{"\n", "\r\n", ""} {
+			name := fmt.Sprintf("%d bytes/%q", tt.size, term)
+			t.Run(name, func(t *testing.T) {
+				line := strings.Repeat("x", tt.size)
+				dec := NewDecoder(bufio.NewReaderSize(strings.NewReader(line+term), 16), NewMCP(),
+					FramingNDJSON, WithMaxMessageSize(max))
+				got, err := dec.ReadMessage()
+				if tt.tooBig {
+					if !errors.Is(err, ErrMessageTooLarge) {
+						t.Errorf("ReadMessage = %q, %v, want ErrMessageTooLarge", got, err)
+					}
+					return
+				}
+				if err != nil || string(got) != line {
+					t.Errorf("ReadMessage = %q, %v, want %q", got, err, line)
+				}
+			})
+		}
+	}
+}
+
+func TestEncoder_MaxMessageSize(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, NewMCP(), FramingNDJSON, WithMaxMessageSize(4))
+	if err := enc.WriteMessage([]byte(`{"a":1}`)); !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("WriteMessage error = %v, want ErrMessageTooLarge", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %d bytes, want 0", buf.Len())
+	}
+}
+
+func TestDecoder_Unlimited(t *testing.T) {
+	big := strings.Repeat("x", DefaultMaxMessageSize+1)
+	dec := NewDecoder(strings.NewReader(big+"\n"), NewMCP(), FramingNDJSON, WithMaxMessageSize(0))
+	got, err := dec.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage error = %v", err)
+	}
+	if len(got) != len(big) {
+		t.Errorf("len(ReadMessage) = %d, want %d", len(got), len(big))
+	}
+}
+
+func TestDecoder_ReusesBuffer(t *testing.T) {
+	frame := FramingContentLength.AppendFrame(nil, []byte(`{"jsonrpc":"2.0","id":"1","method":"ping"}`))
+	r := bytes.NewReader(nil)
+	dec := NewDecoder(r, NewMCP(), FramingContentLength)
+
+	// Warm up so the internal buffers reach steady-state size.
+	r.Reset(frame)
+	dec.r.Reset(r)
+	if _, err := dec.ReadMessage(); err != nil {
+		t.Fatalf("ReadMessage error = %v", err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Reset(frame)
+		dec.r.Reset(r)
+		if _, err := dec.ReadMessage(); err != nil {
+			t.Fatalf("ReadMessage error = %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("ReadMessage allocs = %v, want 0", allocs)
+	}
+}
@@ -0,0 +1,285 @@
+package wire
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"net/url"
+	"strings"
+)
+
+// Meta keys carrying W3C Trace Context and Baggage. The same keys are
+// used in every metadata map: Request.Meta and Response.Meta, which the
+// codecs carry as MCP "_meta", A2A "metadata" and ACP "_meta" or
+// "metadata", and the Metadata of A2A and ACP protocol types.
+const (
+	// MetaTraceParent holds the W3C traceparent header value.
+	MetaTraceParent = "traceparent"
+
+	// MetaTraceState holds the W3C tracestate header value.
+	MetaTraceState = "tracestate"
+
+	// MetaBaggage holds the W3C baggage header value.
+	MetaBaggage = "baggage"
+)
+
+// TraceID identifies a distributed trace.
+type TraceID [16]byte
+
+// String returns the ID as 32 lowercase hex digits.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid reports whether the ID is not all zeros.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the ID as 16 lowercase hex digits.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid reports whether the ID is not all zeros.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// TraceFlags are the trace-flags of a traceparent.
+type TraceFlags byte
+
+// TraceFlagsSampled marks a trace the caller may have recorded.
+const TraceFlagsSampled TraceFlags = 0x01
+
+// Sampled reports whether the sampled flag is set.
+func (f TraceFlags) Sampled() bool {
+	return f&TraceFlagsSampled != 0
+}
+
+// TraceContext is the W3C Trace Context of a message, plus its Baggage.
+//
+// It identifies the caller's span: a handler that receives a request
+// sees the caller as the parent of its own work. TraceState and Baggage
+// are carried as header values; ParseBaggage reads baggage entries.
+type TraceContext struct {
+	// TraceID identifies the trace.
+	TraceID TraceID
+
+	// ParentID is the span ID of the caller (the traceparent parent-id).
+	ParentID SpanID
+
+	// Flags are the trace flags.
+	Flags TraceFlags
+
+	// TraceState is the vendor-specific tracestate value, if any.
+	TraceState string
+
+	// Baggage is the W3C baggage value, if any.
+	Baggage string
+}
+
+// NewTraceContext starts a sampled trace with random IDs.
+func NewTraceContext() TraceContext {
+	var tc TraceContext
+	_, _ = rand.Read(tc.TraceID[:])
+	_, _ = rand.Read(tc.ParentID[:])
+	tc.Flags = TraceFlagsSampled
+	return tc
+}
+
+// Child returns the context of a new span in the same trace, with a
+// random span ID and the same flags, state and baggage. Use it when an
+// application without a tracer forwards a trace to another service.
+func (tc TraceContext) Child() TraceContext {
+	_, _ = rand.Read(tc.ParentID[:])
+	return tc
+}
+
+// IsValid reports whether both IDs are valid.
+func (tc TraceContext) IsValid() bool {
+	return tc.TraceID.IsValid() && tc.ParentID.IsValid()
+}
+
+// TraceParent returns the version 00 traceparent value.
+func (tc TraceContext) TraceParent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", tc.TraceID, tc.ParentID, byte(tc.Flags))
+}
+
+// ParseTraceParent parses a traceparent value.
+//
+// Version 00 values must be exactly 55 characters. Later versions may
+// append fields, which are ignored; version ff, uppercase hex and
+// all-zero IDs are invalid. Failures wrap ErrInvalidTraceContext.
+func ParseTraceParent(s string) (TraceContext, error) {
+	var tc TraceContext
+	invalid := func(why string) error {
+		return fmt.Errorf("%w: traceparent %q: %s", ErrInvalidTraceContext, s, why)
+	}
+	if len(s) < 55 || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return tc, invalid("malformed")
+	}
+	var version [1]byte
+	if !decodeLowerHex(version[:], s[0:2]) || version[0] == 0xff {
+		return tc, invalid("bad version")
+	}
+	switch {
+	case version[0] == 0 && len(s) != 55:
+		return tc, invalid("trailing data")
+	case len(s) > 55 && s[55] != '-':
+		return tc, invalid("malformed")
+	}
+	var flags [1]byte
+	if !decodeLowerHex(tc.TraceID[:], s[3:35]) ||
+		!decodeLowerHex(tc.ParentID[:], s[36:52]) ||
+		!decodeLowerHex(flags[:], s[53:55]) {
+		return TraceContext{}, invalid("bad hex")
+	}
+	tc.Flags = TraceFlags(flags[0])
+	if !tc.IsValid() {
+		return TraceContext{}, invalid("zero ID")
+	}
+	return tc, nil
+}
+
+// decodeLowerHex decodes s into dst, rejecting uppercase digits as
+// traceparent requires.
+func decodeLowerHex(dst []byte, s string) bool {
+	if strings.ToLower(s) != s {
+		return false
+	}
+	n, err := hex.Decode(dst, []byte(s))
+	return err == nil && n == len(dst)
+}
+
+// TraceFromMeta reads the trace context of a metadata map. It reports
+// false when the map has no valid traceparent, in which case a receiver
+// starts a new trace; tracestate and baggage are only read alongside a
+// valid traceparent, and a tracestate with more than 32 entries is
+// dropped.
+func TraceFromMeta(meta map[string]any) (TraceContext, bool) {
+	s, _ := meta[MetaTraceParent].(string)
+	tc, err := ParseTraceParent(strings.TrimSpace(s))
+	if err != nil {
+		return TraceContext{}, false
+	}
+	if state, ok := meta[MetaTraceState].(string); ok && strings.Count(state, ",") < 32 {
+		tc.TraceState = strings.TrimSpace(state)
+	}
+	if baggage, ok := meta[MetaBaggage].(string); ok {
+		tc.Baggage = strings.TrimSpace(baggage)
+	}
+	return tc, true
+}
+
+// Meta returns the metadata entries of tc: traceparent, and tracestate
+// and baggage when set.
+func (tc TraceContext) Meta() map[string]any {
+	m := map[string]any{MetaTraceParent: tc.TraceParent()}
+	if tc.TraceState != "" {
+		m[MetaTraceState] = tc.TraceState
+	}
+	if tc.Baggage != "" {
+		m[MetaBaggage] = tc.Baggage
+	}
+	return m
+}
+
+// InjectTraceMeta returns a copy of meta with the trace context entries
+// of tc. Entries from an earlier trace are replaced; meta is not
+// modified.
+func InjectTraceMeta(meta map[string]any, tc TraceContext) map[string]any {
+	out := maps.Clone(meta)
+	if out == nil {
+		out = make(map[string]any, 3)
+	}
+	delete(out, MetaTraceState)
+	delete(out, MetaBaggage)
+	maps.Copy(out, tc.Meta())
+	return out
+}
+
+// traceKey is the context key of a TraceContext.
+type traceKey struct{}
+
+// ContextWithTrace returns a copy of ctx carrying tc.
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey{}, tc)
+}
+
+// TraceFromContext returns the trace context carried by ctx.
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceKey{}).(TraceContext)
+	return tc, ok
+}
+
+// ExtractTrace returns ctx carrying the trace context of meta, so that a
+// handler sees the caller's span as its parent:
+//
+//	req, err := w.DecodeRequest(ctx, data)
+//	...
+//	ctx = wire.ExtractTrace(ctx, req.Meta)
+//	if tc, ok := wire.TraceFromContext(ctx); ok {
+//	    // tc.ParentID is the caller's span.
+//	}
+//
+// Without a valid traceparent, ctx is returned unchanged.
+func ExtractTrace(ctx context.Context, meta map[string]any) context.Context {
+	if tc, ok := TraceFromMeta(meta); ok {
+		return ContextWithTrace(ctx, tc)
+	}
+	return ctx
+}
+
+// PropagateTrace returns an interceptor that injects the trace context
+// carried by the call's context into every request and response it
+// encodes, as InjectTraceMeta does. Calls without a trace context are
+// unchanged.
+func PropagateTrace() Interceptor {
+	return func(ctx context.Context, m *Message, next Handler) error {
+		tc, ok := TraceFromContext(ctx)
+		if !ok || !tc.IsValid() {
+			return next(ctx, m)
+		}
+		switch {
+		case m.Op == OpEncodeRequest && m.Request != nil:
+			req := *m.Request
+			req.Meta = InjectTraceMeta(req.Meta, tc)
+			m.Request = &req
+		case m.Op == OpEncodeResponse && m.Response != nil:
+			resp := *m.Response
+			resp.Meta = InjectTraceMeta(resp.Meta, tc)
+			m.Response = &resp
+		}
+		return next(ctx, m)
+	}
+}
+
+// ParseBaggage parses a W3C baggage value into its entries, with values
+// percent-decoded and entry properties dropped. Malformed entries return
+// ErrInvalidTraceContext.
+func ParseBaggage(s string) (map[string]string, error) {
+	out := make(map[string]string)
+	if strings.TrimSpace(s) == "" {
+		return out, nil
+	}
+	for member := range strings.SplitSeq(s, ",") {
+		member, _, _ = strings.Cut(member, ";")
+		key, value, ok := strings.Cut(member, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t\"(),/:<=>?@[\\]{}") {
+			return nil, fmt.Errorf("%w: baggage member %q", ErrInvalidTraceContext, member)
+		}
+		v, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%w: baggage member %q: %w", ErrInvalidTraceContext, member, err)
+		}
+		out[key] = v
+	}
+	return out, nil
+}
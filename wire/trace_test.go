@@ -0,0 +1,200 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceParent(t *testing.T) {
+	tc, err := ParseTraceParent(testTraceParent)
+	if err != nil {
+		t.Fatalf("ParseTraceParent() error = %v", err)
+	}
+	if tc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || tc.ParentID.String() != "00f067aa0ba902b7" {
+		t.Errorf("ParseTraceParent() = %s %s", tc.TraceID, tc.ParentID)
+	}
+	if !tc.Flags.Sampled() || !tc.IsValid() {
+		t.Errorf("ParseTraceParent() flags = %02x, valid = %v", byte(tc.Flags), tc.IsValid())
+	}
+	if got := tc.TraceParent(); got != testTraceParent {
+		t.Errorf("TraceParent() = %s, want %s", got, testTraceParent)
+	}
+
+	// Later versions may append fields.
+	future := "cc" + testTraceParent[2:] + "-what-the-future-holds"
+	if tc, err := ParseTraceParent(future); err != nil || tc.TraceParent() != testTraceParent {
+		t.Errorf("ParseTraceParent(future) = %s, %v", tc.TraceParent(), err)
+	}
+}
+
+func TestParseTraceParent_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01x",
+	} {
+		if _, err := ParseTraceParent(s); !errors.Is(err, ErrInvalidTraceContext) {
+			t.Errorf("ParseTraceParent(%q) error = %v, want ErrInvalidTraceContext", s, err)
+		}
+	}
+}
+
+func TestNewTraceContext(t *testing.T) {
+	tc := NewTraceContext()
+	if !tc.IsValid() || !tc.Flags.Sampled() {
+		t.Fatalf("NewTraceContext() = %+v, want valid and sampled", tc)
+	}
+	tc.Baggage = "user=ada"
+	child := tc.Child()
+	if child.TraceID != tc.TraceID || child.ParentID == tc.ParentID || child.Baggage != tc.Baggage {
+		t.Errorf("Child() = %+v, want same trace with a new span", child)
+	}
+	parsed, err := ParseTraceParent(child.TraceParent())
+	if err != nil || parsed.ParentID != child.ParentID {
+		t.Errorf("ParseTraceParent(Child()) = %+v, %v", parsed, err)
+	}
+}
+
+func TestTraceMeta(t *testing.T) {
+	meta := map[string]any{
+		"tenant":        "acme",
+		MetaTraceParent: "00-11111111111111111111111111111111-2222222222222222-00",
+		MetaTraceState:  "old=1",
+	}
+	tc, err := ParseTraceParent(testTraceParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc.Baggage = "user=ada"
+	got := InjectTraceMeta(meta, tc)
+	want := map[string]any{"tenant": "acme", MetaTraceParent: testTraceParent, MetaBaggage: "user=ada"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InjectTraceMeta() = %v, want %v", got, want)
+	}
+	if meta[MetaTraceState] != "old=1" {
+		t.Error("InjectTraceMeta modified its input")
+	}
+
+	back, ok := TraceFromMeta(got)
+	if !ok || back != tc {
+		t.Errorf("TraceFromMeta() = %+v, %v, want %+v", back, ok, tc)
+	}
+	if _, ok := TraceFromMeta(map[string]any{MetaTraceParent: "garbage", MetaBaggage: "a=b"}); ok {
+		t.Error("TraceFromMeta(invalid traceparent) ok = true")
+	}
+	if _, ok := TraceFromMeta(nil); ok {
+		t.Error("TraceFromMeta(nil) ok = true")
+	}
+	long := map[string]any{MetaTraceParent: testTraceParent, MetaTraceState: strings.Repeat("k=v,", 32) + "k=v"}
+	if tc, _ := TraceFromMeta(long); tc.TraceState != "" {
+		t.Errorf("TraceFromMeta() kept a tracestate of 33 entries")
+	}
+}
+
+func TestTraceContext_Context(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := TraceFromContext(ctx); ok {
+		t.Error("TraceFromContext(Background) ok = true")
+	}
+	if got := ExtractTrace(ctx, map[string]any{"a": 1}); got != ctx {
+		t.Error("ExtractTrace() without a trace changed the context")
+	}
+	ctx = ExtractTrace(ctx, map[string]any{MetaTraceParent: testTraceParent, MetaTraceState: "vendor=x"})
+	tc, ok := TraceFromContext(ctx)
+	if !ok || tc.ParentID.String() != "00f067aa0ba902b7" || tc.TraceState != "vendor=x" {
+		t.Errorf("TraceFromContext() = %+v, %v", tc, ok)
+	}
+}
+
+// TestPropagateTrace sends a trace from a client context through each
+// codec and reads it back on the server side.
+func TestPropagateTrace(t *testing.T) {
+	tc, err := ParseTraceParent(testTraceParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc.TraceState = "vendor=opaque"
+	tc.Baggage = "tenant=acme,user=ada%20l"
+	client := ContextWithTrace(context.Background(), tc)
+
+	requests := map[string]*Request{
+		"tools/call":     {ID: "1", Method: "tools/call", ToolID: "search", Meta: map[string]any{"keep": "me"}},
+		"session/prompt": {ID: "2", Method: "session/prompt", Arguments: map[string]any{"sessionId": "s"}},
+		"agents/run":     {ID: "3", Method: "agents/run", ToolID: "summarizer"},
+	}
+	for _, codec := range []Wire{NewMCP(), NewA2A(), NewACP(), NewBinary(EncodingCBOR)} {
+		w := Intercept(codec, PropagateTrace())
+		for name, req := range requests {
+			data, err := w.EncodeRequest(client, req)
+			if err != nil {
+				t.Fatalf("%s %s: EncodeRequest() error = %v", codec.Name(), name, err)
+			}
+			got, err := codec.DecodeRequest(context.Background(), data)
+			if err != nil {
+				t.Fatalf("%s %s: DecodeRequest() error = %v", codec.Name(), name, err)
+			}
+			server, ok := TraceFromContext(ExtractTrace(context.Background(), got.Meta))
+			if !ok || server != tc {
+				t.Errorf("%s %s: server trace = %+v, %v, want %+v (meta %v)", codec.Name(), name, server, ok, tc, got.Meta)
+			}
+			if req.Meta != nil && got.Meta["keep"] != "me" {
+				t.Errorf("%s %s: meta = %v, want existing entries kept", codec.Name(), name, got.Meta)
+			}
+		}
+		if len(requests["tools/call"].Meta) != 1 {
+			t.Fatalf("PropagateTrace modified the caller's request: %v", requests["tools/call"].Meta)
+		}
+
+		data, err := w.EncodeResponse(client, &Response{ID: "1", Content: []Content{{Type: ContentTypeText, Text: "ok"}}})
+		if err != nil {
+			t.Fatalf("%s: EncodeResponse() error = %v", codec.Name(), err)
+		}
+		resp, err := codec.DecodeResponse(context.Background(), data)
+		if err != nil {
+			t.Fatalf("%s: DecodeResponse() error = %v", codec.Name(), err)
+		}
+		if got, ok := TraceFromMeta(resp.Meta); !ok || got != tc {
+			t.Errorf("%s: response trace = %+v, %v, want %+v", codec.Name(), got, ok, tc)
+		}
+	}
+
+	// Without a trace in the context, messages are unchanged.
+	data, err := Intercept(NewMCP(), PropagateTrace()).EncodeRequest(context.Background(), requests["tools/call"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), MetaTraceParent) {
+		t.Errorf("EncodeRequest() = %s, want no traceparent", data)
+	}
+}
+
+func TestParseBaggage(t *testing.T) {
+	got, err := ParseBaggage(" userId=alice , serverNode = DF%2028 ;prop=1,isProduction=false")
+	if err != nil {
+		t.Fatalf("ParseBaggage() error = %v", err)
+	}
+	want := map[string]string{"userId": "alice", "serverNode": "DF 28", "isProduction": "false"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseBaggage() = %v, want %v", got, want)
+	}
+	if got, err := ParseBaggage(""); err != nil || len(got) != 0 {
+		t.Errorf("ParseBaggage(\"\") = %v, %v", got, err)
+	}
+	for _, s := range []string{"novalue", "=v", "a b=c", "k=%zz"} {
+		if _, err := ParseBaggage(s); !errors.Is(err, ErrInvalidTraceContext) {
+			t.Errorf("ParseBaggage(%q) error = %v, want ErrInvalidTraceContext", s, err)
+		}
+	}
+}
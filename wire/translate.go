@@ -0,0 +1,355 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// MessageKind identifies the shape of a message being translated.
+type MessageKind string
+
+const (
+	// KindRequest is a tool invocation request.
+	KindRequest MessageKind = "request"
+
+	// KindResponse is a tool invocation response.
+	KindResponse MessageKind = "response"
+
+	// KindToolList is a tool, skill, or agent listing.
+	KindToolList MessageKind = "tool_list"
+
+	// KindError is an error response.
+	KindError MessageKind = "error"
+
+	// KindTaskStatus is a response carrying task status metadata.
+	KindTaskStatus MessageKind = "task_status"
+)
+
+// Translation is the result of translating a message between protocols.
+type Translation struct {
+	// Kind is the message kind that was translated.
+	Kind MessageKind
+
+	// Data is the message encoded in the target protocol.
+	Data []byte
+
+	// Dropped lists fields present in the source message that the target
+	// protocol could not represent, as paths like "content[0].data" or
+	// "meta.traceId". Empty when the translation is lossless.
+	Dropped []string
+}
+
+// Lossless reports whether every source field survived translation.
+func (t *Translation) Lossless() bool {
+	return len(t.Dropped) == 0
+}
+
+// Translator converts raw messages from one wire protocol to another.
+//
+// Translation decodes the payload with the source codec, encodes it with
+// the target codec, and then decodes the result again with the target codec
+// to detect fields that did not survive the trip.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use if both codecs are.
+//   - Errors: Decode/encode failures wrap ErrTranslateFailure.
+//   - Ownership: Input data is not modified.
+type Translator struct {
+	from Wire
+	to   Wire
+}
+
+// NewTranslator creates a translator from one wire protocol to another.
+func NewTranslator(from, to Wire) *Translator {
+	return &Translator{from: from, to: to}
+}
+
+// From returns the source codec.
+func (t *Translator) From() Wire {
+	return t.from
+}
+
+// To returns the target codec.
+func (t *Translator) To() Wire {
+	return t.to
+}
+
+// Translate converts a message of the given kind from the source protocol
+// to the target protocol.
+func (t *Translator) Translate(ctx context.Context, kind MessageKind, data []byte) (*Translation, error) {
+	switch kind {
+	case KindRequest:
+		return t.TranslateRequest(ctx, data)
+	case KindResponse:
+		return t.TranslateResponse(ctx, data)
+	case KindToolList:
+		return t.TranslateToolList(ctx, data)
+	case KindError:
+		return t.TranslateError(ctx, data)
+	case KindTaskStatus:
+		return t.TranslateTaskStatus(ctx, data)
+	default:
+		return nil, fmt.Errorf("%w: unknown message kind %q", ErrTranslateFailure, kind)
+	}
+}
+
+// TranslateRequest converts a request between protocols.
+func (t *Translator) TranslateRequest(ctx context.Context, data []byte) (*Translation, error) {
+	req, err := t.from.DecodeRequest(ctx, data)
+	if err != nil {
+		return nil, t.fail("decode", err)
+	}
+	out, err := t.to.EncodeRequest(ctx, req)
+	if err != nil {
+		return nil, t.fail("encode", err)
+	}
+	back, err := t.to.DecodeRequest(ctx, out)
+	if err != nil {
+		return nil, t.fail("verify", err)
+	}
+	return &Translation{
+		Kind:    KindRequest,
+		Data:    out,
+		Dropped: diffRequest(req, back),
+	}, nil
+}
+
+// TranslateResponse converts a response between protocols.
+func (t *Translator) TranslateResponse(ctx context.Context, data []byte) (*Translation, error) {
+	resp, err := t.from.DecodeResponse(ctx, data)
+	if err != nil {
+		return nil, t.fail("decode", err)
+	}
+	return t.translateResponse(ctx, KindResponse, resp)
+}
+
+// TranslateError converts an error response between protocols.
+// The source payload must decode to a response with IsError set.
+func (t *Translator) TranslateError(ctx context.Context, data []byte) (*Translation, error) {
+	resp, err := t.from.DecodeResponse(ctx, data)
+	if err != nil {
+		return nil, t.fail("decode", err)
+	}
+	if !resp.IsError || resp.Error == nil {
+		return nil, fmt.Errorf("%w: message is not an error response", ErrTranslateFailure)
+	}
+	return t.translateResponse(ctx, KindError, resp)
+}
+
+// TranslateTaskStatus converts a task status response between protocols.
+// The source payload must decode to a response whose Meta carries a
+// "status" object or a "state" string.
+func (t *Translator) TranslateTaskStatus(ctx context.Context, data []byte) (*Translation, error) {
+	resp, err := t.from.DecodeResponse(ctx, data)
+	if err != nil {
+		return nil, t.fail("decode", err)
+	}
+	if _, ok := resp.Meta["status"]; !ok {
+		if _, ok := resp.Meta["state"]; !ok {
+			return nil, fmt.Errorf("%w: message carries no task status", ErrTranslateFailure)
+		}
+	}
+	return t.translateResponse(ctx, KindTaskStatus, resp)
+}
+
+// TranslateToolList converts a tool, skill, or agent list between protocols.
+func (t *Translator) TranslateToolList(ctx context.Context, data []byte) (*Translation, error) {
+	tools, err := t.from.DecodeToolList(ctx, data)
+	if err != nil {
+		return nil, t.fail("decode", err)
+	}
+	out, err := t.to.EncodeToolList(ctx, tools)
+	if err != nil {
+		return nil, t.fail("encode", err)
+	}
+	back, err := t.to.DecodeToolList(ctx, out)
+	if err != nil {
+		return nil, t.fail("verify", err)
+	}
+	return &Translation{
+		Kind:    KindToolList,
+		Data:    out,
+		Dropped: diffTools(tools, back),
+	}, nil
+}
+
+func (t *Translator) translateResponse(ctx context.Context, kind MessageKind, resp *Response) (*Translation, error) {
+	out, err := t.to.EncodeResponse(ctx, resp)
+	if err != nil {
+		return nil, t.fail("encode", err)
+	}
+	back, err := t.to.DecodeResponse(ctx, out)
+	if err != nil {
+		return nil, t.fail("verify", err)
+	}
+	return &Translation{
+		Kind:    kind,
+		Data:    out,
+		Dropped: diffResponse(resp, back),
+	}, nil
+}
+
+func (t *Translator) fail(stage string, err error) error {
+	name := t.from.Name()
+	if stage != "decode" {
+		name = t.to.Name()
+	}
+	return fmt.Errorf("%w: %s %s: %w", ErrTranslateFailure, stage, name, err)
+}
+
+// invokeMethods are the tool invocation methods of each protocol.
+// Translating between them is a rename, not a loss.
+var invokeMethods = map[string]bool{
+	"tools/call":         true,
+	A2AMethodMessageSend: true,
+}
+
+// diffRequest reports request fields in src that are missing or altered in dst.
+func diffRequest(src, dst *Request) []string {
+	var dropped []string
+	if src.ID != dst.ID {
+		dropped = append(dropped, "id")
+	}
+	if src.Method != dst.Method && !(invokeMethods[src.Method] && invokeMethods[dst.Method]) {
+		dropped = append(dropped, "method")
+	}
+	if src.ToolID != dst.ToolID {
+		dropped = append(dropped, "toolId")
+	}
+	dropped = diffMap(dropped, "arguments", src.Arguments, dst.Arguments)
+	dropped = diffMap(dropped, "meta", src.Meta, dst.Meta)
+	return dropped
+}
+
+// diffResponse reports response fields in src that are missing or altered in dst.
+func diffResponse(src, dst *Response) []string {
+	var dropped []string
+	if src.ID != dst.ID {
+		dropped = append(dropped, "id")
+	}
+	if src.IsError != dst.IsError {
+		dropped = append(dropped, "isError")
+	}
+	if src.Error != nil && dst.Error == nil {
+		dropped = append(dropped, "error")
+	} else if src.Error != nil {
+		if src.Error.Code != dst.Error.Code {
+			dropped = append(dropped, "error.code")
+		}
+		if src.Error.Message != dst.Error.Message {
+			dropped = append(dropped, "error.message")
+		}
+		if src.Error.Data != nil && !jsonEqual(src.Error.Data, dst.Error.Data) {
+			dropped = append(dropped, "error.data")
+		}
+	}
+	for i, c := range src.Content {
+		prefix := "content[" + strconv.Itoa(i) + "]"
+		if i >= len(dst.Content) {
+			dropped = append(dropped, prefix)
+			continue
+		}
+		d := dst.Content[i]
+		if c.Type != d.Type {
+			dropped = append(dropped, prefix+".type")
+		}
+		if c.Text != "" && c.Text != d.Text {
+			dropped = append(dropped, prefix+".text")
+		}
+		if c.MIMEType != "" && c.MIMEType != d.MIMEType {
+			dropped = append(dropped, prefix+".mimeType")
+		}
+		if len(c.Data) > 0 && !reflect.DeepEqual(c.Data, d.Data) {
+			dropped = append(dropped, prefix+".data")
+		}
+		if c.URI != "" && c.URI != d.URI {
+			dropped = append(dropped, prefix+".uri")
+		}
+		if c.AltText != "" && c.AltText != d.AltText {
+			dropped = append(dropped, prefix+".altText")
+		}
+		if c.Width != 0 && c.Width != d.Width {
+			dropped = append(dropped, prefix+".width")
+		}
+		if c.Height != 0 && c.Height != d.Height {
+			dropped = append(dropped, prefix+".height")
+		}
+		if c.Name != "" && c.Name != d.Name {
+			dropped = append(dropped, prefix+".name")
+		}
+		if c.Size != 0 && c.Size != d.Size {
+			dropped = append(dropped, prefix+".size")
+		}
+		if c.Duration != 0 && c.Duration != d.Duration {
+			dropped = append(dropped, prefix+".duration")
+		}
+		if c.Annotations != nil && !reflect.DeepEqual(annotationsMap(c.Annotations), annotationsMap(d.Annotations)) {
+			dropped = append(dropped, prefix+".annotations")
+		}
+	}
+	if src.StructuredContent != nil && !jsonEqual(src.StructuredContent, dst.StructuredContent) {
+		dropped = append(dropped, "structuredContent")
+	}
+	return diffMap(dropped, "meta", src.Meta, dst.Meta)
+}
+
+// diffTools reports tool fields in src that are missing or altered in dst.
+func diffTools(src, dst []Tool) []string {
+	var dropped []string
+	for i, t := range src {
+		prefix := "tools[" + strconv.Itoa(i) + "]"
+		if i >= len(dst) {
+			dropped = append(dropped, prefix)
+			continue
+		}
+		d := dst[i]
+		if t.Name != d.Name {
+			dropped = append(dropped, prefix+".name")
+		}
+		if t.Description != "" && t.Description != d.Description {
+			dropped = append(dropped, prefix+".description")
+		}
+		if len(t.InputSchema) > 0 && !jsonEqual(t.InputSchema, d.InputSchema) {
+			dropped = append(dropped, prefix+".inputSchema")
+		}
+		if len(t.OutputSchema) > 0 && !jsonEqual(t.OutputSchema, d.OutputSchema) {
+			dropped = append(dropped, prefix+".outputSchema")
+		}
+		if len(t.Annotations) > 0 && !jsonEqual(t.Annotations, d.Annotations) {
+			dropped = append(dropped, prefix+".annotations")
+		}
+	}
+	return dropped
+}
+
+// diffMap appends the keys of src that are absent from, or differ in, dst.
+// Keys are visited in sorted order so reports are deterministic.
+func diffMap(dropped []string, prefix string, src, dst map[string]any) []string {
+	for _, k := range slices.Sorted(maps.Keys(src)) {
+		v, ok := dst[k]
+		if !ok || !jsonEqual(src[k], v) {
+			dropped = append(dropped, prefix+"."+k)
+		}
+	}
+	return dropped
+}
+
+// jsonEqual compares two values by their JSON representation so that
+// numeric types and nested maps compare equal after a JSON round-trip.
+func jsonEqual(a, b any) bool {
+	ab, errA := json.Marshal(a)
+	bb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	var av, bv any
+	if json.Unmarshal(ab, &av) != nil || json.Unmarshal(bb, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
@@ -0,0 +1,204 @@
+package wire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTranslator_Request_MCPToA2A(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTranslator(NewMCP(), NewA2A())
+
+	src := []byte(`{"jsonrpc":"2.0","id":"1","method":"tools/call","params":{"name":"search","arguments":{"query":"go","limit":5},"_meta":{"tenant":"acme"}}}`)
+	out, err := tr.TranslateRequest(ctx, src)
+	if err != nil {
+		t.Fatalf("TranslateRequest error = %v", err)
+	}
+	if !out.Lossless() {
+		t.Errorf("Dropped = %v, want none", out.Dropped)
+	}
+
+	req, err := NewA2A().DecodeRequest(ctx, out.Data)
+	if err != nil {
+		t.Fatalf("DecodeRequest error = %v", err)
+	}
+	if req.ToolID != "search" {
+		t.Errorf("ToolID = %q, want %q", req.ToolID, "search")
+	}
+	if req.Arguments["query"] != "go" {
+		t.Errorf("Arguments[query] = %v, want %q", req.Arguments["query"], "go")
+	}
+	if req.Meta["tenant"] != "acme" {
+		t.Errorf("Meta[tenant] = %v, want %q", req.Meta["tenant"], "acme")
+	}
+}
+
+func TestTranslator_Response_ImageData(t *testing.T) {
+	ctx := context.Background()
+	resp := &Response{
+		ID: "1",
+		Content: []Content{
+			{Type: ContentTypeImage, Data: []byte{0x89, 0x50, 0x4e, 0x47}, MIMEType: "image/png"},
+		},
+	}
+	src, err := NewMCP().EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+
+	for _, to := range []Wire{NewMCP(), NewA2A(), NewACP()} {
+		out, err := NewTranslator(NewMCP(), to).TranslateResponse(ctx, src)
+		if err != nil {
+			t.Fatalf("%s: TranslateResponse error = %v", to.Name(), err)
+		}
+		if !out.Lossless() {
+			t.Errorf("%s: Dropped = %v, want none", to.Name(), out.Dropped)
+		}
+	}
+}
+
+func TestTranslator_Response_ReportsDropped(t *testing.T) {
+	ctx := context.Background()
+	resp := &Response{
+		ID: "1",
+		Content: []Content{
+			{Type: ContentTypeText, Text: "2 results"},
+		},
+		StructuredContent: map[string]any{"count": float64(2)},
+	}
+	src, err := NewMCP().EncodeResponse(ctx, resp)
+	if err != nil {
+		t.Fatalf("EncodeResponse error = %v", err)
+	}
+
+	out, err := NewTranslator(NewMCP(), NewACP()).TranslateResponse(ctx, src)
+	if err != nil {
+		t.Fatalf("TranslateResponse error = %v", err)
+	}
+	if !slices.Equal(out.Dropped, []string{"structuredContent"}) {
+		t.Errorf("Dropped = %v, want [structuredContent]", out.Dropped)
+	}
+}
+
+func TestTranslator_Error(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTranslator(NewACP(), NewMCP())
+
+	src := []byte(`{"jsonrpc":"2.0","id":"7","error":{"code":-32601,"message":"Method not found","data":{"method":"x"}}}`)
+	out, err := tr.Translate(ctx, KindError, src)
+	if err != nil {
+		t.Fatalf("Translate error = %v", err)
+	}
+	if out.Kind != KindError {
+		t.Errorf("Kind = %q, want %q", out.Kind, KindError)
+	}
+	if !out.Lossless() {
+		t.Errorf("Dropped = %v, want none", out.Dropped)
+	}
+
+	var rpc map[string]any
+	if err := json.Unmarshal(out.Data, &rpc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	rpcErr, ok := rpc["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("error missing from %s", out.Data)
+	}
+	if rpcErr["code"] != float64(-32601) {
+		t.Errorf("error.code = %v, want -32601", rpcErr["code"])
+	}
+}
+
+func TestTranslator_Error_NotAnError(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTranslator(NewMCP(), NewA2A())
+
+	src := []byte(`{"jsonrpc":"2.0","id":"1","result":{"content":[]}}`)
+	if _, err := tr.TranslateError(ctx, src); !errors.Is(err, ErrTranslateFailure) {
+		t.Errorf("TranslateError error = %v, want ErrTranslateFailure", err)
+	}
+}
+
+func TestTranslator_TaskStatus_A2AToMCP(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTranslator(NewA2A(), NewMCP())
+
+	src := []byte(`{"jsonrpc":"2.0","id":"1","result":{"taskId":"t-1","status":{"state":"working"},"artifacts":[]}}`)
+	out, err := tr.TranslateTaskStatus(ctx, src)
+	if err != nil {
+		t.Fatalf("TranslateTaskStatus error = %v", err)
+	}
+	if !out.Lossless() {
+		t.Errorf("Dropped = %v, want none", out.Dropped)
+	}
+
+	resp, err := NewMCP().DecodeResponse(ctx, out.Data)
+	if err != nil {
+		t.Fatalf("DecodeResponse error = %v", err)
+	}
+	status, ok := resp.Meta["status"].(map[string]any)
+	if !ok || status["state"] != "working" {
+		t.Errorf("Meta[status] = %v, want state working", resp.Meta["status"])
+	}
+}
+
+func TestTranslator_TaskStatus_Missing(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTranslator(NewMCP(), NewA2A())
+
+	src := []byte(`{"jsonrpc":"2.0","id":"1","result":{"content":[]}}`)
+	if _, err := tr.TranslateTaskStatus(ctx, src); !errors.Is(err, ErrTranslateFailure) {
+		t.Errorf("TranslateTaskStatus error = %v, want ErrTranslateFailure", err)
+	}
+}
+
+func TestTranslator_ToolList(t *testing.T) {
+	ctx := context.Background()
+	tools := []Tool{
+		{Name: "search", Description: "Search", InputSchema: map[string]any{"type": "object"}},
+		{Name: "fetch"},
+	}
+
+	for _, from := range []Wire{NewMCP(), NewA2A(), NewACP()} {
+		for _, to := range []Wire{NewMCP(), NewA2A(), NewACP()} {
+			src, err := from.EncodeToolList(ctx, tools)
+			if err != nil {
+				t.Fatalf("EncodeToolList error = %v", err)
+			}
+			out, err := NewTranslator(from, to).TranslateToolList(ctx, src)
+			if err != nil {
+				t.Fatalf("%s->%s: TranslateToolList error = %v", from.Name(), to.Name(), err)
+			}
+			if !out.Lossless() {
+				t.Errorf("%s->%s: Dropped = %v, want none", from.Name(), to.Name(), out.Dropped)
+			}
+			got, err := to.DecodeToolList(ctx, out.Data)
+			if err != nil {
+				t.Fatalf("DecodeToolList error = %v", err)
+			}
+			if len(got) != len(tools) {
+				t.Errorf("%s->%s: len(tools) = %d, want %d", from.Name(), to.Name(), len(got), len(tools))
+			}
+		}
+	}
+}
+
+func TestTranslator_DecodeFailure(t *testing.T) {
+	ctx := context.Background()
+	tr := NewTranslator(NewMCP(), NewA2A())
+
+	_, err := tr.TranslateRequest(ctx, []byte(`{invalid`))
+	if !errors.Is(err, ErrTranslateFailure) {
+		t.Errorf("TranslateRequest error = %v, want ErrTranslateFailure", err)
+	}
+}
+
+func TestTranslator_UnknownKind(t *testing.T) {
+	tr := NewTranslator(NewMCP(), NewA2A())
+	if _, err := tr.Translate(context.Background(), MessageKind("bogus"), []byte(`{}`)); !errors.Is(err, ErrTranslateFailure) {
+		t.Errorf("Translate error = %v, want ErrTranslateFailure", err)
+	}
+}
@@ -1,6 +1,11 @@
 package wire
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/jonwraymond/toolprotocol/content"
+)
 
 // ContentType identifies the type of content in a response.
 type ContentType string
@@ -14,6 +19,12 @@ const (
 
 	// ContentTypeResource is a resource reference.
 	ContentTypeResource ContentType = "resource"
+
+	// ContentTypeAudio is audio data.
+	ContentTypeAudio ContentType = "audio"
+
+	// ContentTypeFile is file data.
+	ContentTypeFile ContentType = "file"
 )
 
 // Content represents a piece of response content.
@@ -21,17 +32,39 @@ type Content struct {
 	// Type identifies the content type.
 	Type ContentType
 
-	// Text is the text content (for ContentTypeText).
+	// Text is the text content (for ContentTypeText), or the text of a
+	// resource.
 	Text string
 
-	// MIMEType is the MIME type (for images and resources).
+	// MIMEType is the MIME type (for binary content and resources).
 	MIMEType string
 
-	// Data is binary data (for images).
+	// Data is binary data (for images, audio, files and resource blobs).
 	Data []byte
 
-	// URI is the resource URI (for ContentTypeResource).
+	// URI is the resource URI (for ContentTypeResource), or the source
+	// URI of an image.
 	URI string
+
+	// AltText is accessibility text (for images).
+	AltText string
+
+	// Width and Height are the pixel dimensions of an image, or 0 when
+	// unknown.
+	Width  int
+	Height int
+
+	// Name is the file name or path (for files).
+	Name string
+
+	// Size is the file size in bytes, which may be set without Data.
+	Size int64
+
+	// Duration is the playback duration (for audio).
+	Duration time.Duration
+
+	// Annotations are optional audience, priority and modification hints.
+	Annotations *content.Annotations
 }
 
 // Tool describes a tool's interface.
@@ -44,6 +77,14 @@ type Tool struct {
 
 	// InputSchema is the JSON Schema for tool arguments.
 	InputSchema map[string]any
+
+	// OutputSchema is the JSON Schema for structured tool results.
+	// Optional; protocols without structured output drop it.
+	OutputSchema map[string]any
+
+	// Annotations are MCP tool behavior hints such as readOnlyHint and
+	// destructiveHint. Optional; protocols without them drop it.
+	Annotations map[string]any
 }
 
 // Error represents a wire protocol error.
@@ -56,6 +97,16 @@ type Error struct {
 
 	// Data contains additional error details.
 	Data any
+
+	// Protocol scopes Code to the protocol that defines it, by codec
+	// name ("mcp", "a2a", "acp"). Protocols assign server-defined codes
+	// independently, so Is matches those codes only within the same
+	// protocol. Empty means the code is not scoped. Codecs scope decoded
+	// errors with codes in the server-defined range (-32099 to -32000).
+	Protocol string
+
+	// cause is the Go error FromError mapped, if any.
+	cause error
 }
 
 // Error implements the error interface.
@@ -65,3 +116,22 @@ func (e *Error) Error() string {
 	}
 	return fmt.Sprintf("%s (code: %d)", e.Message, e.Code)
 }
+
+// Is reports whether target is an *Error with the same code, so that
+// errors.Is(err, ErrTaskNotFound) matches any task-not-found error.
+// Server-defined codes (-32099 to -32000) must have the same Protocol
+// too: MCP's resource-not-found and A2A's task-not-cancelable share
+// -32002 but are different errors, and an unscoped -32002 is neither.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || e == nil || t == nil || e.Code != t.Code {
+		return false
+	}
+	return !serverDefined(e.Code) || e.Protocol == t.Protocol
+}
+
+// Unwrap returns the Go error the protocol error was mapped from by
+// FromError, or nil.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
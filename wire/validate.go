@@ -0,0 +1,588 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxRefDepth bounds $ref chains that do not descend into the instance,
+// so that a self-referencing schema cannot recurse forever.
+const maxRefDepth = 32
+
+// Violation describes one way an instance fails a schema.
+type Violation struct {
+	// Path is a JSON Pointer (RFC 6901) to the offending value;
+	// "" is the instance root.
+	Path string `json:"path"`
+
+	// Keyword is the schema keyword that failed, such as "type" or "required".
+	Keyword string `json:"keyword"`
+
+	// Message describes the failure.
+	Message string `json:"message"`
+}
+
+// String returns the violation as "path: message".
+func (v Violation) String() string {
+	path := v.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return path + ": " + v.Message
+}
+
+// ValidationError reports arguments that do not satisfy a tool's input
+// schema. It matches ErrInvalidArguments with errors.Is, and FromError
+// maps it to an invalid-params error carrying the violations as data.
+type ValidationError struct {
+	// Violations lists every failure found, in instance order.
+	Violations []Violation
+}
+
+// Error returns all violations joined by "; ".
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+	return ErrInvalidArguments.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns ErrInvalidArguments.
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidArguments
+}
+
+// Schema is a compiled JSON Schema.
+//
+// Schema implements the subset of JSON Schema 2020-12 that tool input
+// schemas use in practice: type, properties, required,
+// additionalProperties, enum, const, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, minLength, maxLength, pattern,
+// items, minItems, maxItems, allOf, anyOf, oneOf, not, and $ref to
+// local definitions ("#/$defs/..." or any JSON Pointer within the
+// document). Other keywords, including format, are ignored.
+//
+// Contract:
+//   - Concurrency: Safe for concurrent use after CompileSchema returns.
+//   - Errors: Validate returns a *ValidationError listing every violation.
+type Schema struct {
+	root     any
+	patterns map[string]*regexp.Regexp
+
+	// refs holds the references whose targets have been compiled, so
+	// that cyclic references compile once.
+	refs map[string]bool
+}
+
+// CompileSchema checks a schema and prepares it for validation.
+// A nil or empty schema accepts any value.
+//
+// Malformed keywords, invalid patterns and unresolvable references
+// return an error wrapping ErrInvalidSchema.
+func CompileSchema(schema map[string]any) (*Schema, error) {
+	s := &Schema{root: true, patterns: make(map[string]*regexp.Regexp), refs: make(map[string]bool)}
+	if schema != nil {
+		root, err := jsonValue(schema)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+		}
+		s.root = root
+	}
+	if err := s.compile(s.root, ""); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ValidateArguments checks args against tool's input schema.
+// Missing arguments are validated as an empty object.
+func ValidateArguments(tool Tool, args map[string]any) error {
+	s, err := CompileSchema(tool.InputSchema)
+	if err != nil {
+		return fmt.Errorf("tool %q: %w", tool.Name, err)
+	}
+	if args == nil {
+		args = map[string]any{}
+	}
+	return s.Validate(args)
+}
+
+// Validate checks v against the schema. v may be any value that
+// encodes to JSON; decoded JSON (map[string]any, []any, float64) is
+// checked directly.
+func (s *Schema) Validate(v any) error {
+	v, err := jsonValue(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	var vs validation
+	vs.schema = s
+	vs.validate(s.root, v, "", 0)
+	if len(vs.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: vs.violations}
+}
+
+// compile walks a schema checking keyword shapes and caching patterns.
+func (s *Schema) compile(node any, loc string) error {
+	if _, ok := node.(bool); ok {
+		return nil
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		return schemaErrorf(loc, "schema must be an object or boolean")
+	}
+	if t, ok := m["type"]; ok {
+		names, ok := typeNames(t)
+		if !ok {
+			return schemaErrorf(loc+"/type", "must be a string or array of strings")
+		}
+		for _, n := range names {
+			if !slices.Contains(jsonTypes, n) {
+				return schemaErrorf(loc+"/type", "unknown type %q", n)
+			}
+		}
+	}
+	if r, ok := m["required"]; ok {
+		if _, ok := stringList(r); !ok {
+			return schemaErrorf(loc+"/required", "must be an array of strings")
+		}
+	}
+	if e, ok := m["enum"]; ok {
+		if _, ok := e.([]any); !ok {
+			return schemaErrorf(loc+"/enum", "must be an array")
+		}
+	}
+	for _, kw := range numberKeywords {
+		if n, ok := m[kw]; ok {
+			if _, ok := n.(float64); !ok {
+				return schemaErrorf(loc+"/"+kw, "must be a number")
+			}
+		}
+	}
+	if p, ok := m["pattern"]; ok {
+		str, ok := p.(string)
+		if !ok {
+			return schemaErrorf(loc+"/pattern", "must be a string")
+		}
+		re, err := regexp.Compile(str)
+		if err != nil {
+			return schemaErrorf(loc+"/pattern", "%v", err)
+		}
+		s.patterns[str] = re
+	}
+	if ref, ok := m["$ref"]; ok {
+		str, ok := ref.(string)
+		if !ok {
+			return schemaErrorf(loc+"/$ref", "must be a string")
+		}
+		target, err := s.resolve(str)
+		if err != nil {
+			return schemaErrorf(loc+"/$ref", "%v", err)
+		}
+		switch target.(type) {
+		case bool, map[string]any:
+		default:
+			return schemaErrorf(loc+"/$ref", "reference %q is not an object or boolean schema", str)
+		}
+		// Targets outside the keywords walked below still need their
+		// patterns cached and their keywords checked.
+		if !s.refs[str] {
+			s.refs[str] = true
+			if err := s.compile(target, strings.TrimPrefix(str, "#")); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, kw := range []string{"items", "additionalProperties", "not"} {
+		if sub, ok := m[kw]; ok {
+			if err := s.compile(sub, loc+"/"+kw); err != nil {
+				return err
+			}
+		}
+	}
+	for _, kw := range []string{"allOf", "anyOf", "oneOf"} {
+		sub, ok := m[kw]
+		if !ok {
+			continue
+		}
+		list, ok := sub.([]any)
+		if !ok || len(list) == 0 {
+			return schemaErrorf(loc+"/"+kw, "must be a non-empty array")
+		}
+		for i, item := range list {
+			if err := s.compile(item, loc+"/"+kw+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, kw := range []string{"properties", "$defs", "definitions"} {
+		sub, ok := m[kw]
+		if !ok {
+			continue
+		}
+		props, ok := sub.(map[string]any)
+		if !ok {
+			return schemaErrorf(loc+"/"+kw, "must be an object")
+		}
+		for name, item := range props {
+			if err := s.compile(item, loc+"/"+kw+"/"+escapePointer(name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve follows a local reference such as "#/$defs/address".
+func (s *Schema) resolve(ref string) (any, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("only local references are supported, got %q", ref)
+	}
+	node := s.root
+	if pointer == "" {
+		return node, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid reference %q", ref)
+	}
+	for _, tok := range strings.Split(pointer[1:], "/") {
+		tok = pointerUnescaper.Replace(tok)
+		switch n := node.(type) {
+		case map[string]any:
+			next, ok := n[tok]
+			if !ok {
+				return nil, fmt.Errorf("unresolved reference %q", ref)
+			}
+			node = next
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, fmt.Errorf("unresolved reference %q", ref)
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("unresolved reference %q", ref)
+		}
+	}
+	return node, nil
+}
+
+// validation accumulates violations for one Validate call.
+type validation struct {
+	schema     *Schema
+	violations []Violation
+}
+
+func (vs *validation) fail(path, keyword, format string, args ...any) {
+	vs.violations = append(vs.violations, Violation{
+		Path:    path,
+		Keyword: keyword,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// valid reports whether v satisfies node without recording violations.
+func (vs *validation) valid(node, v any, path string, depth int) bool {
+	sub := validation{schema: vs.schema}
+	sub.validate(node, v, path, depth)
+	return len(sub.violations) == 0
+}
+
+func (vs *validation) validate(node, v any, path string, depth int) {
+	if b, ok := node.(bool); ok {
+		if !b {
+			vs.fail(path, "false", "no value is allowed")
+		}
+		return
+	}
+	m := node.(map[string]any)
+
+	if ref, ok := m["$ref"].(string); ok {
+		if depth >= maxRefDepth {
+			vs.fail(path, "$ref", "reference depth exceeds %d", maxRefDepth)
+			return
+		}
+		target, _ := vs.schema.resolve(ref)
+		vs.validate(target, v, path, depth+1)
+	}
+
+	if t, ok := m["type"]; ok {
+		names, _ := typeNames(t)
+		if !slices.ContainsFunc(names, func(n string) bool { return hasType(v, n) }) {
+			vs.fail(path, "type", "expected %s, got %s", strings.Join(names, " or "), typeOf(v))
+			return
+		}
+	}
+	if e, ok := m["enum"].([]any); ok {
+		if !slices.ContainsFunc(e, func(x any) bool { return reflect.DeepEqual(x, v) }) {
+			vs.fail(path, "enum", "value must be one of %s", encodeList(e))
+		}
+	}
+	if c, ok := m["const"]; ok && !reflect.DeepEqual(c, v) {
+		vs.fail(path, "const", "value must be %s", encodeValue(c))
+	}
+
+	switch x := v.(type) {
+	case float64:
+		vs.validateNumber(m, x, path)
+	case string:
+		vs.validateString(m, x, path)
+	case []any:
+		vs.validateArray(m, x, path)
+	case map[string]any:
+		vs.validateObject(m, x, path)
+	}
+
+	if all, ok := m["allOf"].([]any); ok {
+		for _, sub := range all {
+			vs.validate(sub, v, path, depth)
+		}
+	}
+	if anyOf, ok := m["anyOf"].([]any); ok {
+		if !slices.ContainsFunc(anyOf, func(sub any) bool { return vs.valid(sub, v, path, depth) }) {
+			vs.fail(path, "anyOf", "value does not match any schema in anyOf")
+		}
+	}
+	if oneOf, ok := m["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if vs.valid(sub, v, path, depth) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			vs.fail(path, "oneOf", "value matches %d schemas in oneOf, want exactly 1", matched)
+		}
+	}
+	if not, ok := m["not"]; ok && vs.valid(not, v, path, depth) {
+		vs.fail(path, "not", "value must not match the schema in not")
+	}
+}
+
+func (vs *validation) validateNumber(m map[string]any, x float64, path string) {
+	if n, ok := number(m, "minimum"); ok && x < n {
+		vs.fail(path, "minimum", "value %v is less than %v", x, n)
+	}
+	if n, ok := number(m, "maximum"); ok && x > n {
+		vs.fail(path, "maximum", "value %v is greater than %v", x, n)
+	}
+	if n, ok := number(m, "exclusiveMinimum"); ok && x <= n {
+		vs.fail(path, "exclusiveMinimum", "value %v must be greater than %v", x, n)
+	}
+	if n, ok := number(m, "exclusiveMaximum"); ok && x >= n {
+		vs.fail(path, "exclusiveMaximum", "value %v must be less than %v", x, n)
+	}
+}
+
+func (vs *validation) validateString(m map[string]any, x, path string) {
+	length := float64(utf8.RuneCountInString(x))
+	if n, ok := number(m, "minLength"); ok && length < n {
+		vs.fail(path, "minLength", "length %v is less than %v", length, n)
+	}
+	if n, ok := number(m, "maxLength"); ok && length > n {
+		vs.fail(path, "maxLength", "length %v is greater than %v", length, n)
+	}
+	if p, ok := m["pattern"].(string); ok && !vs.schema.patterns[p].MatchString(x) {
+		vs.fail(path, "pattern", "value does not match pattern %q", p)
+	}
+}
+
+func (vs *validation) validateArray(m map[string]any, x []any, path string) {
+	length := float64(len(x))
+	if n, ok := number(m, "minItems"); ok && length < n {
+		vs.fail(path, "minItems", "array has %v items, want at least %v", length, n)
+	}
+	if n, ok := number(m, "maxItems"); ok && length > n {
+		vs.fail(path, "maxItems", "array has %v items, want at most %v", length, n)
+	}
+	if items, ok := m["items"]; ok {
+		for i, item := range x {
+			vs.validate(items, item, path+"/"+strconv.Itoa(i), 0)
+		}
+	}
+}
+
+func (vs *validation) validateObject(m map[string]any, x map[string]any, path string) {
+	if required, ok := stringList(m["required"]); ok {
+		for _, name := range required {
+			if _, ok := x[name]; !ok {
+				vs.fail(path, "required", "missing required property %q", name)
+			}
+		}
+	}
+	props, _ := m["properties"].(map[string]any)
+	additional, hasAdditional := m["additionalProperties"]
+
+	// Sorted keys keep violation order stable across runs.
+	keys := make([]string, 0, len(x))
+	for k := range x {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		child := path + "/" + escapePointer(k)
+		if sub, ok := props[k]; ok {
+			vs.validate(sub, x[k], child, 0)
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		if b, ok := additional.(bool); ok && !b {
+			vs.fail(child, "additionalProperties", "property %q is not allowed", k)
+			continue
+		}
+		vs.validate(additional, x[k], child, 0)
+	}
+}
+
+var (
+	jsonTypes      = []string{"null", "boolean", "integer", "number", "string", "array", "object"}
+	numberKeywords = []string{
+		"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+		"minLength", "maxLength", "minItems", "maxItems",
+	}
+)
+
+func schemaErrorf(loc, format string, args ...any) error {
+	if loc == "" {
+		loc = "(root)"
+	}
+	return fmt.Errorf("%w: %s: %s", ErrInvalidSchema, loc, fmt.Sprintf(format, args...))
+}
+
+func typeNames(t any) ([]string, bool) {
+	if s, ok := t.(string); ok {
+		return []string{s}, true
+	}
+	return stringList(t)
+}
+
+func stringList(v any) ([]string, bool) {
+	l, ok := v.([]any)
+	if !ok {
+		return nil, false
+	}
+	out := make([]string, len(l))
+	for i, item := range l {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		out[i] = s
+	}
+	return out, true
+}
+
+func number(m map[string]any, kw string) (float64, bool) {
+	v, ok := m[kw]
+	if !ok {
+		return 0, false
+	}
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func hasType(v any, name string) bool {
+	switch name {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f) && !math.IsInf(f, 0)
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	default:
+		return typeOf(v) == name
+	}
+}
+
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// jsonValue normalizes v to the types encoding/json decodes into.
+// Values that are already normalized are returned as is; anything else
+// is round-tripped through encoding/json.
+func jsonValue(v any) (any, error) {
+	if isJSONValue(v) {
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func isJSONValue(v any) bool {
+	switch x := v.(type) {
+	case nil, bool, float64, string:
+		return true
+	case []any:
+		return !slices.ContainsFunc(x, func(item any) bool { return !isJSONValue(item) })
+	case map[string]any:
+		for _, item := range x {
+			if !isJSONValue(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func encodeValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func encodeList(l []any) string {
+	parts := make([]string, len(l))
+	for i, v := range l {
+		parts[i] = encodeValue(v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+func escapePointer(s string) string {
+	return pointerEscaper.Replace(s)
+}
@@ -0,0 +1,231 @@
+package wire
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func mustSchema(t *testing.T, src string) *Schema {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(src), &m); err != nil {
+		t.Fatalf("unmarshal schema: %v", err)
+	}
+	s, err := CompileSchema(m)
+	if err != nil {
+		t.Fatalf("CompileSchema error: %v", err)
+	}
+	return s
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		value  string
+		want   []Violation
+	}{
+		{"type ok", `{"type":"string"}`, `"x"`, nil},
+		{"type mismatch", `{"type":"string"}`, `1`, []Violation{{"", "type", "expected string, got number"}}},
+		{"type union", `{"type":["string","null"]}`, `null`, nil},
+		{"integer", `{"type":"integer"}`, `1.5`, []Violation{{"", "type", "expected integer, got number"}}},
+		{"integer whole float", `{"type":"integer"}`, `2.0`, nil},
+		{"enum", `{"enum":["a","b"]}`, `"c"`, []Violation{{"", "enum", `value must be one of "a", "b"`}}},
+		{"enum object", `{"enum":[{"k":1}]}`, `{"k":1}`, nil},
+		{"const", `{"const":3}`, `4`, []Violation{{"", "const", "value must be 3"}}},
+		{"minimum", `{"minimum":1}`, `0`, []Violation{{"", "minimum", "value 0 is less than 1"}}},
+		{"maximum", `{"maximum":1}`, `2`, []Violation{{"", "maximum", "value 2 is greater than 1"}}},
+		{"exclusiveMinimum", `{"exclusiveMinimum":1}`, `1`, []Violation{{"", "exclusiveMinimum", "value 1 must be greater than 1"}}},
+		{"exclusiveMaximum", `{"exclusiveMaximum":1}`, `1`, []Violation{{"", "exclusiveMaximum", "value 1 must be less than 1"}}},
+		{"minLength runes", `{"minLength":2}`, `"é"`, []Violation{{"", "minLength", "length 1 is less than 2"}}},
+		{"maxLength", `{"maxLength":1}`, `"ab"`, []Violation{{"", "maxLength", "length 2 is greater than 1"}}},
+		{"pattern", `{"pattern":"^[a-z]+$"}`, `"A1"`, []Violation{{"", "pattern", `value does not match pattern "^[a-z]+$"`}}},
+		{"pattern unanchored", `{"pattern":"b"}`, `"abc"`, nil},
+		{"non-string ignores pattern", `{"pattern":"^a$"}`, `1`, nil},
+		{
+			"items", `{"type":"array","items":{"type":"number"},"maxItems":2}`, `[1,"x",3]`,
+			[]Violation{
+				{"", "maxItems", "array has 3 items, want at most 2"},
+				{"/1", "type", "expected number, got string"},
+			},
+		},
+		{"minItems", `{"minItems":1}`, `[]`, []Violation{{"", "minItems", "array has 0 items, want at least 1"}}},
+		{
+			"object", `{
+				"type":"object",
+				"properties":{"a/b":{"type":"string"},"n":{"type":"object","properties":{"x":{"type":"integer"}}}},
+				"required":["a/b","c"]
+			}`,
+			`{"a/b":1,"n":{"x":"y"}}`,
+			[]Violation{
+				{"", "required", `missing required property "c"`},
+				{"/a~1b", "type", "expected string, got number"},
+				{"/n/x", "type", "expected integer, got string"},
+			},
+		},
+		{
+			"additionalProperties false", `{"properties":{"a":{}},"additionalProperties":false}`, `{"a":1,"b":2}`,
+			[]Violation{{"/b", "additionalProperties", `property "b" is not allowed`}},
+		},
+		{
+			"additionalProperties schema", `{"additionalProperties":{"type":"boolean"}}`, `{"b":2}`,
+			[]Violation{{"/b", "type", "expected boolean, got number"}},
+		},
+		{
+			"allOf", `{"allOf":[{"minimum":0},{"maximum":5}]}`, `9`,
+			[]Violation{{"", "maximum", "value 9 is greater than 5"}},
+		},
+		{"anyOf ok", `{"anyOf":[{"type":"string"},{"type":"number"}]}`, `1`, nil},
+		{
+			"anyOf", `{"anyOf":[{"type":"string"},{"type":"number"}]}`, `true`,
+			[]Violation{{"", "anyOf", "value does not match any schema in anyOf"}},
+		},
+		{"oneOf ok", `{"oneOf":[{"type":"string"},{"type":"number"}]}`, `1`, nil},
+		{
+			"oneOf many", `{"oneOf":[{"type":"number"},{"minimum":0}]}`, `1`,
+			[]Violation{{"", "oneOf", "value matches 2 schemas in oneOf, want exactly 1"}},
+		},
+		{"not", `{"not":{"type":"null"}}`, `null`, []Violation{{"", "not", "value must not match the schema in not"}}},
+		{"false schema", `{"properties":{"x":false}}`, `{"x":1}`, []Violation{{"/x", "false", "no value is allowed"}}},
+		{
+			"ref", `{
+				"$defs":{"pos":{"type":"integer","minimum":1}},
+				"type":"object",
+				"properties":{"page":{"$ref":"#/$defs/pos"}}
+			}`,
+			`{"page":0}`,
+			[]Violation{{"/page", "minimum", "value 0 is less than 1"}},
+		},
+		{
+			"recursive ref", `{
+				"$defs":{"node":{"type":"object","properties":{"next":{"$ref":"#/$defs/node"},"v":{"type":"string"}}}},
+				"$ref":"#/$defs/node"
+			}`,
+			`{"next":{"next":{"v":1}}}`,
+			[]Violation{{"/next/next/v", "type", "expected string, got number"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := mustSchema(t, tt.schema)
+			var v any
+			if err := json.Unmarshal([]byte(tt.value), &v); err != nil {
+				t.Fatalf("unmarshal value: %v", err)
+			}
+			err := s.Validate(v)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate error: %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate error = %v, want *ValidationError", err)
+			}
+			if !reflect.DeepEqual(verr.Violations, tt.want) {
+				t.Errorf("violations = %+v, want %+v", verr.Violations, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_RefLoop(t *testing.T) {
+	s := mustSchema(t, `{"$defs":{"a":{"$ref":"#/$defs/a"}},"$ref":"#/$defs/a"}`)
+	var verr *ValidationError
+	if err := s.Validate(1); !errors.As(err, &verr) || verr.Violations[0].Keyword != "$ref" {
+		t.Errorf("Validate error = %v, want $ref depth violation", err)
+	}
+	// Recursion that descends into the instance is not limited.
+	list := mustSchema(t, `{"$defs":{"n":{"type":"array","items":{"$ref":"#/$defs/n"}}},"$ref":"#/$defs/n"}`)
+	var v any = []any{}
+	for range 2 * maxRefDepth {
+		v = []any{v}
+	}
+	if err := list.Validate(v); err != nil {
+		t.Errorf("Validate nested error: %v", err)
+	}
+}
+
+func TestSchema_RefOutsideKeywords(t *testing.T) {
+	// components is not a keyword, so only the reference reaches x.
+	s := mustSchema(t, `{"components":{"x":{"type":"string","pattern":"^a"}},"$ref":"#/components/x"}`)
+	if err := s.Validate("abc"); err != nil {
+		t.Errorf("Validate(abc) error = %v", err)
+	}
+	var verr *ValidationError
+	if err := s.Validate("xyz"); !errors.As(err, &verr) || verr.Violations[0].Keyword != "pattern" {
+		t.Errorf("Validate(xyz) error = %v, want pattern violation", err)
+	}
+}
+
+func TestCompileSchema_Errors(t *testing.T) {
+	tests := map[string]map[string]any{
+		"unknown type":  {"type": "text"},
+		"bad required":  {"required": "a"},
+		"bad pattern":   {"pattern": "("},
+		"bad minimum":   {"minimum": "1"},
+		"remote ref":    {"$ref": "https://example.com/schema.json"},
+		"missing ref":   {"$ref": "#/$defs/missing"},
+		"ref to array":  {"required": []any{"a"}, "$ref": "#/required"},
+		"ref to number": {"$ref": "#/minimum", "minimum": 1.0},
+		"unwalked bad pattern": {
+			"$ref":       "#/components/x",
+			"components": map[string]any{"x": map[string]any{"pattern": "("}},
+		},
+		"empty anyOf":   {"anyOf": []any{}},
+		"nested schema": {"properties": map[string]any{"a": "string"}},
+	}
+	for name, schema := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := CompileSchema(schema); !errors.Is(err, ErrInvalidSchema) {
+				t.Errorf("CompileSchema error = %v, want ErrInvalidSchema", err)
+			}
+		})
+	}
+}
+
+func TestValidateArguments(t *testing.T) {
+	tool := Tool{
+		Name: "search",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{"type": "string", "minLength": 1},
+				"limit": map[string]any{"type": "integer", "maximum": 100},
+				"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			},
+			"required": []string{"query"},
+		},
+	}
+
+	// Go-typed arguments validate like decoded JSON.
+	if err := ValidateArguments(tool, map[string]any{"query": "go", "limit": 10, "tags": []string{"a"}}); err != nil {
+		t.Errorf("ValidateArguments error: %v", err)
+	}
+	if err := ValidateArguments(Tool{Name: "any"}, nil); err != nil {
+		t.Errorf("ValidateArguments without schema error: %v", err)
+	}
+
+	err := ValidateArguments(tool, map[string]any{"limit": int64(200)})
+	if !errors.Is(err, ErrInvalidArguments) {
+		t.Fatalf("ValidateArguments error = %v, want ErrInvalidArguments", err)
+	}
+	want := `wire: invalid arguments: (root): missing required property "query"; /limit: value 200 is greater than 100`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	rpcErr := FromError(err)
+	if rpcErr.Code != CodeInvalidParams {
+		t.Errorf("FromError code = %d, want %d", rpcErr.Code, CodeInvalidParams)
+	}
+	data, _ := json.Marshal(rpcErr.Data)
+	wantData := `{"violations":[{"path":"","keyword":"required","message":"missing required property \"query\""},` +
+		`{"path":"/limit","keyword":"maximum","message":"value 200 is greater than 100"}]}`
+	if string(data) != wantData {
+		t.Errorf("FromError data = %s, want %s", data, wantData)
+	}
+}
@@ -68,6 +68,10 @@ type Response struct {
 	// Content is the response payload.
 	Content []Content
 
+	// StructuredContent is an optional structured result matching the
+	// tool's OutputSchema.
+	StructuredContent map[string]any
+
 	// IsError indicates if this is an error response.
 	IsError bool
 